@@ -3,15 +3,20 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/CP-Payne/exercise/cmd/migrate/migrations"
 	"github.com/CP-Payne/exercise/internal/application"
 	"github.com/CP-Payne/exercise/internal/domain"
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/CP-Payne/exercise/internal/infrastructure/migrate"
 	"github.com/CP-Payne/exercise/internal/infrastructure/persistence"
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
 	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
 	"github.com/CP-Payne/exercise/internal/interfaces/services"
 	"github.com/go-chi/chi/v5"
@@ -23,6 +28,10 @@ func NewApp(cfg *config) *app {
 	logger := zap.Must(zap.NewProduction()).Sugar()
 	defer logger.Sync()
 
+	if err := cfg.validate(); err != nil {
+		logger.Fatal(err)
+	}
+
 	db, err := persistence.NewDB(cfg.db.addr, cfg.db.maxOpenConns, cfg.db.maxIdleConns, cfg.db.maxIdleTime)
 	if err != nil {
 		logger.Fatal(err)
@@ -31,13 +40,38 @@ func NewApp(cfg *config) *app {
 	// defer db.Close()
 	logger.Info("database connection pool established")
 
+	if cfg.db.autoMigrate {
+		migrator, err := migrate.New(db, migrations.FS)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if err := migrator.Up(context.Background()); err != nil {
+			logger.Fatal(err)
+		}
+		logger.Info("database migrations applied")
+	}
+
 	// Setting up routes
 	router := chi.NewRouter()
 
+	authenticator := auth.NewJWTAuthenticator(
+		cfg.auth.token.secret,
+		cfg.auth.token.iss,
+		cfg.auth.token.aud,
+		cfg.auth.token.exp,
+	)
+
 	repos := repositories.NewRepositories(db)
-	domainServices := domain.NewDomainServices(repos)
-	applicationUseCases := application.NewUseCases(*domainServices)
-	applicationHandlers := services.NewHandlers(applicationUseCases, logger)
+	domainServices := domain.NewDomainServices(repos, domain.Config{
+		RefreshTokenTTL: cfg.auth.refresh.exp,
+	})
+	images, err := newImageStorage(cfg)
+	if err != nil {
+		logger.Fatal(err)
+	}
+
+	applicationUseCases := application.NewUseCases(*domainServices, repos.Transactor, authenticator, images)
+	applicationHandlers := services.NewHandlers(applicationUseCases, authenticator, logger)
 	applicationHandlers.RegisterRoutes(router)
 
 	return &app{
@@ -49,6 +83,36 @@ func NewApp(cfg *config) *app {
 
 }
 
+// newImageStorage sets up the blob store of the configured backend and the signer of image URLs
+func newImageStorage(cfg *config) (application.ImageStorage, error) {
+	signer, err := storage.NewURLSigner(cfg.images.secret, cfg.images.publicURL, cfg.images.ttl)
+	if err != nil {
+		return application.ImageStorage{}, err
+	}
+
+	var blobs storage.BlobStore
+	switch cfg.storage.backend {
+	case "local":
+		blobs, err = storage.NewLocalStore(cfg.storage.dir)
+	case "s3":
+		blobs, err = storage.NewS3Store(storage.S3Config{
+			Endpoint:        cfg.storage.s3.endpoint,
+			Region:          cfg.storage.s3.region,
+			Bucket:          cfg.storage.s3.bucket,
+			AccessKeyID:     cfg.storage.s3.accessKeyID,
+			SecretAccessKey: cfg.storage.s3.secretAccessKey,
+			PathStyle:       cfg.storage.s3.pathStyle,
+		}, &http.Client{Timeout: 30 * time.Second})
+	default:
+		err = fmt.Errorf("unknown storage backend %q, expected local or s3", cfg.storage.backend)
+	}
+	if err != nil {
+		return application.ImageStorage{}, err
+	}
+
+	return application.ImageStorage{Blobs: blobs, Signer: signer}, nil
+}
+
 func (app *app) run() error {
 	srv := &http.Server{
 		Addr:         app.config.addr,
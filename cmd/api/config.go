@@ -2,15 +2,34 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 type config struct {
-	addr string
-	env  string
-	db   dbConfig
+	addr    string
+	env     string
+	db      dbConfig
+	auth    authConfig
+	storage storageConfig
+	images  imagesConfig
+}
+
+// minSecretLength is the shortest secret accepted for signing tokens and image URLs, in bytes
+const minSecretLength = 32
+
+// validate reports the settings the API cannot run safely with
+func (cfg *config) validate() error {
+	if len(cfg.auth.token.secret) < minSecretLength {
+		return fmt.Errorf("AUTH_TOKEN_SECRET must be set to a secret of at least %d characters", minSecretLength)
+	}
+	if len(cfg.images.secret) < minSecretLength {
+		return fmt.Errorf("IMAGE_URL_SECRET must be set to a secret of at least %d characters", minSecretLength)
+	}
+	return nil
 }
 
 type app struct {
@@ -25,4 +44,42 @@ type dbConfig struct {
 	maxOpenConns int
 	maxIdleConns int
 	maxIdleTime  string
+	autoMigrate  bool
+}
+
+type authConfig struct {
+	token   tokenConfig
+	refresh refreshConfig
+}
+
+type tokenConfig struct {
+	secret string
+	exp    time.Duration
+	iss    string
+	aud    string
+}
+
+type refreshConfig struct {
+	exp time.Duration
+}
+
+type storageConfig struct {
+	backend string
+	dir     string
+	s3      s3Config
+}
+
+type s3Config struct {
+	endpoint        string
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	pathStyle       bool
+}
+
+type imagesConfig struct {
+	secret    string
+	publicURL string
+	ttl       time.Duration
 }
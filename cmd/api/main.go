@@ -1,6 +1,8 @@
 package main
 
 import (
+	"time"
+
 	"github.com/CP-Payne/exercise/internal/env"
 )
 
@@ -14,6 +16,35 @@ func main() {
 			maxOpenConns: env.GetInt("DB_MAX_OPEN_CONNS", 30),
 			maxIdleConns: env.GetInt("DB_MAX_IDLE_CONNS", 30),
 			maxIdleTime:  env.GetString("DB_MAX_IDLE_TIME", "15m"),
+			autoMigrate:  env.GetBool("DB_AUTO_MIGRATE", false),
+		},
+		auth: authConfig{
+			token: tokenConfig{
+				secret: env.GetString("AUTH_TOKEN_SECRET", ""),
+				exp:    time.Minute * time.Duration(env.GetInt("AUTH_TOKEN_EXP_MINUTES", 15)),
+				iss:    "exercise-api",
+				aud:    "exercise-api",
+			},
+			refresh: refreshConfig{
+				exp: time.Hour * time.Duration(env.GetInt("AUTH_REFRESH_EXP_HOURS", 24*30)),
+			},
+		},
+		storage: storageConfig{
+			backend: env.GetString("STORAGE_BACKEND", "local"),
+			dir:     env.GetString("STORAGE_DIR", "./uploads"),
+			s3: s3Config{
+				endpoint:        env.GetString("S3_ENDPOINT", "http://localhost:9000"),
+				region:          env.GetString("S3_REGION", "us-east-1"),
+				bucket:          env.GetString("S3_BUCKET", "exercise-images"),
+				accessKeyID:     env.GetString("S3_ACCESS_KEY_ID", ""),
+				secretAccessKey: env.GetString("S3_SECRET_ACCESS_KEY", ""),
+				pathStyle:       env.GetBool("S3_PATH_STYLE", true),
+			},
+		},
+		images: imagesConfig{
+			secret:    env.GetString("IMAGE_URL_SECRET", ""),
+			publicURL: env.GetString("PUBLIC_URL", "http://localhost:3000"),
+			ttl:       time.Minute * time.Duration(env.GetInt("IMAGE_URL_TTL_MINUTES", 60)),
 		},
 	}
 
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS refresh_tokens(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    family_id UUID NOT NULL,
+    token_hash bytea UNIQUE NOT NULL,
+    expires_at TIMESTAMP(0) with time zone NOT NULL,
+    consumed_at TIMESTAMP(0) with time zone,
+    revoked_at TIMESTAMP(0) with time zone,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_refresh_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS exercise_splits;
+DROP TABLE IF EXISTS exercise_equipment;
+DROP TABLE IF EXISTS exercise_target_muscles;
+DROP TABLE IF EXISTS exercises;
\ No newline at end of file
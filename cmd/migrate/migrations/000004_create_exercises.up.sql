@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS exercises(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    display_image TEXT NOT NULL DEFAULT '',
+    category VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_exercises_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercises_user_id ON exercises(user_id);
+
+CREATE TABLE IF NOT EXISTS exercise_target_muscles(
+    exercise_id UUID NOT NULL,
+    muscle_id UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (exercise_id, muscle_id),
+    CONSTRAINT fk_exercise_target_muscles_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    CONSTRAINT fk_exercise_target_muscles_muscle FOREIGN KEY (muscle_id) REFERENCES target_muscles(id) ON DELETE CASCADE
+);
+
+-- equipment and splits have no tables yet, their foreign keys are added by the migrations that create them
+CREATE TABLE IF NOT EXISTS exercise_equipment(
+    exercise_id UUID NOT NULL,
+    equipment_id UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (exercise_id, equipment_id),
+    CONSTRAINT fk_exercise_equipment_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS exercise_splits(
+    exercise_id UUID NOT NULL,
+    split_id UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (exercise_id, split_id),
+    CONSTRAINT fk_exercise_splits_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
\ No newline at end of file
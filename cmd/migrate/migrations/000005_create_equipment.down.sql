@@ -0,0 +1,2 @@
+ALTER TABLE exercise_equipment DROP CONSTRAINT IF EXISTS fk_exercise_equipment_equipment;
+DROP TABLE IF EXISTS equipment;
\ No newline at end of file
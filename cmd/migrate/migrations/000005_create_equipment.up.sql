@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS equipment(
+    id UUID PRIMARY KEY,
+    equipment_name VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_equipment_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS equipment_user_id_equipment_name_key ON equipment(user_id, lower(equipment_name));
+
+ALTER TABLE exercise_equipment
+    ADD CONSTRAINT fk_exercise_equipment_equipment FOREIGN KEY (equipment_id) REFERENCES equipment(id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE exercise_splits DROP CONSTRAINT IF EXISTS fk_exercise_splits_split;
+DROP TABLE IF EXISTS split_day_exercises;
+DROP TABLE IF EXISTS split_days;
+DROP TABLE IF EXISTS splits;
\ No newline at end of file
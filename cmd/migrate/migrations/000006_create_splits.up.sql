@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS splits(
+    id UUID PRIMARY KEY,
+    split_name VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_splits_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS splits_user_id_split_name_key ON splits(user_id, lower(split_name));
+
+CREATE TABLE IF NOT EXISTS split_days(
+    id UUID PRIMARY KEY,
+    split_id UUID NOT NULL,
+    day_name VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    CONSTRAINT fk_split_days_split FOREIGN KEY (split_id) REFERENCES splits(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_split_days_split_id ON split_days(split_id);
+
+CREATE TABLE IF NOT EXISTS split_day_exercises(
+    split_day_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (split_day_id, exercise_id),
+    CONSTRAINT fk_split_day_exercises_day FOREIGN KEY (split_day_id) REFERENCES split_days(id) ON DELETE CASCADE,
+    CONSTRAINT fk_split_day_exercises_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+ALTER TABLE exercise_splits
+    ADD CONSTRAINT fk_exercise_splits_split FOREIGN KEY (split_id) REFERENCES splits(id) ON DELETE CASCADE;
\ No newline at end of file
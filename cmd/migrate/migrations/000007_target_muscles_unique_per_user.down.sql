@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS target_muscles_user_id_muscle_name_key;
+
+ALTER TABLE target_muscles ADD CONSTRAINT target_muscles_muscle_name_key UNIQUE (muscle_name);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE target_muscles DROP CONSTRAINT IF EXISTS target_muscles_muscle_name_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS target_muscles_user_id_muscle_name_key ON target_muscles(user_id, lower(muscle_name));
\ No newline at end of file
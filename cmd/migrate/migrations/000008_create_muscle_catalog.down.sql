@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS hidden_catalog_muscles;
+
+DROP INDEX IF EXISTS target_muscles_catalog_muscle_name_key;
+
+DELETE FROM target_muscles WHERE user_id IS NULL;
+
+ALTER TABLE target_muscles ALTER COLUMN user_id SET NOT NULL;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Catalog muscles are owned by no user and are visible to everyone
+ALTER TABLE target_muscles ALTER COLUMN user_id DROP NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS target_muscles_catalog_muscle_name_key ON target_muscles(lower(muscle_name)) WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS hidden_catalog_muscles(
+    user_id UUID NOT NULL,
+    muscle_id UUID NOT NULL,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, muscle_id),
+    CONSTRAINT fk_hidden_catalog_muscles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_hidden_catalog_muscles_muscle FOREIGN KEY (muscle_id) REFERENCES target_muscles(id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS target_muscles_parent_id_idx;
+
+ALTER TABLE target_muscles DROP CONSTRAINT IF EXISTS fk_target_muscles_parent;
+ALTER TABLE target_muscles DROP COLUMN IF EXISTS parent_id;
+
+ALTER TABLE target_muscles DROP CONSTRAINT IF EXISTS target_muscles_kind_check;
+ALTER TABLE target_muscles DROP COLUMN IF EXISTS kind;
\ No newline at end of file
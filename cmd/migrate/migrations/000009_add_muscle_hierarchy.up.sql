@@ -0,0 +1,8 @@
+-- Muscles form a tree: region, group, muscle and head
+ALTER TABLE target_muscles ADD COLUMN IF NOT EXISTS kind VARCHAR(10) NOT NULL DEFAULT 'muscle';
+ALTER TABLE target_muscles ADD CONSTRAINT target_muscles_kind_check CHECK (kind IN ('region', 'group', 'muscle', 'head'));
+
+ALTER TABLE target_muscles ADD COLUMN IF NOT EXISTS parent_id UUID;
+ALTER TABLE target_muscles ADD CONSTRAINT fk_target_muscles_parent FOREIGN KEY (parent_id) REFERENCES target_muscles(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS target_muscles_parent_id_idx ON target_muscles(parent_id);
\ No newline at end of file
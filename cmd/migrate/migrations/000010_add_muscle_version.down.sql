@@ -0,0 +1 @@
+ALTER TABLE target_muscles DROP COLUMN IF EXISTS version;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Incremented on every update so that concurrent updates can be detected
+ALTER TABLE target_muscles ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
\ No newline at end of file
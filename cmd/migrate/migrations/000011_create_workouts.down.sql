@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS workout_sets;
+DROP TABLE IF EXISTS workout_entries;
+DROP TABLE IF EXISTS workout_sessions;
\ No newline at end of file
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS workout_sessions(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    session_name VARCHAR(255) NOT NULL DEFAULT '',
+    notes TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP(0) with time zone NOT NULL,
+    finished_at TIMESTAMP(0) with time zone,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_workout_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sessions_user_id_started_at ON workout_sessions(user_id, started_at);
+
+-- Logged exercises keep their history, an exercise cannot be deleted while a workout refers to it
+CREATE TABLE IF NOT EXISTS workout_entries(
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    position INT NOT NULL,
+    CONSTRAINT fk_workout_entries_session FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_workout_entries_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_entries_session_id ON workout_entries(session_id);
+CREATE INDEX IF NOT EXISTS idx_workout_entries_exercise_id ON workout_entries(exercise_id);
+
+CREATE TABLE IF NOT EXISTS workout_sets(
+    id UUID PRIMARY KEY,
+    entry_id UUID NOT NULL,
+    position INT NOT NULL,
+    reps INT NOT NULL CHECK (reps > 0),
+    weight NUMERIC(7, 2) NOT NULL DEFAULT 0 CHECK (weight >= 0),
+    unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (unit IN ('kg', 'lb')),
+    rpe NUMERIC(3, 1) CHECK (rpe BETWEEN 1 AND 10),
+    rir SMALLINT CHECK (rir BETWEEN 0 AND 10),
+    warmup BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT fk_workout_sets_entry FOREIGN KEY (entry_id) REFERENCES workout_entries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_sets_entry_id ON workout_sets(entry_id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE workout_entries DROP COLUMN IF EXISTS target_unit;
+ALTER TABLE workout_entries DROP COLUMN IF EXISTS target_weight;
+ALTER TABLE workout_entries DROP COLUMN IF EXISTS target_max_reps;
+ALTER TABLE workout_entries DROP COLUMN IF EXISTS target_min_reps;
+ALTER TABLE workout_entries DROP COLUMN IF EXISTS target_sets;
+DROP TABLE IF EXISTS routine_exercises;
+DROP TABLE IF EXISTS routines;
\ No newline at end of file
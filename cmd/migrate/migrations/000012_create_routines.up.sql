@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS routines(
+    id UUID PRIMARY KEY,
+    routine_name VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_routines_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS routines_user_id_routine_name_key ON routines(user_id, lower(routine_name));
+
+CREATE TABLE IF NOT EXISTS routine_exercises(
+    id UUID PRIMARY KEY,
+    routine_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    position INT NOT NULL,
+    target_sets INT NOT NULL CHECK (target_sets > 0),
+    min_reps INT NOT NULL CHECK (min_reps > 0),
+    max_reps INT NOT NULL CHECK (max_reps >= min_reps),
+    CONSTRAINT fk_routine_exercises_routine FOREIGN KEY (routine_id) REFERENCES routines(id) ON DELETE CASCADE,
+    CONSTRAINT fk_routine_exercises_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_routine_exercises_routine_id ON routine_exercises(routine_id);
+
+-- Entries of sessions started from a routine keep the target they were planned with, entries added by hand have none
+ALTER TABLE workout_entries ADD COLUMN IF NOT EXISTS target_sets INT;
+ALTER TABLE workout_entries ADD COLUMN IF NOT EXISTS target_min_reps INT;
+ALTER TABLE workout_entries ADD COLUMN IF NOT EXISTS target_max_reps INT;
+ALTER TABLE workout_entries ADD COLUMN IF NOT EXISTS target_weight NUMERIC(7, 2);
+ALTER TABLE workout_entries ADD COLUMN IF NOT EXISTS target_unit VARCHAR(2);
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- Weights and volumes are stored in kg whatever unit the set was logged in
+CREATE TABLE IF NOT EXISTS personal_records(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    session_id UUID NOT NULL,
+    set_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('heaviest_weight', 'reps_at_weight', 'estimated_1rm', 'session_volume')),
+    value NUMERIC(10, 2) NOT NULL CHECK (value > 0),
+    weight NUMERIC(7, 2) NOT NULL DEFAULT 0,
+    achieved_at TIMESTAMP(0) with time zone NOT NULL,
+    CONSTRAINT fk_personal_records_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_exercise FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_session FOREIGN KEY (session_id) REFERENCES workout_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_set FOREIGN KEY (set_id) REFERENCES workout_sets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_personal_records_user_id_exercise_id ON personal_records(user_id, exercise_id, achieved_at);
+CREATE INDEX IF NOT EXISTS idx_personal_records_session_id ON personal_records(session_id);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE users DROP COLUMN IF EXISTS one_rep_max_formula;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- The formula used to estimate one-rep maxes for the user
+ALTER TABLE users ADD COLUMN IF NOT EXISTS one_rep_max_formula VARCHAR(20) NOT NULL DEFAULT 'epley'
+    CHECK (one_rep_max_formula IN ('epley', 'brzycki', 'lombardi', 'rpe'));
\ No newline at end of file
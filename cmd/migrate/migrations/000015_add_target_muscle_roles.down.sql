@@ -0,0 +1,2 @@
+ALTER TABLE exercise_target_muscles DROP COLUMN IF EXISTS activation;
+ALTER TABLE exercise_target_muscles DROP COLUMN IF EXISTS role;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Existing links were ordered with the main muscle first, the others become secondary
+ALTER TABLE exercise_target_muscles ADD COLUMN IF NOT EXISTS role VARCHAR(10) NOT NULL DEFAULT 'secondary'
+    CHECK (role IN ('primary', 'secondary', 'stabilizer'));
+UPDATE exercise_target_muscles SET role = 'primary' WHERE position = 0;
+ALTER TABLE exercise_target_muscles ALTER COLUMN role SET DEFAULT 'primary';
+
+-- The fraction of a set that counts for the muscle, NULL for the default of the role
+ALTER TABLE exercise_target_muscles ADD COLUMN IF NOT EXISTS activation NUMERIC(3, 2)
+    CHECK (activation > 0 AND activation <= 1);
\ No newline at end of file
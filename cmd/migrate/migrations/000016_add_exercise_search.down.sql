@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_exercises_search_text;
+DROP INDEX IF EXISTS idx_exercises_search_vector;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS search_text;
+ALTER TABLE exercises DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS exercise_aliases_text(TEXT[]);
+ALTER TABLE exercises DROP COLUMN IF EXISTS aliases;
+
+-- pg_trgm is left installed, it may have been installed before the migration
\ No newline at end of file
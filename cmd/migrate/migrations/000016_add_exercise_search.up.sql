@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS aliases TEXT[] NOT NULL DEFAULT '{}';
+
+-- array_to_string is only stable, generated columns need an immutable function
+CREATE OR REPLACE FUNCTION exercise_aliases_text(aliases TEXT[]) RETURNS TEXT
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT array_to_string(aliases, ' ') $$;
+
+-- Names weigh the most, then aliases, then descriptions
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', exercise_aliases_text(aliases)), 'B') ||
+    setweight(to_tsvector('english', description), 'C')
+) STORED;
+
+-- Names and aliases are also matched by trigram, to tolerate typos
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS search_text TEXT GENERATED ALWAYS AS (
+    lower(name || ' ' || exercise_aliases_text(aliases))
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_exercises_search_vector ON exercises USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_exercises_search_text ON exercises USING GIN (search_text gin_trgm_ops);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS equipment_profile_items;
+DROP TABLE IF EXISTS equipment_profiles;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS equipment_profiles(
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    profile_name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP(0) with time zone NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_equipment_profiles_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS equipment_profiles_user_id_profile_name_key ON equipment_profiles(user_id, lower(profile_name));
+
+CREATE TABLE IF NOT EXISTS equipment_profile_items(
+    profile_id UUID NOT NULL,
+    equipment_id UUID NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (profile_id, equipment_id),
+    CONSTRAINT fk_equipment_profile_items_profile FOREIGN KEY (profile_id) REFERENCES equipment_profiles(id) ON DELETE CASCADE,
+    CONSTRAINT fk_equipment_profile_items_equipment FOREIGN KEY (equipment_id) REFERENCES equipment(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_equipment_profile_items_equipment_id ON equipment_profile_items(equipment_id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_exercises_user_id_movement_pattern;
+ALTER TABLE exercises DROP COLUMN IF EXISTS movement_pattern;
\ No newline at end of file
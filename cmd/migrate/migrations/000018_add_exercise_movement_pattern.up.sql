@@ -0,0 +1,6 @@
+-- The basic movement an exercise trains, empty for none
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS movement_pattern VARCHAR(20) NOT NULL DEFAULT ''
+    CHECK (movement_pattern IN ('', 'squat', 'hinge', 'lunge', 'horizontal_push', 'vertical_push', 'horizontal_pull', 'vertical_pull', 'carry'));
+
+-- Alternatives are looked up by the pattern of the exercise they replace
+CREATE INDEX IF NOT EXISTS idx_exercises_user_id_movement_pattern ON exercises(user_id, movement_pattern) WHERE movement_pattern <> '';
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS display_image TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS image_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Exercises keep the ID of their uploaded image, URLs to it are signed whenever they are handed out
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS image_id UUID;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS display_image;
\ No newline at end of file
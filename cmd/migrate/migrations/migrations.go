@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations so binaries can apply them without the source tree.
+package migrations
+
+import "embed"
+
+// FS holds the up and down files of every migration
+//
+//go:embed *.sql
+var FS embed.FS
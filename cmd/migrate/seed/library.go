@@ -0,0 +1,324 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/CP-Payne/exercise/internal/domain"
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// libraryEquipment holds the names of the equipment in the library
+//
+//go:embed data/equipment.json
+var libraryEquipment []byte
+
+// libraryExercises holds the exercises in the library with their muscle and equipment links
+//
+//go:embed data/exercises.json
+var libraryExercises []byte
+
+// librarySplits holds the splits in the library with the exercises trained on each day
+//
+//go:embed data/splits.json
+var librarySplits []byte
+
+// libraryExercise is an exercise in the embedded library, links refer to muscles and equipment by name
+type libraryExercise struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Category    string   `json:"category"`
+	Pattern     string   `json:"pattern"`
+	Description string   `json:"description"`
+	Primary     []string `json:"primary"`
+	Secondary   []string `json:"secondary"`
+	Equipment   []string `json:"equipment"`
+}
+
+// librarySplit is a split in the embedded library, exercises are referred to by name
+type librarySplit struct {
+	Name string `json:"name"`
+	Days []struct {
+		Name      string   `json:"name"`
+		Exercises []string `json:"exercises"`
+	} `json:"days"`
+}
+
+// seedLibrary adds the equipment, exercises and splits of the library the user does not have yet.
+// Entries are matched by case-insensitive name, so entries the user already has or renamed to a library name are kept.
+func seedLibrary(ctx context.Context, services *domain.DomainServices, userID uuid.UUID, muscleIDs map[string]uuid.UUID, logger *zap.SugaredLogger) error {
+	equipmentIDs, added, err := seedEquipment(ctx, services.Equipment, userID)
+	if err != nil {
+		return err
+	}
+	logger.Infow("seeded equipment", "added", added, "total", len(equipmentIDs))
+
+	exerciseIDs, added, err := seedExercises(ctx, services.Exercise, userID, muscleIDs, equipmentIDs)
+	if err != nil {
+		return err
+	}
+	logger.Infow("seeded exercises", "added", added, "total", len(exerciseIDs))
+
+	added, err = seedSplits(ctx, services.Split, userID, exerciseIDs)
+	if err != nil {
+		return err
+	}
+	logger.Infow("seeded splits", "added", added)
+
+	return nil
+}
+
+// seedEquipment adds the library equipment and returns the IDs of all the user's equipment by lower case name
+func seedEquipment(ctx context.Context, service equipment.EquipmentService, userID uuid.UUID) (map[string]uuid.UUID, int, error) {
+	var names []string
+	if err := json.Unmarshal(libraryEquipment, &names); err != nil {
+		return nil, 0, err
+	}
+
+	existing, err := listAll(ctx, func(ctx context.Context, q listquery.Query) (listquery.Page[*equipment.Equipment], error) {
+		return service.ListEquipment(ctx, userID, q)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(existing)+len(names))
+	for _, e := range existing {
+		ids[strings.ToLower(e.GetName())] = e.GetId()
+	}
+
+	added := 0
+	for _, name := range names {
+		if _, ok := ids[strings.ToLower(name)]; ok {
+			continue
+		}
+
+		e, err := equipment.NewEquipment(equipment.EquipmentParams{Name: name})
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := service.AddEquipment(ctx, userID, e); err != nil {
+			return nil, 0, fmt.Errorf("adding equipment %q: %w", name, err)
+		}
+
+		ids[strings.ToLower(name)] = e.GetId()
+		added++
+	}
+
+	return ids, added, nil
+}
+
+// seedExercises adds the library exercises and returns the IDs of all the user's exercises by lower case name
+func seedExercises(ctx context.Context, service exercise.ExerciseService, userID uuid.UUID, muscleIDs, equipmentIDs map[string]uuid.UUID) (map[string]uuid.UUID, int, error) {
+	var exercises []libraryExercise
+	if err := json.Unmarshal(libraryExercises, &exercises); err != nil {
+		return nil, 0, err
+	}
+
+	existing, err := listAll(ctx, func(ctx context.Context, q listquery.Query) (listquery.Page[*exercise.Exercise], error) {
+		return service.ListExercises(ctx, userID, q, exercise.ListFilter{})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make(map[string]uuid.UUID, len(existing)+len(exercises))
+	byName := make(map[string]*exercise.Exercise, len(existing))
+	for _, e := range existing {
+		ids[strings.ToLower(e.GetName())] = e.GetID()
+		byName[strings.ToLower(e.GetName())] = e
+	}
+
+	added := 0
+	for _, le := range exercises {
+		if e, ok := byName[strings.ToLower(le.Name)]; ok {
+			if err := backfillMovementPattern(ctx, service, userID, e, le); err != nil {
+				return nil, 0, err
+			}
+			continue
+		}
+
+		targetMuscles, err := resolveTargetMuscles(muscleIDs, le)
+		if err != nil {
+			return nil, 0, fmt.Errorf("exercise %q: muscle %w", le.Name, err)
+		}
+		exerciseEquipmentIDs, err := resolve(equipmentIDs, le.Equipment)
+		if err != nil {
+			return nil, 0, fmt.Errorf("exercise %q: equipment %w", le.Name, err)
+		}
+
+		e, err := exercise.NewExercise(exercise.ExerciseParams{
+			Name:            le.Name,
+			Description:     le.Description,
+			Aliases:         libraryAliases(le),
+			Category:        le.Category,
+			MovementPattern: exercise.MovementPattern(le.Pattern),
+			TargetMuscles:   targetMuscles,
+			EquipmentIDs:    exerciseEquipmentIDs,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := service.AddExercise(ctx, userID, e); err != nil {
+			return nil, 0, fmt.Errorf("adding exercise %q: %w", le.Name, err)
+		}
+
+		ids[strings.ToLower(le.Name)] = e.GetID()
+		added++
+	}
+
+	return ids, added, nil
+}
+
+// backfillMovementPattern gives an exercise seeded before the library had movement patterns the pattern of
+// its library entry. Exercises that already have a pattern are left as the user set them.
+func backfillMovementPattern(ctx context.Context, service exercise.ExerciseService, userID uuid.UUID, e *exercise.Exercise, le libraryExercise) error {
+	if e.GetMovementPattern() != "" || le.Pattern == "" {
+		return nil
+	}
+	if err := e.SetMovementPattern(exercise.MovementPattern(le.Pattern)); err != nil {
+		return fmt.Errorf("exercise %q: %w", le.Name, err)
+	}
+	if err := service.UpdateExercise(ctx, userID, e); err != nil {
+		return fmt.Errorf("updating exercise %q: %w", le.Name, err)
+	}
+	return nil
+}
+
+// seedSplits adds the library splits the user does not have yet
+func seedSplits(ctx context.Context, service split.SplitService, userID uuid.UUID, exerciseIDs map[string]uuid.UUID) (int, error) {
+	var splits []librarySplit
+	if err := json.Unmarshal(librarySplits, &splits); err != nil {
+		return 0, err
+	}
+
+	existing, err := listAll(ctx, func(ctx context.Context, q listquery.Query) (listquery.Page[*split.Split], error) {
+		return service.ListSplits(ctx, userID, q)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	names := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		names[strings.ToLower(s.GetName())] = true
+	}
+
+	added := 0
+	for _, ls := range splits {
+		if names[strings.ToLower(ls.Name)] {
+			continue
+		}
+
+		days := make([]split.DayParams, 0, len(ls.Days))
+		for _, d := range ls.Days {
+			ids, err := resolve(exerciseIDs, d.Exercises)
+			if err != nil {
+				return 0, fmt.Errorf("split %q: exercise %w", ls.Name, err)
+			}
+			days = append(days, split.DayParams{Name: d.Name, ExerciseIDs: ids})
+		}
+
+		s, err := split.NewSplit(split.SplitParams{Name: ls.Name, Days: days})
+		if err != nil {
+			return 0, err
+		}
+		if err := service.AddSplit(ctx, userID, s); err != nil {
+			return 0, fmt.Errorf("adding split %q: %w", ls.Name, err)
+		}
+		added++
+	}
+
+	return added, nil
+}
+
+// resolve looks up the IDs of names, skipping names that are listed twice
+func resolve(ids map[string]uuid.UUID, names []string) ([]uuid.UUID, error) {
+	resolved := make([]uuid.UUID, 0, len(names))
+	seen := make(map[uuid.UUID]bool, len(names))
+
+	for _, name := range names {
+		id, ok := ids[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("%q is not in the library", name)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		resolved = append(resolved, id)
+	}
+	return resolved, nil
+}
+
+// abbreviations replaces equipment names with the short forms lifters search for
+var abbreviations = strings.NewReplacer("Dumbbell", "DB", "Barbell", "BB", "Kettlebell", "KB")
+
+// libraryAliases returns the aliases of a library exercise followed by its name with the equipment abbreviated.
+// The exercise deduplicates aliases that are repeated.
+func libraryAliases(le libraryExercise) []string {
+	aliases := slices.Clone(le.Aliases)
+	if abbreviated := abbreviations.Replace(le.Name); abbreviated != le.Name {
+		aliases = append(aliases, abbreviated)
+	}
+	return aliases
+}
+
+// resolveTargetMuscles links the primary and secondary muscles of a library exercise with their roles.
+// A muscle listed as both is primary.
+func resolveTargetMuscles(ids map[string]uuid.UUID, le libraryExercise) ([]exercise.TargetMuscle, error) {
+	primary, err := resolve(ids, le.Primary)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := resolve(ids, le.Secondary)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]exercise.TargetMuscle, 0, len(primary)+len(secondary))
+	for _, id := range primary {
+		links = append(links, exercise.TargetMuscle{MuscleID: id, Role: exercise.RolePrimary})
+	}
+	for _, id := range secondary {
+		if !slices.Contains(primary, id) {
+			links = append(links, exercise.TargetMuscle{MuscleID: id, Role: exercise.RoleSecondary})
+		}
+	}
+	return links, nil
+}
+
+// listAll collects every page of a list
+func listAll[T any](ctx context.Context, list func(context.Context, listquery.Query) (listquery.Page[T], error)) ([]T, error) {
+	values := url.Values{"limit": {strconv.Itoa(listquery.MaxLimit)}}
+
+	var items []T
+	for {
+		q, err := listquery.Parse(values)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := list(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+
+		if page.NextCursor == "" {
+			return items, nil
+		}
+		values.Set("cursor", page.NextCursor)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namesOf assigns an ID to every name and fails on case-insensitive duplicates
+func namesOf(t *testing.T, names []string) map[string]uuid.UUID {
+	t.Helper()
+
+	ids := make(map[string]uuid.UUID, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		assert.NotContains(t, ids, key, "%q is listed twice", name)
+		ids[key] = uuid.New()
+	}
+	return ids
+}
+
+func catalogMuscleNames(muscles []catalogMuscle) []string {
+	var names []string
+	for _, m := range muscles {
+		names = append(names, m.Name)
+		names = append(names, catalogMuscleNames(m.Children)...)
+	}
+	return names
+}
+
+// TestLibrary_ReferencesResolve checks that every link in the embedded dataset refers to an entry that is seeded
+func TestLibrary_ReferencesResolve(t *testing.T) {
+	var muscles []catalogMuscle
+	require.NoError(t, json.Unmarshal(catalogMuscles, &muscles))
+	muscleIDs := namesOf(t, catalogMuscleNames(muscles))
+
+	var equipmentNames []string
+	require.NoError(t, json.Unmarshal(libraryEquipment, &equipmentNames))
+	equipmentIDs := namesOf(t, equipmentNames)
+
+	var exercises []libraryExercise
+	require.NoError(t, json.Unmarshal(libraryExercises, &exercises))
+	assert.GreaterOrEqual(t, len(exercises), 300)
+
+	exerciseNames := make([]string, 0, len(exercises))
+	for _, e := range exercises {
+		exerciseNames = append(exerciseNames, e.Name)
+
+		assert.NotEmpty(t, e.Primary, "%q has no primary muscle", e.Name)
+		assert.NoError(t, exercise.MovementPattern(e.Pattern).Validate(), e.Name)
+		_, err := resolve(muscleIDs, append(e.Primary, e.Secondary...))
+		assert.NoError(t, err, e.Name)
+		_, err = resolve(equipmentIDs, e.Equipment)
+		assert.NoError(t, err, e.Name)
+	}
+	exerciseIDs := namesOf(t, exerciseNames)
+
+	var splits []librarySplit
+	require.NoError(t, json.Unmarshal(librarySplits, &splits))
+	for _, s := range splits {
+		for _, d := range s.Days {
+			_, err := resolve(exerciseIDs, d.Exercises)
+			assert.NoError(t, err, "%s / %s", s.Name, d.Name)
+		}
+	}
+}
+
+func TestLibraryAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		exercise libraryExercise
+		expected []string
+	}{
+		{name: "Abbreviates equipment", exercise: libraryExercise{Name: "Dumbbell Incline Bench Press"}, expected: []string{"DB Incline Bench Press"}},
+		{name: "Keeps listed aliases first", exercise: libraryExercise{Name: "Barbell Romanian Deadlift", Aliases: []string{"RDL"}}, expected: []string{"RDL", "BB Romanian Deadlift"}},
+		{name: "Nothing to abbreviate", exercise: libraryExercise{Name: "Pull-up"}, expected: nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, libraryAliases(tc.exercise))
+		})
+	}
+}
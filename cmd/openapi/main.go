@@ -0,0 +1,36 @@
+// Command openapi writes the OpenAPI document of the API.
+// It is run by make gen-docs, the same document is served at /openapi.json.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/CP-Payne/exercise/internal/interfaces/services"
+)
+
+func main() {
+	out := flag.String("o", "", "file to write the document to, stdout when empty")
+	flag.Parse()
+
+	data, err := json.MarshalIndent(services.NewOpenAPIDocument(), "", "  ")
+	if err != nil {
+		log.Fatalf("failed to encode OpenAPI document: %v", err)
+	}
+	data = append(data, '\n')
+
+	if *out == "" {
+		os.Stdout.Write(data)
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+		log.Fatalf("failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatalf("failed to write OpenAPI document: %v", err)
+	}
+}
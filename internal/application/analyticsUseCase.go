@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/google/uuid"
+)
+
+type AnalyticsUseCase interface {
+	WeeklyMuscleVolume(ctx context.Context, userID uuid.UUID, r analytics.Range) ([]analytics.WeekVolume, error)
+}
+
+type analyticsUseCase struct {
+	analyticsService analytics.AnalyticsService
+}
+
+func NewAnalyticsUseCase(analyticsService analytics.AnalyticsService) *analyticsUseCase {
+	return &analyticsUseCase{
+		analyticsService: analyticsService,
+	}
+}
+
+// WeeklyMuscleVolume returns the working sets and tonnage of each target muscle per ISO week of the range.
+// Sets count for each target muscle of an exercise by the activation of its link, or the default of its role.
+func (us *analyticsUseCase) WeeklyMuscleVolume(ctx context.Context, userID uuid.UUID, r analytics.Range) ([]analytics.WeekVolume, error) {
+	exercises, err := us.analyticsService.ExerciseWeeks(ctx, userID, r)
+	if err != nil {
+		return nil, err
+	}
+	return analytics.WeeklyMuscleVolume(r, exercises), nil
+}
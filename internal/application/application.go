@@ -1,21 +1,102 @@
 package application
 
-import "github.com/CP-Payne/exercise/internal/domain"
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/domain"
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+)
+
+// Transactor runs a unit of work that spans several domain services in a single transaction.
+// Services called with the context passed to fn take part in it.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
 
 type UseCases interface {
 	MuscleUseCase() MuscleUseCase
+	AuthUseCase() AuthUseCase
+	ExerciseUseCase() ExerciseUseCase
+	EquipmentUseCase() EquipmentUseCase
+	SplitUseCase() SplitUseCase
+	WorkoutUseCase() WorkoutUseCase
+	RoutineUseCase() RoutineUseCase
+	SettingsUseCase() SettingsUseCase
+	ToolsUseCase() ToolsUseCase
+	AnalyticsUseCase() AnalyticsUseCase
+	ImageUseCase() ImageUseCase
 }
 
 type useCases struct {
-	Muscle MuscleUseCase
+	Muscle    MuscleUseCase
+	Auth      AuthUseCase
+	Exercise  ExerciseUseCase
+	Equipment EquipmentUseCase
+	Split     SplitUseCase
+	Workout   WorkoutUseCase
+	Routine   RoutineUseCase
+	Settings  SettingsUseCase
+	Tools     ToolsUseCase
+	Analytics AnalyticsUseCase
+	Image     ImageUseCase
 }
 
-func NewUseCases(domainServices domain.DomainServices) UseCases {
+func NewUseCases(domainServices domain.DomainServices, transactor Transactor, authenticator auth.Authenticator, images ImageStorage) UseCases {
 	return &useCases{
-		Muscle: NewMuscleUseCase(domainServices.Muscle),
+		Muscle:    NewMuscleUseCase(domainServices.Muscle),
+		Auth:      NewAuthUseCase(domainServices.User, domainServices.Session, authenticator),
+		Exercise:  NewExerciseUseCase(domainServices.Exercise, domainServices.Muscle, domainServices.Equipment, domainServices.EquipmentProfile, domainServices.Split, domainServices.Record, domainServices.Workout, domainServices.User, images),
+		Equipment: NewEquipmentUseCase(domainServices.Equipment, domainServices.EquipmentProfile),
+		Split:     NewSplitUseCase(domainServices.Split, domainServices.Exercise),
+		Workout:   NewWorkoutUseCase(domainServices.Workout, domainServices.Exercise, domainServices.Record, transactor),
+		Routine:   NewRoutineUseCase(domainServices.Routine, domainServices.Exercise, domainServices.Split, domainServices.Workout),
+		Settings:  NewSettingsUseCase(domainServices.User),
+		Tools:     NewToolsUseCase(domainServices.User),
+		Analytics: NewAnalyticsUseCase(domainServices.Analytics),
+		Image:     NewImageUseCase(images),
 	}
 }
 
 func (u *useCases) MuscleUseCase() MuscleUseCase {
 	return u.Muscle
 }
+
+func (u *useCases) AuthUseCase() AuthUseCase {
+	return u.Auth
+}
+
+func (u *useCases) ExerciseUseCase() ExerciseUseCase {
+	return u.Exercise
+}
+
+func (u *useCases) EquipmentUseCase() EquipmentUseCase {
+	return u.Equipment
+}
+
+func (u *useCases) SplitUseCase() SplitUseCase {
+	return u.Split
+}
+
+func (u *useCases) WorkoutUseCase() WorkoutUseCase {
+	return u.Workout
+}
+
+func (u *useCases) RoutineUseCase() RoutineUseCase {
+	return u.Routine
+}
+
+func (u *useCases) SettingsUseCase() SettingsUseCase {
+	return u.Settings
+}
+
+func (u *useCases) ToolsUseCase() ToolsUseCase {
+	return u.Tools
+}
+
+func (u *useCases) AnalyticsUseCase() AnalyticsUseCase {
+	return u.Analytics
+}
+
+func (u *useCases) ImageUseCase() ImageUseCase {
+	return u.Image
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/google/uuid"
+)
+
+// AuthTokens holds the tokens issued to a user after a successful login or refresh
+type AuthTokens struct {
+	AccessToken           string
+	ExpiresIn             time.Duration
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+}
+
+type AuthUseCase interface {
+	Signup(ctx context.Context, email, username, password string) (*user.User, error)
+	Login(ctx context.Context, email, password string) (*AuthTokens, error)
+	Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
+}
+
+type authUseCase struct {
+	userService    user.UserService
+	sessionService session.SessionService
+	authenticator  auth.Authenticator
+}
+
+func NewAuthUseCase(userService user.UserService, sessionService session.SessionService, authenticator auth.Authenticator) *authUseCase {
+	return &authUseCase{
+		userService:    userService,
+		sessionService: sessionService,
+		authenticator:  authenticator,
+	}
+}
+
+func (us *authUseCase) Signup(ctx context.Context, email, username, password string) (*user.User, error) {
+	u, err := user.NewUser(user.UserParams{Email: email, Username: username})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	if err := us.userService.RegisterUser(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (us *authUseCase) Login(ctx context.Context, email, password string) (*AuthTokens, error) {
+	u, err := us.userService.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, user.ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	ok, err := u.PasswordMatches(password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, user.ErrInvalidCredentials
+	}
+
+	refreshToken, plaintext, err := us.sessionService.StartSession(ctx, u.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return us.issueTokens(u.ID(), refreshToken, plaintext)
+}
+
+func (us *authUseCase) Refresh(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	next, plaintext, err := us.sessionService.RotateSession(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, session.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	return us.issueTokens(next.UserID(), next, plaintext)
+}
+
+func (us *authUseCase) Logout(ctx context.Context, refreshToken string) error {
+	err := us.sessionService.EndSession(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return session.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	return nil
+}
+
+func (us *authUseCase) issueTokens(userID uuid.UUID, refreshToken *session.RefreshToken, plaintext string) (*AuthTokens, error) {
+	accessToken, err := us.authenticator.GenerateToken(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:           accessToken,
+		ExpiresIn:             us.authenticator.TokenExpiry(),
+		RefreshToken:          plaintext,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+type EquipmentUseCase interface {
+	CreateEquipment(ctx context.Context, userID uuid.UUID, equipment *equipment.Equipment) error
+	ListEquipmentForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Equipment], error)
+	GetEquipmentByID(ctx context.Context, userID, equipmentID uuid.UUID) (*equipment.Equipment, error)
+	RenameEquipment(ctx context.Context, userID, equipmentID uuid.UUID, name string) (*equipment.Equipment, error)
+	DeleteEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error
+
+	CreateProfile(ctx context.Context, userID uuid.UUID, profile *equipment.Profile) error
+	ListProfilesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Profile], error)
+	GetProfileByID(ctx context.Context, userID, profileID uuid.UUID) (*equipment.Profile, error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, profile *equipment.Profile) error
+	DeleteProfile(ctx context.Context, userID, profileID uuid.UUID) error
+	AddProfileEquipment(ctx context.Context, userID, profileID, equipmentID uuid.UUID) (*equipment.Profile, error)
+	RemoveProfileEquipment(ctx context.Context, userID, profileID, equipmentID uuid.UUID) (*equipment.Profile, error)
+}
+
+type equipmentUseCase struct {
+	equipmentService equipment.EquipmentService
+	profileService   equipment.ProfileService
+}
+
+func NewEquipmentUseCase(equipmentService equipment.EquipmentService, profileService equipment.ProfileService) *equipmentUseCase {
+	return &equipmentUseCase{
+		equipmentService: equipmentService,
+		profileService:   profileService,
+	}
+}
+
+func (us *equipmentUseCase) CreateEquipment(ctx context.Context, userID uuid.UUID, equipment *equipment.Equipment) error {
+	return us.equipmentService.AddEquipment(ctx, userID, equipment)
+}
+
+func (us *equipmentUseCase) ListEquipmentForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Equipment], error) {
+	return us.equipmentService.ListEquipment(ctx, userID, q)
+}
+
+func (us *equipmentUseCase) GetEquipmentByID(ctx context.Context, userID, equipmentID uuid.UUID) (*equipment.Equipment, error) {
+	return us.equipmentService.GetEquipmentByID(ctx, userID, equipmentID)
+}
+
+func (us *equipmentUseCase) RenameEquipment(ctx context.Context, userID, equipmentID uuid.UUID, name string) (*equipment.Equipment, error) {
+	e, err := us.equipmentService.GetEquipmentByID(ctx, userID, equipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.SetName(name); err != nil {
+		return nil, err
+	}
+
+	if err := us.equipmentService.UpdateEquipment(ctx, userID, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (us *equipmentUseCase) DeleteEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error {
+	return us.equipmentService.RemoveEquipment(ctx, userID, equipmentID)
+}
+
+func (us *equipmentUseCase) CreateProfile(ctx context.Context, userID uuid.UUID, profile *equipment.Profile) error {
+	if err := us.validateProfile(ctx, userID, profile); err != nil {
+		return err
+	}
+	return us.profileService.AddProfile(ctx, userID, profile)
+}
+
+func (us *equipmentUseCase) ListProfilesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Profile], error) {
+	return us.profileService.ListProfiles(ctx, userID, q)
+}
+
+func (us *equipmentUseCase) GetProfileByID(ctx context.Context, userID, profileID uuid.UUID) (*equipment.Profile, error) {
+	return us.profileService.GetProfileByID(ctx, userID, profileID)
+}
+
+func (us *equipmentUseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, profile *equipment.Profile) error {
+	if err := us.validateProfile(ctx, userID, profile); err != nil {
+		return err
+	}
+	return us.profileService.UpdateProfile(ctx, userID, profile)
+}
+
+func (us *equipmentUseCase) DeleteProfile(ctx context.Context, userID, profileID uuid.UUID) error {
+	return us.profileService.RemoveProfile(ctx, userID, profileID)
+}
+
+// AddProfileEquipment adds equipment to a profile, doing nothing when it is already there
+func (us *equipmentUseCase) AddProfileEquipment(ctx context.Context, userID, profileID, equipmentID uuid.UUID) (*equipment.Profile, error) {
+	return us.modifyProfile(ctx, userID, profileID, func(p *equipment.Profile) error {
+		if p.HasEquipment(equipmentID) {
+			return nil
+		}
+		if err := us.checkEquipment(ctx, userID, equipmentID); err != nil {
+			return err
+		}
+		return p.AddEquipment(equipmentID)
+	})
+}
+
+func (us *equipmentUseCase) RemoveProfileEquipment(ctx context.Context, userID, profileID, equipmentID uuid.UUID) (*equipment.Profile, error) {
+	return us.modifyProfile(ctx, userID, profileID, func(p *equipment.Profile) error {
+		return p.RemoveEquipment(equipmentID)
+	})
+}
+
+// modifyProfile loads a profile, applies fn and stores the result
+func (us *equipmentUseCase) modifyProfile(ctx context.Context, userID, profileID uuid.UUID, fn func(*equipment.Profile) error) (*equipment.Profile, error) {
+	p, err := us.profileService.GetProfileByID(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(p); err != nil {
+		return nil, err
+	}
+
+	if err := us.profileService.UpdateProfile(ctx, userID, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// validateProfile checks that all the equipment in the profile exists and belongs to the user
+func (us *equipmentUseCase) validateProfile(ctx context.Context, userID uuid.UUID, profile *equipment.Profile) error {
+	for _, id := range profile.GetEquipmentIDs() {
+		if err := us.checkEquipment(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (us *equipmentUseCase) checkEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error {
+	_, err := us.equipmentService.GetEquipmentByID(ctx, userID, equipmentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return equipment.ErrUnknownEquipment
+	}
+	return err
+}
@@ -0,0 +1,367 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+type ExerciseUseCase interface {
+	CreateExercise(ctx context.Context, userID uuid.UUID, exercise *exercise.Exercise) error
+	GetExerciseByID(ctx context.Context, userID, exerciseID uuid.UUID) (*exercise.Exercise, error)
+	ListExercisesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*exercise.Exercise], error)
+	ListAvailableExercises(ctx context.Context, userID uuid.UUID, q listquery.Query, profile string) (listquery.Page[*exercise.Exercise], error)
+	SearchExercises(ctx context.Context, userID uuid.UUID, q exercise.SearchQuery) ([]exercise.SearchResult, error)
+	ListAlternatives(ctx context.Context, userID, exerciseID uuid.UUID, profile string, limit int) ([]exercise.Alternative, error)
+	UpdateExercise(ctx context.Context, userID uuid.UUID, exercise *exercise.Exercise) error
+	DeleteExercise(ctx context.Context, userID, exerciseID uuid.UUID) error
+	SetDisplayImage(ctx context.Context, userID, exerciseID uuid.UUID, upload io.Reader) (*exercise.Exercise, error)
+	RemoveDisplayImage(ctx context.Context, userID, exerciseID uuid.UUID) (*exercise.Exercise, error)
+
+	AttachTargetMuscle(ctx context.Context, userID, exerciseID uuid.UUID, link exercise.TargetMuscle) (*exercise.Exercise, error)
+	DetachTargetMuscle(ctx context.Context, userID, exerciseID, muscleID uuid.UUID) (*exercise.Exercise, error)
+	AttachEquipment(ctx context.Context, userID, exerciseID, equipmentID uuid.UUID) (*exercise.Exercise, error)
+	DetachEquipment(ctx context.Context, userID, exerciseID, equipmentID uuid.UUID) (*exercise.Exercise, error)
+	AttachSplit(ctx context.Context, userID, exerciseID, splitID uuid.UUID) (*exercise.Exercise, error)
+	DetachSplit(ctx context.Context, userID, exerciseID, splitID uuid.UUID) (*exercise.Exercise, error)
+
+	ListRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*record.Record, error)
+	OneRepMaxHistory(ctx context.Context, userID, exerciseID uuid.UUID, f strength.Formula) (strength.Formula, []strength.Point, error)
+}
+
+type exerciseUseCase struct {
+	exerciseService  exercise.ExerciseService
+	muscleService    muscle.MuscleService
+	equipmentService equipment.EquipmentService
+	profileService   equipment.ProfileService
+	splitService     split.SplitService
+	recordService    record.RecordService
+	workoutService   workout.WorkoutService
+	userService      user.UserService
+	images           ImageStorage
+}
+
+func NewExerciseUseCase(exerciseService exercise.ExerciseService, muscleService muscle.MuscleService, equipmentService equipment.EquipmentService, profileService equipment.ProfileService, splitService split.SplitService, recordService record.RecordService, workoutService workout.WorkoutService, userService user.UserService, images ImageStorage) *exerciseUseCase {
+	return &exerciseUseCase{
+		exerciseService:  exerciseService,
+		muscleService:    muscleService,
+		equipmentService: equipmentService,
+		profileService:   profileService,
+		splitService:     splitService,
+		recordService:    recordService,
+		workoutService:   workoutService,
+		userService:      userService,
+		images:           images,
+	}
+}
+
+func (us *exerciseUseCase) CreateExercise(ctx context.Context, userID uuid.UUID, exercise *exercise.Exercise) error {
+	if err := us.validateReferences(ctx, userID, exercise); err != nil {
+		return err
+	}
+	return us.exerciseService.AddExercise(ctx, userID, exercise)
+}
+
+func (us *exerciseUseCase) GetExerciseByID(ctx context.Context, userID, exerciseID uuid.UUID) (*exercise.Exercise, error) {
+	return us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+}
+
+func (us *exerciseUseCase) ListExercisesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*exercise.Exercise], error) {
+	return us.exerciseService.ListExercises(ctx, userID, q, exercise.ListFilter{})
+}
+
+// ListAvailableExercises lists the exercises the user can do with the equipment in the named profile,
+// or in any of their profiles when profile is empty
+func (us *exerciseUseCase) ListAvailableExercises(ctx context.Context, userID uuid.UUID, q listquery.Query, profile string) (listquery.Page[*exercise.Exercise], error) {
+	inventory, err := us.inventory(ctx, userID, profile)
+	if err != nil {
+		return listquery.Page[*exercise.Exercise]{}, err
+	}
+	return us.exerciseService.ListExercises(ctx, userID, q, exercise.ListFilter{AvailableWith: inventory})
+}
+
+// ListAlternatives ranks the exercises that can replace an exercise, the best first. Equipment counts as owned
+// when it is in the named profile, or in any of the user's profiles when profile is empty.
+func (us *exerciseUseCase) ListAlternatives(ctx context.Context, userID, exerciseID uuid.UUID, profile string, limit int) ([]exercise.Alternative, error) {
+	limit, err := exercise.AlternativesLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := us.inventory(ctx, userID, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := us.exerciseService.ListRelatedExercises(ctx, userID, exercise.RelatedTo(e))
+	if err != nil {
+		return nil, err
+	}
+	return exercise.Alternatives(e, candidates, inventory, limit), nil
+}
+
+// inventory returns the equipment in the named profile, or in any of the user's profiles when profile is empty.
+// It is never nil, as a nil inventory would disable ListFilter.AvailableWith instead of keeping only
+// exercises without equipment.
+func (us *exerciseUseCase) inventory(ctx context.Context, userID uuid.UUID, profile string) ([]uuid.UUID, error) {
+	var inventory []uuid.UUID
+	if profile == "" {
+		ids, err := us.profileService.Inventory(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		inventory = ids
+	} else {
+		p, err := us.profileService.GetProfileByName(ctx, userID, profile)
+		if err != nil {
+			return nil, err
+		}
+		inventory = p.GetEquipmentIDs()
+	}
+
+	if inventory == nil {
+		inventory = []uuid.UUID{}
+	}
+	return inventory, nil
+}
+
+// SearchExercises returns the exercises of the user that match the search, the most relevant first
+func (us *exerciseUseCase) SearchExercises(ctx context.Context, userID uuid.UUID, q exercise.SearchQuery) ([]exercise.SearchResult, error) {
+	return us.exerciseService.SearchExercises(ctx, userID, q)
+}
+
+func (us *exerciseUseCase) UpdateExercise(ctx context.Context, userID uuid.UUID, exercise *exercise.Exercise) error {
+	if err := us.validateReferences(ctx, userID, exercise); err != nil {
+		return err
+	}
+	return us.exerciseService.UpdateExercise(ctx, userID, exercise)
+}
+
+func (us *exerciseUseCase) DeleteExercise(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	e, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+	if err := us.exerciseService.RemoveExercise(ctx, userID, exerciseID); err != nil {
+		return err
+	}
+	us.deleteImage(ctx, e.GetImageID())
+	return nil
+}
+
+// SetDisplayImage checks and stores an uploaded image with its thumbnail and sets it as the image of
+// the exercise. The image it replaces is deleted.
+func (us *exerciseUseCase) SetDisplayImage(ctx context.Context, userID, exerciseID uuid.UUID, upload io.Reader) (*exercise.Exercise, error) {
+	e, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	processed, err := imaging.Process(upload)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := us.images.save(ctx, processed)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := e.GetImageID()
+	e.SetImageID(id)
+
+	if err := us.exerciseService.UpdateExercise(ctx, userID, e); err != nil {
+		us.images.delete(ctx, id)
+		return nil, err
+	}
+
+	us.deleteImage(ctx, previous)
+	return e, nil
+}
+
+// RemoveDisplayImage clears the display image of the exercise and deletes it
+func (us *exerciseUseCase) RemoveDisplayImage(ctx context.Context, userID, exerciseID uuid.UUID) (*exercise.Exercise, error) {
+	var previous uuid.UUID
+	e, err := us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		previous = e.GetImageID()
+		e.SetImageID(uuid.Nil)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	us.deleteImage(ctx, previous)
+	return e, nil
+}
+
+// deleteImage deletes an uploaded image once nothing refers to it anymore, uuid.Nil is no image.
+// Failing to delete it only leaves an unreachable blob behind, so the error is not returned.
+func (us *exerciseUseCase) deleteImage(ctx context.Context, id uuid.UUID) {
+	if id != uuid.Nil {
+		_ = us.images.delete(ctx, id)
+	}
+}
+
+// AttachTargetMuscle links a target muscle to the exercise, or changes the role and activation of an existing link
+func (us *exerciseUseCase) AttachTargetMuscle(ctx context.Context, userID, exerciseID uuid.UUID, link exercise.TargetMuscle) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		if e.HasTargetMuscle(link.MuscleID) {
+			return e.SetTargetMuscle(link)
+		}
+		if err := us.checkMuscle(ctx, userID, link.MuscleID); err != nil {
+			return err
+		}
+		return e.AddTargetMuscle(link)
+	})
+}
+
+func (us *exerciseUseCase) DetachTargetMuscle(ctx context.Context, userID, exerciseID, muscleID uuid.UUID) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		return e.RemoveTargetMuscle(muscleID)
+	})
+}
+
+func (us *exerciseUseCase) AttachEquipment(ctx context.Context, userID, exerciseID, equipmentID uuid.UUID) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		if slices.Contains(e.GetEquipments(), equipmentID) {
+			return nil
+		}
+		if err := us.checkEquipment(ctx, userID, equipmentID); err != nil {
+			return err
+		}
+		return e.AddEquipment(equipmentID)
+	})
+}
+
+func (us *exerciseUseCase) DetachEquipment(ctx context.Context, userID, exerciseID, equipmentID uuid.UUID) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		return e.RemoveEquipment(equipmentID)
+	})
+}
+
+func (us *exerciseUseCase) AttachSplit(ctx context.Context, userID, exerciseID, splitID uuid.UUID) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		if slices.Contains(e.GetSplits(), splitID) {
+			return nil
+		}
+		if err := us.checkSplit(ctx, userID, splitID); err != nil {
+			return err
+		}
+		return e.AddSplit(splitID)
+	})
+}
+
+func (us *exerciseUseCase) DetachSplit(ctx context.Context, userID, exerciseID, splitID uuid.UUID) (*exercise.Exercise, error) {
+	return us.modify(ctx, userID, exerciseID, func(e *exercise.Exercise) error {
+		return e.RemoveSplit(splitID)
+	})
+}
+
+// modify loads an exercise, applies fn to it and saves the result
+func (us *exerciseUseCase) modify(ctx context.Context, userID, exerciseID uuid.UUID, fn func(*exercise.Exercise) error) (*exercise.Exercise, error) {
+	e, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := fn(e); err != nil {
+		return nil, err
+	}
+
+	if err := us.exerciseService.UpdateExercise(ctx, userID, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// ListRecords returns every personal record of an exercise from the oldest to the newest
+func (us *exerciseUseCase) ListRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*record.Record, error) {
+	if _, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID); err != nil {
+		return nil, err
+	}
+	return us.recordService.ListExerciseRecords(ctx, userID, exerciseID)
+}
+
+// OneRepMaxHistory returns the best estimated one-rep max of an exercise in each session it was logged in,
+// with the formula, or the user's preferred formula when it is empty. It returns the formula that was used.
+func (us *exerciseUseCase) OneRepMaxHistory(ctx context.Context, userID, exerciseID uuid.UUID, f strength.Formula) (strength.Formula, []strength.Point, error) {
+	f, err := preferredFormula(ctx, us.userService, userID, f)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID); err != nil {
+		return "", nil, err
+	}
+
+	history, err := us.workoutService.ExerciseHistory(ctx, userID, exerciseID)
+	if err != nil {
+		return "", nil, err
+	}
+	return f, strength.Series(f, history), nil
+}
+
+// validateReferences checks that every muscle, equipment and split linked
+// to the exercise exists and belongs to the user
+func (us *exerciseUseCase) validateReferences(ctx context.Context, userID uuid.UUID, e *exercise.Exercise) error {
+	for _, id := range e.GetTargetMuscleIDs() {
+		if err := us.checkMuscle(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range e.GetEquipments() {
+		if err := us.checkEquipment(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range e.GetSplits() {
+		if err := us.checkSplit(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (us *exerciseUseCase) checkMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	_, err := us.muscleService.GetMuscleByID(ctx, userID, muscleID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return exercise.ErrUnknownTargetMuscle
+	}
+	return err
+}
+
+func (us *exerciseUseCase) checkEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error {
+	_, err := us.equipmentService.GetEquipmentByID(ctx, userID, equipmentID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return exercise.ErrUnknownEquipment
+	}
+	return err
+}
+
+func (us *exerciseUseCase) checkSplit(ctx context.Context, userID, splitID uuid.UUID) error {
+	_, err := us.splitService.GetSplitByID(ctx, userID, splitID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return exercise.ErrUnknownSplit
+	}
+	return err
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidImageSignature is returned when an image is requested without the signature of its URL
+var ErrInvalidImageSignature = errors.New("image URL signature is invalid")
+
+// ImageVariant is one of the versions an uploaded image is stored in
+type ImageVariant string
+
+const (
+	// ImageOriginal is the uploaded image, re-encoded without its metadata
+	ImageOriginal ImageVariant = "original"
+	// ImageThumbnail is the uploaded image scaled down to imaging.ThumbnailSize
+	ImageThumbnail ImageVariant = "thumbnail"
+)
+
+// ImageStorage holds where uploaded images are stored and how the URLs they are served from are signed
+type ImageStorage struct {
+	Blobs  storage.BlobStore
+	Signer *storage.URLSigner
+}
+
+type ImageUseCase interface {
+	OpenImage(ctx context.Context, imageID uuid.UUID, variant ImageVariant, query url.Values) (io.ReadCloser, storage.Info, error)
+	ImageURL(imageID uuid.UUID, variant ImageVariant) url.URL
+}
+
+type imageUseCase struct {
+	images ImageStorage
+}
+
+func NewImageUseCase(images ImageStorage) *imageUseCase {
+	return &imageUseCase{
+		images: images,
+	}
+}
+
+// OpenImage returns a variant of an uploaded image when the query of the request holds an unexpired signature of its URL
+func (us *imageUseCase) OpenImage(ctx context.Context, imageID uuid.UUID, variant ImageVariant, query url.Values) (io.ReadCloser, storage.Info, error) {
+	if !us.images.Signer.Verify(imagePath(imageID, variant), query, time.Now()) {
+		return nil, storage.Info{}, ErrInvalidImageSignature
+	}
+	return us.images.Blobs.Get(ctx, imageKey(imageID, variant))
+}
+
+// ImageURL returns a freshly signed URL a variant of an uploaded image is served from
+func (us *imageUseCase) ImageURL(imageID uuid.UUID, variant ImageVariant) url.URL {
+	return us.images.Signer.Sign(imagePath(imageID, variant), time.Now())
+}
+
+// save stores both variants of a processed image under a new ID
+func (s ImageStorage) save(ctx context.Context, p imaging.Processed) (uuid.UUID, error) {
+	id := uuid.New()
+	if err := s.Blobs.Put(ctx, imageKey(id, ImageOriginal), p.Original.ContentType, p.Original.Data); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.Blobs.Put(ctx, imageKey(id, ImageThumbnail), p.Thumbnail.ContentType, p.Thumbnail.Data); err != nil {
+		s.delete(ctx, id)
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// delete removes both variants of an image
+func (s ImageStorage) delete(ctx context.Context, id uuid.UUID) error {
+	return errors.Join(
+		s.Blobs.Delete(ctx, imageKey(id, ImageOriginal)),
+		s.Blobs.Delete(ctx, imageKey(id, ImageThumbnail)),
+	)
+}
+
+// imagePath returns the path a variant of an image is served from, relative to the public URL of the API
+func imagePath(id uuid.UUID, variant ImageVariant) string {
+	if variant == ImageThumbnail {
+		return "/images/" + id.String() + "/thumbnail"
+	}
+	return "/images/" + id.String()
+}
+
+// imageKey returns the key a variant of an image is stored under
+func imageKey(id uuid.UUID, variant ImageVariant) string {
+	return "images/" + id.String() + "/" + string(variant)
+}
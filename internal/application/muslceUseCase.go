@@ -2,16 +2,25 @@ package application
 
 import (
 	"context"
+	"errors"
+	"slices"
 
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/google/uuid"
 )
 
 type MuscleUseCase interface {
 	CreateMuscle(ctx context.Context, userID uuid.UUID, muscle *muscle.Muscle) error
-	ListMusclesForUser(ctx context.Context, userID uuid.UUID) ([]*muscle.Muscle, error)
+	ListMusclesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error)
+	UpdateMuscle(ctx context.Context, userID, muscleID uuid.UUID, versions []int, apply func(*muscle.Muscle) error) (*muscle.Muscle, error)
 	DeleteMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
 	GetMuscleByID(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Muscle, error)
+	GetMuscleTree(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Node, error)
+	ListHiddenMusclesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error)
+	HideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
+	UnhideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
 }
 
 type muscleUseCase struct {
@@ -24,22 +33,109 @@ func NewMuscleUseCase(muscleService muscle.MuscleService) *muscleUseCase {
 	}
 }
 
-func (us *muscleUseCase) CreateMuscle(ctx context.Context, userID uuid.UUID, muscle *muscle.Muscle) error {
-	err := us.muscleService.AddMuscle(ctx, userID, muscle)
+// CreateMuscle adds a private muscle for the user after checking that its parent, if any,
+// is visible to the user and sits higher in the hierarchy
+func (us *muscleUseCase) CreateMuscle(ctx context.Context, userID uuid.UUID, m *muscle.Muscle) error {
+	if m.HasParent() {
+		if err := us.checkParent(ctx, userID, m); err != nil {
+			return err
+		}
+	}
+
+	err := us.muscleService.AddMuscle(ctx, userID, m)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (us *muscleUseCase) ListMusclesForUser(ctx context.Context, userID uuid.UUID) ([]*muscle.Muscle, error) {
-	return us.muscleService.ListMuscles(ctx, userID)
+func (us *muscleUseCase) ListMusclesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
+	return us.muscleService.ListMuscles(ctx, userID, q)
 }
 
 func (us *muscleUseCase) GetMuscleByID(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Muscle, error) {
 	return us.muscleService.GetMuscleByID(ctx, userID, muscleID)
 }
 
+func (us *muscleUseCase) GetMuscleTree(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Node, error) {
+	return us.muscleService.GetMuscleTree(ctx, userID, muscleID)
+}
+
+// UpdateMuscle loads one of the user's muscles, applies the changes and stores it.
+// When versions is not nil the current version of the muscle must be one of them, otherwise muscle.ErrVersionConflict is returned.
+// The changed muscle must still fit between its parent and its children in the hierarchy.
+func (us *muscleUseCase) UpdateMuscle(ctx context.Context, userID, muscleID uuid.UUID, versions []int, apply func(*muscle.Muscle) error) (*muscle.Muscle, error) {
+	m, err := us.muscleService.GetMuscleByID(ctx, userID, muscleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.IsCatalog() {
+		return nil, muscle.ErrCatalogMuscleReadOnly
+	}
+
+	if versions != nil && !slices.Contains(versions, m.Version()) {
+		return nil, muscle.ErrVersionConflict
+	}
+
+	if err := apply(m); err != nil {
+		return nil, err
+	}
+
+	if m.HasParent() {
+		if err := us.checkParent(ctx, userID, m); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := us.checkChildren(ctx, userID, m); err != nil {
+		return nil, err
+	}
+
+	if err := us.muscleService.UpdateMuscle(ctx, userID, m); err != nil {
+		return nil, err
+	}
+
+	return us.muscleService.GetMuscleByID(ctx, userID, muscleID)
+}
+
 func (us *muscleUseCase) DeleteMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
 	return us.muscleService.RemoveMuscle(ctx, userID, muscleID)
 }
+
+func (us *muscleUseCase) ListHiddenMusclesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
+	return us.muscleService.ListHiddenMuscles(ctx, userID, q)
+}
+
+func (us *muscleUseCase) HideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	return us.muscleService.HideMuscle(ctx, userID, muscleID)
+}
+
+func (us *muscleUseCase) UnhideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	return us.muscleService.UnhideMuscle(ctx, userID, muscleID)
+}
+
+func (us *muscleUseCase) checkParent(ctx context.Context, userID uuid.UUID, m *muscle.Muscle) error {
+	parent, err := us.muscleService.GetMuscleByID(ctx, userID, m.ParentID())
+	if errors.Is(err, repositories.ErrNotFound) {
+		return muscle.ErrUnknownParent
+	}
+	if err != nil {
+		return err
+	}
+	return m.CanHaveParent(parent)
+}
+
+// checkChildren verifies that the muscles directly below m may still be held by it
+func (us *muscleUseCase) checkChildren(ctx context.Context, userID uuid.UUID, m *muscle.Muscle) error {
+	tree, err := us.muscleService.GetMuscleTree(ctx, userID, m.ID())
+	if err != nil {
+		return err
+	}
+	for _, child := range tree.Children {
+		if err := child.Muscle.CanHaveParent(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
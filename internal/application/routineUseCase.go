@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+type RoutineUseCase interface {
+	CreateRoutine(ctx context.Context, userID uuid.UUID, routine *routine.Routine) error
+	CreateRoutineFromSplitDay(ctx context.Context, userID uuid.UUID, name string, splitID, dayID uuid.UUID) (*routine.Routine, error)
+	ListRoutinesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*routine.Routine], error)
+	GetRoutineByID(ctx context.Context, userID, routineID uuid.UUID) (*routine.Routine, error)
+	RenameRoutine(ctx context.Context, userID, routineID uuid.UUID, name string) (*routine.Routine, error)
+	DeleteRoutine(ctx context.Context, userID, routineID uuid.UUID) error
+
+	AddExercise(ctx context.Context, userID, routineID, exerciseID uuid.UUID, target routine.Target) (*routine.Routine, error)
+	SetExerciseTarget(ctx context.Context, userID, routineID, routineExerciseID uuid.UUID, target routine.Target) (*routine.Routine, error)
+	RemoveExercise(ctx context.Context, userID, routineID, routineExerciseID uuid.UUID) (*routine.Routine, error)
+	ReorderExercises(ctx context.Context, userID, routineID uuid.UUID, routineExerciseIDs []uuid.UUID) (*routine.Routine, error)
+
+	StartRoutine(ctx context.Context, userID, routineID uuid.UUID) (*workout.Session, error)
+}
+
+type routineUseCase struct {
+	routineService  routine.RoutineService
+	exerciseService exercise.ExerciseService
+	splitService    split.SplitService
+	workoutService  workout.WorkoutService
+}
+
+func NewRoutineUseCase(routineService routine.RoutineService, exerciseService exercise.ExerciseService, splitService split.SplitService, workoutService workout.WorkoutService) *routineUseCase {
+	return &routineUseCase{
+		routineService:  routineService,
+		exerciseService: exerciseService,
+		splitService:    splitService,
+		workoutService:  workoutService,
+	}
+}
+
+// CreateRoutine stores a new routine after checking that every exercise it lists exists for the user
+func (us *routineUseCase) CreateRoutine(ctx context.Context, userID uuid.UUID, r *routine.Routine) error {
+	for _, id := range r.GetExerciseIDs() {
+		if err := us.checkExercise(ctx, userID, id); err != nil {
+			return err
+		}
+	}
+	return us.routineService.AddRoutine(ctx, userID, r)
+}
+
+// CreateRoutineFromSplitDay creates a routine with the exercises of a split day, each with the default target
+func (us *routineUseCase) CreateRoutineFromSplitDay(ctx context.Context, userID uuid.UUID, name string, splitID, dayID uuid.UUID) (*routine.Routine, error) {
+	s, err := us.splitService.GetSplitByID(ctx, userID, splitID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, routine.ErrUnknownSplitDay
+		}
+		return nil, err
+	}
+
+	var exercises []routine.ExerciseParams
+	found := false
+	for _, d := range s.GetDays() {
+		if d.GetId() != dayID {
+			continue
+		}
+		found = true
+		for _, id := range d.GetExerciseIDs() {
+			exercises = append(exercises, routine.ExerciseParams{ExerciseID: id, Target: routine.DefaultTarget})
+		}
+	}
+	if !found {
+		return nil, routine.ErrUnknownSplitDay
+	}
+
+	r, err := routine.NewRoutine(routine.RoutineParams{Name: name, Exercises: exercises})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.routineService.AddRoutine(ctx, userID, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (us *routineUseCase) ListRoutinesForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*routine.Routine], error) {
+	return us.routineService.ListRoutines(ctx, userID, q)
+}
+
+func (us *routineUseCase) GetRoutineByID(ctx context.Context, userID, routineID uuid.UUID) (*routine.Routine, error) {
+	return us.routineService.GetRoutineByID(ctx, userID, routineID)
+}
+
+func (us *routineUseCase) RenameRoutine(ctx context.Context, userID, routineID uuid.UUID, name string) (*routine.Routine, error) {
+	return us.modify(ctx, userID, routineID, func(r *routine.Routine) error {
+		return r.SetName(name)
+	})
+}
+
+func (us *routineUseCase) DeleteRoutine(ctx context.Context, userID, routineID uuid.UUID) error {
+	return us.routineService.RemoveRoutine(ctx, userID, routineID)
+}
+
+func (us *routineUseCase) AddExercise(ctx context.Context, userID, routineID, exerciseID uuid.UUID, target routine.Target) (*routine.Routine, error) {
+	return us.modify(ctx, userID, routineID, func(r *routine.Routine) error {
+		if err := us.checkExercise(ctx, userID, exerciseID); err != nil {
+			return err
+		}
+		_, err := r.AddExercise(exerciseID, target)
+		return err
+	})
+}
+
+func (us *routineUseCase) SetExerciseTarget(ctx context.Context, userID, routineID, routineExerciseID uuid.UUID, target routine.Target) (*routine.Routine, error) {
+	return us.modify(ctx, userID, routineID, func(r *routine.Routine) error {
+		return r.SetTarget(routineExerciseID, target)
+	})
+}
+
+func (us *routineUseCase) RemoveExercise(ctx context.Context, userID, routineID, routineExerciseID uuid.UUID) (*routine.Routine, error) {
+	return us.modify(ctx, userID, routineID, func(r *routine.Routine) error {
+		return r.RemoveExercise(routineExerciseID)
+	})
+}
+
+func (us *routineUseCase) ReorderExercises(ctx context.Context, userID, routineID uuid.UUID, routineExerciseIDs []uuid.UUID) (*routine.Routine, error) {
+	return us.modify(ctx, userID, routineID, func(r *routine.Routine) error {
+		return r.ReorderExercises(routineExerciseIDs)
+	})
+}
+
+// StartRoutine starts a workout session with an entry for every exercise of the routine.
+// Each entry targets the sets and reps of the routine with the load of the top working set
+// the user lifted in the most recent session that included the exercise.
+func (us *routineUseCase) StartRoutine(ctx context.Context, userID, routineID uuid.UUID) (*workout.Session, error) {
+	r, err := us.routineService.GetRoutineByID(ctx, userID, routineID)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := us.workoutService.LatestEntries(ctx, userID, r.GetExerciseIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]workout.ExerciseEntryParams, 0, len(r.GetExercises()))
+	for _, e := range r.GetExercises() {
+		t := e.GetTarget()
+		target := &workout.Target{Sets: t.Sets, MinReps: t.MinReps, MaxReps: t.MaxReps}
+
+		if top, ok := latest[e.GetExerciseID()].TopSet(); ok {
+			target.Weight, target.Unit = top.GetWeight(), top.GetUnit()
+		}
+
+		entries = append(entries, workout.ExerciseEntryParams{ExerciseID: e.GetExerciseID(), Target: target})
+	}
+
+	session, err := workout.NewSession(workout.SessionParams{Name: r.GetName(), Entries: entries})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.workoutService.AddSession(ctx, userID, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// checkExercise translates a missing exercise to routine.ErrUnknownExercise
+func (us *routineUseCase) checkExercise(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	_, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return routine.ErrUnknownExercise
+		}
+		return err
+	}
+	return nil
+}
+
+// modify loads a routine, applies fn to it and saves the result without racing other modifications of the routine
+func (us *routineUseCase) modify(ctx context.Context, userID, routineID uuid.UUID, fn func(*routine.Routine) error) (*routine.Routine, error) {
+	return us.routineService.ModifyRoutine(ctx, userID, routineID, fn)
+}
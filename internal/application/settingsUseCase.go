@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type SettingsUseCase interface {
+	GetSettings(ctx context.Context, userID uuid.UUID) (user.Settings, error)
+	UpdateSettings(ctx context.Context, userID uuid.UUID, settings user.Settings) (user.Settings, error)
+}
+
+type settingsUseCase struct {
+	userService user.UserService
+}
+
+func NewSettingsUseCase(userService user.UserService) *settingsUseCase {
+	return &settingsUseCase{
+		userService: userService,
+	}
+}
+
+func (us *settingsUseCase) GetSettings(ctx context.Context, userID uuid.UUID) (user.Settings, error) {
+	u, err := us.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return user.Settings{}, err
+	}
+	return u.Settings(), nil
+}
+
+func (us *settingsUseCase) UpdateSettings(ctx context.Context, userID uuid.UUID, settings user.Settings) (user.Settings, error) {
+	u, err := us.userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return user.Settings{}, err
+	}
+
+	if err := u.SetSettings(settings); err != nil {
+		return user.Settings{}, err
+	}
+
+	if err := us.userService.UpdateUserSettings(ctx, u); err != nil {
+		return user.Settings{}, err
+	}
+	return u.Settings(), nil
+}
+
+// preferredFormula returns f, or the formula the user picked in their settings when f is empty
+func preferredFormula(ctx context.Context, userService user.UserService, userID uuid.UUID, f strength.Formula) (strength.Formula, error) {
+	if f != "" {
+		return f, f.Validate()
+	}
+
+	u, err := userService.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	return u.Settings().OneRepMaxFormula, nil
+}
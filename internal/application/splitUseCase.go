@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+type SplitUseCase interface {
+	CreateSplit(ctx context.Context, userID uuid.UUID, split *split.Split) error
+	ListSplitsForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*split.Split], error)
+	GetSplitByID(ctx context.Context, userID, splitID uuid.UUID) (*split.Split, error)
+	RenameSplit(ctx context.Context, userID, splitID uuid.UUID, name string) (*split.Split, error)
+	DeleteSplit(ctx context.Context, userID, splitID uuid.UUID) error
+
+	AddDay(ctx context.Context, userID, splitID uuid.UUID, name string) (*split.Split, error)
+	RenameDay(ctx context.Context, userID, splitID, dayID uuid.UUID, name string) (*split.Split, error)
+	RemoveDay(ctx context.Context, userID, splitID, dayID uuid.UUID) (*split.Split, error)
+	ReorderDays(ctx context.Context, userID, splitID uuid.UUID, dayIDs []uuid.UUID) (*split.Split, error)
+	AssignExerciseToDay(ctx context.Context, userID, splitID, dayID, exerciseID uuid.UUID) (*split.Split, error)
+	UnassignExerciseFromDay(ctx context.Context, userID, splitID, dayID, exerciseID uuid.UUID) (*split.Split, error)
+}
+
+type splitUseCase struct {
+	splitService    split.SplitService
+	exerciseService exercise.ExerciseService
+}
+
+func NewSplitUseCase(splitService split.SplitService, exerciseService exercise.ExerciseService) *splitUseCase {
+	return &splitUseCase{
+		splitService:    splitService,
+		exerciseService: exerciseService,
+	}
+}
+
+func (us *splitUseCase) CreateSplit(ctx context.Context, userID uuid.UUID, split *split.Split) error {
+	return us.splitService.AddSplit(ctx, userID, split)
+}
+
+func (us *splitUseCase) ListSplitsForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*split.Split], error) {
+	return us.splitService.ListSplits(ctx, userID, q)
+}
+
+func (us *splitUseCase) GetSplitByID(ctx context.Context, userID, splitID uuid.UUID) (*split.Split, error) {
+	return us.splitService.GetSplitByID(ctx, userID, splitID)
+}
+
+func (us *splitUseCase) RenameSplit(ctx context.Context, userID, splitID uuid.UUID, name string) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		return s.SetName(name)
+	})
+}
+
+func (us *splitUseCase) DeleteSplit(ctx context.Context, userID, splitID uuid.UUID) error {
+	return us.splitService.RemoveSplit(ctx, userID, splitID)
+}
+
+func (us *splitUseCase) AddDay(ctx context.Context, userID, splitID uuid.UUID, name string) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		_, err := s.AddDay(name)
+		return err
+	})
+}
+
+func (us *splitUseCase) RenameDay(ctx context.Context, userID, splitID, dayID uuid.UUID, name string) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		return s.RenameDay(dayID, name)
+	})
+}
+
+func (us *splitUseCase) RemoveDay(ctx context.Context, userID, splitID, dayID uuid.UUID) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		return s.RemoveDay(dayID)
+	})
+}
+
+func (us *splitUseCase) ReorderDays(ctx context.Context, userID, splitID uuid.UUID, dayIDs []uuid.UUID) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		return s.ReorderDays(dayIDs)
+	})
+}
+
+func (us *splitUseCase) AssignExerciseToDay(ctx context.Context, userID, splitID, dayID, exerciseID uuid.UUID) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		_, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				return split.ErrUnknownExercise
+			}
+			return err
+		}
+		return s.AssignExercise(dayID, exerciseID)
+	})
+}
+
+func (us *splitUseCase) UnassignExerciseFromDay(ctx context.Context, userID, splitID, dayID, exerciseID uuid.UUID) (*split.Split, error) {
+	return us.modify(ctx, userID, splitID, func(s *split.Split) error {
+		return s.UnassignExercise(dayID, exerciseID)
+	})
+}
+
+// modify loads a split, applies fn to it and saves the result without racing other modifications of the split
+func (us *splitUseCase) modify(ctx context.Context, userID, splitID uuid.UUID, fn func(*split.Split) error) (*split.Split, error) {
+	return us.splitService.ModifySplit(ctx, userID, splitID, fn)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+type ToolsUseCase interface {
+	EstimateOneRepMax(ctx context.Context, userID uuid.UUID, f strength.Formula, weight float64, reps int, rpe *float64) (strength.Formula, float64, error)
+}
+
+type toolsUseCase struct {
+	userService user.UserService
+}
+
+func NewToolsUseCase(userService user.UserService) *toolsUseCase {
+	return &toolsUseCase{
+		userService: userService,
+	}
+}
+
+// EstimateOneRepMax estimates a one-rep max with the formula, or with the user's preferred formula when it is empty.
+// It returns the formula that was used with the estimate.
+func (us *toolsUseCase) EstimateOneRepMax(ctx context.Context, userID uuid.UUID, f strength.Formula, weight float64, reps int, rpe *float64) (strength.Formula, float64, error) {
+	f, err := preferredFormula(ctx, us.userService, userID, f)
+	if err != nil {
+		return "", 0, err
+	}
+
+	e1rm, err := strength.Estimate(f, weight, reps, rpe)
+	if err != nil {
+		return "", 0, err
+	}
+	return f, e1rm, nil
+}
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+type WorkoutUseCase interface {
+	StartWorkout(ctx context.Context, userID uuid.UUID, session *workout.Session) error
+	ListWorkoutsForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*workout.Session], error)
+	GetWorkoutByID(ctx context.Context, userID, sessionID uuid.UUID) (*workout.Session, error)
+	DeleteWorkout(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	AddExercise(ctx context.Context, userID, sessionID, exerciseID uuid.UUID) (*workout.Session, error)
+	AddSet(ctx context.Context, userID, sessionID, entryID uuid.UUID, params workout.SetParams) (*workout.Session, error)
+	FinishWorkout(ctx context.Context, userID, sessionID uuid.UUID) (*workout.Session, []*record.Record, error)
+}
+
+type workoutUseCase struct {
+	workoutService  workout.WorkoutService
+	exerciseService exercise.ExerciseService
+	recordService   record.RecordService
+	transactor      Transactor
+}
+
+func NewWorkoutUseCase(workoutService workout.WorkoutService, exerciseService exercise.ExerciseService, recordService record.RecordService, transactor Transactor) *workoutUseCase {
+	return &workoutUseCase{
+		workoutService:  workoutService,
+		exerciseService: exerciseService,
+		recordService:   recordService,
+		transactor:      transactor,
+	}
+}
+
+// StartWorkout stores a new session after checking that every exercise it starts with exists for the user
+func (us *workoutUseCase) StartWorkout(ctx context.Context, userID uuid.UUID, session *workout.Session) error {
+	for _, e := range session.GetEntries() {
+		if err := us.checkExercise(ctx, userID, e.GetExerciseID()); err != nil {
+			return err
+		}
+	}
+	return us.workoutService.AddSession(ctx, userID, session)
+}
+
+func (us *workoutUseCase) ListWorkoutsForUser(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*workout.Session], error) {
+	return us.workoutService.ListSessions(ctx, userID, q)
+}
+
+func (us *workoutUseCase) GetWorkoutByID(ctx context.Context, userID, sessionID uuid.UUID) (*workout.Session, error) {
+	return us.workoutService.GetSessionByID(ctx, userID, sessionID)
+}
+
+func (us *workoutUseCase) DeleteWorkout(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return us.workoutService.RemoveSession(ctx, userID, sessionID)
+}
+
+func (us *workoutUseCase) AddExercise(ctx context.Context, userID, sessionID, exerciseID uuid.UUID) (*workout.Session, error) {
+	return us.modify(ctx, userID, sessionID, func(s *workout.Session) error {
+		if s.IsFinished() {
+			return workout.ErrSessionFinished
+		}
+		if err := us.checkExercise(ctx, userID, exerciseID); err != nil {
+			return err
+		}
+		_, err := s.AddEntry(exerciseID)
+		return err
+	})
+}
+
+// AddSet logs a set for an entry of the session and stores the personal records the set beats.
+// Both happen in one transaction that keeps the session locked, so overlapping sets never detect
+// records against the same history, and a set is never logged without its records.
+func (us *workoutUseCase) AddSet(ctx context.Context, userID, sessionID, entryID uuid.UUID, params workout.SetParams) (*workout.Session, error) {
+	var session *workout.Session
+	err := us.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var set workout.Set
+		var err error
+		session, err = us.modify(ctx, userID, sessionID, func(s *workout.Session) error {
+			set, err = s.AddSet(entryID, params)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return us.detectRecords(ctx, userID, session, entryID, set)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// FinishWorkout finishes the session and returns it with the personal records set during it
+func (us *workoutUseCase) FinishWorkout(ctx context.Context, userID, sessionID uuid.UUID) (*workout.Session, []*record.Record, error) {
+	session, err := us.modify(ctx, userID, sessionID, func(s *workout.Session) error {
+		return s.Finish(time.Now())
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, err := us.recordService.ListSessionRecords(ctx, userID, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return session, records, nil
+}
+
+// detectRecords compares a logged set with the current records of its exercise and saves the ones it beats
+func (us *workoutUseCase) detectRecords(ctx context.Context, userID uuid.UUID, s *workout.Session, entryID uuid.UUID, set workout.Set) error {
+	var exerciseID uuid.UUID
+	for _, e := range s.GetEntries() {
+		if e.GetID() == entryID {
+			exerciseID = e.GetExerciseID()
+		}
+	}
+
+	history, err := us.recordService.ListExerciseRecords(ctx, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	records := record.Detect(record.Current(history), exerciseID, s.GetID(), set, s.Volume(exerciseID), time.Now())
+	return us.recordService.SaveRecords(ctx, userID, records)
+}
+
+// checkExercise translates a missing exercise to workout.ErrUnknownExercise
+func (us *workoutUseCase) checkExercise(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	_, err := us.exerciseService.GetExerciseByID(ctx, userID, exerciseID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return workout.ErrUnknownExercise
+		}
+		return err
+	}
+	return nil
+}
+
+// modify loads a session, applies fn to it and saves the result without racing other modifications of the session
+func (us *workoutUseCase) modify(ctx context.Context, userID, sessionID uuid.UUID, fn func(*workout.Session) error) (*workout.Session, error) {
+	return us.workoutService.ModifySession(ctx, userID, sessionID, fn)
+}
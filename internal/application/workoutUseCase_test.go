@@ -0,0 +1,186 @@
+package application_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWorkouts is an in-memory WorkoutRepository that keeps sessions as copies the way a database keeps rows,
+// so a session handed out and saved later overwrites whatever was saved in between
+type memoryWorkouts struct {
+	workout.WorkoutRepository
+	mu       sync.Mutex
+	sessions map[uuid.UUID]workout.SessionParams
+}
+
+func (m *memoryWorkouts) Modify(ctx context.Context, userID, sessionID uuid.UUID, fn func(*workout.Session) error) (*workout.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, err := workout.NewSession(m.sessions[sessionID])
+	if err != nil {
+		return nil, err
+	}
+	if err := fn(s); err != nil {
+		return nil, err
+	}
+	m.sessions[sessionID] = sessionParams(s)
+	return s, nil
+}
+
+// sessionParams copies a session into the parameters that restore it
+func sessionParams(s *workout.Session) workout.SessionParams {
+	params := workout.SessionParams{ID: s.GetID(), Name: s.GetName(), StartedAt: s.GetStartedAt()}
+	for _, e := range s.GetEntries() {
+		entry := workout.ExerciseEntryParams{ID: e.GetID(), ExerciseID: e.GetExerciseID()}
+		for _, set := range e.GetSets() {
+			entry.Sets = append(entry.Sets, workout.SetParams{ID: set.GetID(), Reps: set.GetReps(), Weight: set.GetWeight(), Unit: set.GetUnit()})
+		}
+		params.Entries = append(params.Entries, entry)
+	}
+	return params
+}
+
+// memoryRecords is an in-memory RecordService that replaces saved records with the same ID like the database does
+type memoryRecords struct {
+	record.RecordService
+	mu    sync.Mutex
+	saved []*record.Record
+	err   error
+}
+
+func (m *memoryRecords) ListExerciseRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*record.Record, error) {
+	m.mu.Lock()
+	var records []*record.Record
+	for _, r := range m.saved {
+		if r.GetExerciseID() == exerciseID {
+			records = append(records, r)
+		}
+	}
+	m.mu.Unlock()
+
+	// Reading takes a while like a query does, which gives overlapping sets the chance to read the same history
+	time.Sleep(time.Millisecond)
+	return records, nil
+}
+
+func (m *memoryRecords) SaveRecords(ctx context.Context, userID uuid.UUID, records []*record.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.err != nil {
+		return m.err
+	}
+	for _, r := range records {
+		i := slices.IndexFunc(m.saved, func(saved *record.Record) bool { return saved.GetID() == r.GetID() })
+		if i == -1 {
+			m.saved = append(m.saved, r)
+			continue
+		}
+		m.saved[i] = r
+	}
+	return nil
+}
+
+// memoryTransactor runs units of work one at a time and restores the sessions and records when one fails
+type memoryTransactor struct {
+	mu       sync.Mutex
+	workouts *memoryWorkouts
+	records  *memoryRecords
+}
+
+func (m *memoryTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions, saved := maps.Clone(m.workouts.sessions), slices.Clone(m.records.saved)
+	if err := fn(ctx); err != nil {
+		m.workouts.sessions, m.records.saved = sessions, saved
+		return err
+	}
+	return nil
+}
+
+// newTestWorkout stores a session with one entry and returns it with a use case working on it
+func newTestWorkout(t *testing.T) (application.WorkoutUseCase, *memoryWorkouts, *memoryRecords, *workout.Session, workout.ExerciseEntry) {
+	t.Helper()
+
+	s, err := workout.NewSession(workout.SessionParams{Name: "Legs"})
+	require.NoError(t, err)
+	entry, err := s.AddEntry(uuid.New())
+	require.NoError(t, err)
+
+	workouts := &memoryWorkouts{sessions: map[uuid.UUID]workout.SessionParams{s.GetID(): sessionParams(s)}}
+	records := &memoryRecords{}
+	transactor := &memoryTransactor{workouts: workouts, records: records}
+	useCase := application.NewWorkoutUseCase(workout.NewWorkoutService(workouts), nil, records, transactor)
+	return useCase, workouts, records, s, entry
+}
+
+func TestWorkoutUseCase_AddSet_Overlapping(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	useCase, workouts, records, s, entry := newTestWorkout(t)
+
+	const sets = 8
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := range sets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := useCase.AddSet(ctx, userID, s.GetID(), entry.GetID(), workout.SetParams{Reps: 5, Weight: float64(100 + i)})
+			assert.NoError(t, err)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	saved, err := workout.NewSession(workouts.sessions[s.GetID()])
+	require.NoError(t, err)
+	logged := saved.GetEntries()[0].GetSets()
+	assert.Len(t, logged, sets, "no set is lost to an overlapping one")
+
+	setIDs := map[uuid.UUID]bool{}
+	for _, set := range logged {
+		setIDs[set.GetID()] = true
+	}
+	type key struct {
+		kind   record.Kind
+		weight float64
+	}
+	seen := map[key]bool{}
+	for _, r := range records.saved {
+		assert.True(t, setIDs[r.GetSetID()], "records point to logged sets")
+
+		k := key{kind: r.GetKind(), weight: r.GetWeight()}
+		assert.False(t, seen[k], "the session holds one %s record at %v kg", k.kind, k.weight)
+		seen[k] = true
+	}
+	assert.NotEmpty(t, seen)
+}
+
+func TestWorkoutUseCase_AddSet_RecordsFailToSave(t *testing.T) {
+	useCase, workouts, records, s, entry := newTestWorkout(t)
+	records.err = errors.New("connection lost")
+
+	_, err := useCase.AddSet(context.Background(), uuid.New(), s.GetID(), entry.GetID(), workout.SetParams{Reps: 5, Weight: 100})
+	assert.ErrorIs(t, err, records.err)
+
+	saved, err := workout.NewSession(workouts.sessions[s.GetID()])
+	require.NoError(t, err)
+	assert.Empty(t, saved.GetEntries()[0].GetSets(), "the set is not logged without its records")
+}
@@ -0,0 +1,91 @@
+package analytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Test cases for date range validation
+func TestNewRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		from, to      time.Time
+		expectedError error
+	}{
+		{name: "Single day", from: day(2026, 3, 2), to: day(2026, 3, 2)},
+		{name: "53 weeks", from: day(2026, 1, 1), to: day(2026, 1, 1).AddDate(0, 0, analytics.MaxRangeDays-1)},
+		{name: "Ends before it starts", from: day(2026, 3, 2), to: day(2026, 3, 1), expectedError: analytics.ErrInvalidRange},
+		{name: "Too long", from: day(2026, 1, 1), to: day(2026, 1, 1).AddDate(0, 0, analytics.MaxRangeDays), expectedError: analytics.ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := analytics.NewRange(tt.from, tt.to)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+// Test cases for ISO weeks
+func TestWeek(t *testing.T) {
+	// January 1st 2027 is a Friday, it belongs to the last week of 2026
+	w := analytics.WeekOf(day(2027, 1, 1))
+	assert.Equal(t, analytics.Week{Year: 2026, Week: 53}, w)
+	assert.Equal(t, "2026-W53", w.String())
+	assert.Equal(t, day(2026, 12, 28), w.Start())
+
+	assert.Equal(t, day(2025, 12, 29), analytics.Week{Year: 2026, Week: 1}.Start())
+
+	r := analytics.DefaultRange(time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC))
+	assert.Equal(t, day(2026, 9, 21), r.From)
+	assert.Equal(t, day(2026, 10, 18), r.To)
+	assert.Len(t, r.Weeks(), analytics.DefaultRangeWeeks)
+}
+
+// Test cases for spreading exercise volume over target muscles
+func TestWeeklyMuscleVolume(t *testing.T) {
+	chest := analytics.Muscle{ID: uuid.New(), Name: "Chest"}
+	triceps := analytics.Muscle{ID: uuid.New(), Name: "Triceps"}
+	shoulders := analytics.Muscle{ID: uuid.New(), Name: "Shoulders"}
+	core := analytics.Muscle{ID: uuid.New(), Name: "Core"}
+	share := func(m analytics.Muscle, fraction float64) analytics.TargetMuscle {
+		return analytics.TargetMuscle{Muscle: m, Fraction: fraction}
+	}
+
+	r, err := analytics.NewRange(day(2026, 3, 2), day(2026, 3, 15))
+	assert.NoError(t, err)
+	week1, week2 := analytics.WeekOf(day(2026, 3, 2)), analytics.WeekOf(day(2026, 3, 9))
+
+	volumes := analytics.WeeklyMuscleVolume(r, []analytics.ExerciseWeek{
+		// Bench press, chest is the primary muscle and the core only stabilizes
+		{Week: week1, ExerciseID: uuid.New(), Muscles: []analytics.TargetMuscle{
+			share(chest, 1), share(triceps, 0.5), share(shoulders, 0.5), share(core, 0),
+		}, Sets: 4, Tonnage: 2000},
+		// Dips, triceps is the primary muscle and chest has an activation of its own
+		{Week: week1, ExerciseID: uuid.New(), Muscles: []analytics.TargetMuscle{
+			share(triceps, 1), share(chest, 0.25),
+		}, Sets: 4, Tonnage: 0},
+		// An exercise without target muscles counts for nothing
+		{Week: week1, ExerciseID: uuid.New(), Sets: 5, Tonnage: 500},
+	})
+
+	if assert.Len(t, volumes, 2) {
+		assert.Equal(t, week1, volumes[0].Week)
+		assert.Equal(t, []analytics.MuscleVolume{
+			{Muscle: triceps, Sets: 6, Tonnage: 1000},
+			{Muscle: chest, Sets: 5, Tonnage: 2000},
+			{Muscle: shoulders, Sets: 2, Tonnage: 1000},
+		}, volumes[0].Muscles)
+
+		assert.Equal(t, week2, volumes[1].Week)
+		assert.Empty(t, volumes[1].Muscles)
+	}
+}
@@ -0,0 +1,179 @@
+package analytics
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidRange is returned when a date range ends before it starts or is too long
+	ErrInvalidRange = errors.New("the range must not end before it starts and must span at most 53 weeks")
+)
+
+const (
+	// MaxRangeDays is the longest range that can be analysed, 53 ISO weeks
+	MaxRangeDays = 53 * 7
+
+	// DefaultRangeWeeks is the number of ISO weeks, including the current one, analysed when no range is given
+	DefaultRangeWeeks = 4
+)
+
+// Range is an inclusive range of UTC dates
+type Range struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewRange creates a range between two dates, the time of day is ignored
+func NewRange(from, to time.Time) (Range, error) {
+	r := Range{From: date(from), To: date(to)}
+	if r.To.Before(r.From) || r.To.Sub(r.From) >= MaxRangeDays*24*time.Hour {
+		return Range{}, ErrInvalidRange
+	}
+	return r, nil
+}
+
+// DefaultRange returns the range from the Monday DefaultRangeWeeks-1 weeks before now up to now
+func DefaultRange(now time.Time) Range {
+	to := date(now)
+	return Range{From: WeekOf(to).Start().AddDate(0, 0, -7*(DefaultRangeWeeks-1)), To: to}
+}
+
+// End returns the exclusive end of the range, midnight after its last day
+func (r Range) End() time.Time {
+	return r.To.AddDate(0, 0, 1)
+}
+
+// Weeks returns every ISO week that overlaps the range, in order
+func (r Range) Weeks() []Week {
+	var weeks []Week
+	for start := WeekOf(r.From).Start(); !start.After(r.To); start = start.AddDate(0, 0, 7) {
+		weeks = append(weeks, WeekOf(start))
+	}
+	return weeks
+}
+
+func date(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Week is an ISO 8601 week, which starts on Monday and belongs to the year of its Thursday
+type Week struct {
+	Year int
+	Week int
+}
+
+// WeekOf returns the ISO week of a time in UTC
+func WeekOf(t time.Time) Week {
+	y, w := t.UTC().ISOWeek()
+	return Week{Year: y, Week: w}
+}
+
+// Start returns midnight UTC of the Monday the week starts on
+func (w Week) Start() time.Time {
+	// January 4th is always in the first ISO week of its year
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -(int(jan4.Weekday())+6)%7)
+	return monday.AddDate(0, 0, 7*(w.Week-1))
+}
+
+// String formats the week as in ISO 8601, such as 2026-W07
+func (w Week) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// Muscle is a target muscle as it appears in analytics
+type Muscle struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// TargetMuscle is a target muscle of an exercise with the fraction of each set that counts for it
+type TargetMuscle struct {
+	Muscle
+	Fraction float64
+}
+
+// ExerciseWeek is the working sets of an exercise logged during an ISO week
+type ExerciseWeek struct {
+	Week       Week
+	ExerciseID uuid.UUID
+	// Muscles are the target muscles of the exercise in the order they were linked
+	Muscles []TargetMuscle
+	Sets    int
+	// Tonnage is the sum of reps × weight of the sets, in kg
+	Tonnage float64
+}
+
+// MuscleVolume is the training volume of a muscle during a week.
+// Sets and Tonnage are fractional because sets count partially for muscles that are not primary.
+type MuscleVolume struct {
+	Muscle  Muscle
+	Sets    float64
+	Tonnage float64
+}
+
+// WeekVolume is the training volume of every muscle trained during a week
+type WeekVolume struct {
+	Week    Week
+	Muscles []MuscleVolume
+}
+
+// WeeklyMuscleVolume spreads the sets and tonnage of each exercise over its target muscles by their fractions.
+// Muscles that only get a zero fraction, such as stabilizers, are left out. It returns every week of the range, with muscles ordered by decreasing sets then by name.
+func WeeklyMuscleVolume(r Range, exercises []ExerciseWeek) []WeekVolume {
+	weeks := r.Weeks()
+	index := make(map[Week]int, len(weeks))
+	volumes := make([]WeekVolume, len(weeks))
+	for i, w := range weeks {
+		index[w] = i
+		volumes[i] = WeekVolume{Week: w, Muscles: []MuscleVolume{}}
+	}
+
+	byMuscle := make([]map[uuid.UUID]*MuscleVolume, len(weeks))
+	for _, e := range exercises {
+		i, ok := index[e.Week]
+		if !ok {
+			continue
+		}
+		if byMuscle[i] == nil {
+			byMuscle[i] = map[uuid.UUID]*MuscleVolume{}
+		}
+
+		for _, m := range e.Muscles {
+			if m.Fraction <= 0 {
+				continue
+			}
+			v, ok := byMuscle[i][m.ID]
+			if !ok {
+				v = &MuscleVolume{Muscle: m.Muscle}
+				byMuscle[i][m.ID] = v
+			}
+			v.Sets += m.Fraction * float64(e.Sets)
+			v.Tonnage += m.Fraction * e.Tonnage
+		}
+	}
+
+	for i, muscles := range byMuscle {
+		for _, v := range muscles {
+			v.Sets, v.Tonnage = round(v.Sets), round(v.Tonnage)
+			volumes[i].Muscles = append(volumes[i].Muscles, *v)
+		}
+		slices.SortFunc(volumes[i].Muscles, func(a, b MuscleVolume) int {
+			return cmp.Or(cmp.Compare(b.Sets, a.Sets), cmp.Compare(a.Muscle.Name, b.Muscle.Name))
+		})
+	}
+	return volumes
+}
+
+// round rounds to two decimals
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
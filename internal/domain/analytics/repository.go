@@ -0,0 +1,13 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsRepository defines the read-only queries over logged training
+type AnalyticsRepository interface {
+	// ExerciseWeeks returns the working sets of each exercise per ISO week for sessions started within the range
+	ExerciseWeeks(ctx context.Context, userID uuid.UUID, r Range) ([]ExerciseWeek, error)
+}
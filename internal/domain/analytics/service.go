@@ -0,0 +1,27 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsService defines the business operations available for training analytics
+type AnalyticsService interface {
+	ExerciseWeeks(ctx context.Context, userID uuid.UUID, r Range) ([]ExerciseWeek, error)
+}
+
+type analyticsService struct {
+	repo AnalyticsRepository
+}
+
+// NewAnalyticsService create a new service with the provided repository
+func NewAnalyticsService(repo AnalyticsRepository) AnalyticsService {
+	return &analyticsService{
+		repo: repo,
+	}
+}
+
+func (s *analyticsService) ExerciseWeeks(ctx context.Context, userID uuid.UUID, r Range) ([]ExerciseWeek, error) {
+	return s.repo.ExerciseWeeks(ctx, userID, r)
+}
@@ -1,19 +1,57 @@
 package domain
 
 import (
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
 	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
 )
 
+// Config holds the tunables needed by domain services
+type Config struct {
+	RefreshTokenTTL time.Duration
+}
+
 // DomainServices provides access to all domain services
 // from a centralized location
 type DomainServices struct {
-	Muscle muscle.MuscleService
+	Muscle    muscle.MuscleService
+	User      user.UserService
+	Session   session.SessionService
+	Exercise  exercise.ExerciseService
+	Equipment equipment.EquipmentService
+	Split     split.SplitService
+	Workout   workout.WorkoutService
+	Routine   routine.RoutineService
+	Record    record.RecordService
+	Analytics analytics.AnalyticsService
+
+	EquipmentProfile equipment.ProfileService
 }
 
 // NewDomainServices creates and initializes all domain service implementations
-func NewDomainServices(r *repositories.Repositories) *DomainServices {
+func NewDomainServices(r *repositories.Repositories, cfg Config) *DomainServices {
 	return &DomainServices{
-		Muscle: muscle.NewMuscleService(r.Muscles),
+		Muscle:    muscle.NewMuscleService(r.Muscles),
+		User:      user.NewUserService(r.Users),
+		Session:   session.NewSessionService(r.Sessions, cfg.RefreshTokenTTL),
+		Exercise:  exercise.NewExerciseService(r.Exercises),
+		Equipment: equipment.NewEquipmentService(r.Equipment),
+		Split:     split.NewSplitService(r.Splits),
+		Workout:   workout.NewWorkoutService(r.Workouts),
+		Routine:   routine.NewRoutineService(r.Routines),
+		Record:    record.NewRecordService(r.Records),
+		Analytics: analytics.NewAnalyticsService(r.Analytics),
+
+		EquipmentProfile: equipment.NewProfileService(r.EquipmentProfiles),
 	}
 }
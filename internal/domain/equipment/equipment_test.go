@@ -0,0 +1,115 @@
+package equipment_test
+
+import (
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases for Equipment domain model
+func TestNewEquipment(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        equipment.EquipmentParams
+		expectedError error
+	}{
+		{
+			name:          "Valid equipment creation",
+			params:        equipment.EquipmentParams{ID: uuid.New(), Name: "Barbell"},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty name",
+			params:        equipment.EquipmentParams{ID: uuid.New()},
+			expectedError: equipment.ErrInvalidEquipment,
+		},
+		{
+			name:          "Empty ID should generate new ID",
+			params:        equipment.EquipmentParams{Name: "Dumbbell"},
+			expectedError: nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := equipment.NewEquipment(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, e.GetId())
+			if tc.params.ID != uuid.Nil {
+				assert.Equal(t, tc.params.ID, e.GetId())
+			}
+			assert.Equal(t, tc.params.Name, e.GetName())
+		})
+	}
+}
+
+func TestEquipment_SetName(t *testing.T) {
+	e, _ := equipment.NewEquipment(equipment.EquipmentParams{Name: "Barbell"})
+
+	assert.Equal(t, equipment.ErrInvalidEquipment, e.SetName(""))
+	assert.Equal(t, "Barbell", e.GetName())
+
+	assert.NoError(t, e.SetName("EZ Bar"))
+	assert.Equal(t, "EZ Bar", e.GetName())
+}
+
+// Test cases for equipment profiles
+func TestNewProfile(t *testing.T) {
+	barbell := uuid.New()
+	tests := []struct {
+		name          string
+		params        equipment.ProfileParams
+		expectedError error
+	}{
+		{
+			name:          "Valid profile",
+			params:        equipment.ProfileParams{Name: "home", EquipmentIDs: []uuid.UUID{barbell, uuid.New()}},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty name",
+			params:        equipment.ProfileParams{EquipmentIDs: []uuid.UUID{barbell}},
+			expectedError: equipment.ErrInvalidProfile,
+		},
+		{
+			name:          "Duplicate equipment",
+			params:        equipment.ProfileParams{Name: "travel", EquipmentIDs: []uuid.UUID{barbell, barbell}},
+			expectedError: equipment.ErrDuplicateEquipment,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := equipment.NewProfile(tc.params)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.NotEqual(t, uuid.Nil, p.GetID())
+				assert.Equal(t, tc.params.EquipmentIDs, p.GetEquipmentIDs())
+			}
+		})
+	}
+}
+
+func TestProfile_Equipment(t *testing.T) {
+	bands, kettlebell := uuid.New(), uuid.New()
+	p, err := equipment.NewProfile(equipment.ProfileParams{Name: "travel", EquipmentIDs: []uuid.UUID{bands}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, p.AddEquipment(kettlebell))
+	assert.Equal(t, equipment.ErrDuplicateEquipment, p.AddEquipment(bands))
+	assert.Equal(t, []uuid.UUID{bands, kettlebell}, p.GetEquipmentIDs())
+
+	assert.NoError(t, p.RemoveEquipment(bands))
+	assert.Equal(t, equipment.ErrEquipmentNotInProfile, p.RemoveEquipment(bands))
+	assert.False(t, p.HasEquipment(bands))
+	assert.True(t, p.HasEquipment(kettlebell))
+}
@@ -10,18 +10,30 @@ var (
 	ErrInvalidEquipment = errors.New("an equipment must have a name")
 )
 
+// EquipmentParams contains the parameters needed to create a new Equipment
+type EquipmentParams struct {
+	ID   uuid.UUID
+	Name string
+}
+
 type Equipment struct {
 	id   uuid.UUID
 	name string
 }
 
-func NewSplit(name string) (Equipment, error) {
-	if name == "" {
-		return Equipment{}, ErrInvalidEquipment
+// NewEquipment creates a new Equipment entity with validation
+func NewEquipment(params EquipmentParams) (*Equipment, error) {
+	if params.Name == "" {
+		return &Equipment{}, ErrInvalidEquipment
+	}
+
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
 	}
-	return Equipment{
-		id:   uuid.New(),
-		name: name,
+
+	return &Equipment{
+		id:   params.ID,
+		name: params.Name,
 	}, nil
 }
 
@@ -33,6 +45,10 @@ func (m *Equipment) GetName() string {
 	return m.name
 }
 
-func (m *Equipment) SetName(name string) {
+func (m *Equipment) SetName(name string) error {
+	if name == "" {
+		return ErrInvalidEquipment
+	}
 	m.name = name
+	return nil
 }
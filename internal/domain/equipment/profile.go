@@ -0,0 +1,138 @@
+package equipment
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidProfile is returned when an equipment profile has no name
+	ErrInvalidProfile = errors.New("an equipment profile must have a name")
+
+	// ErrUnknownEquipment is returned when adding equipment that does not exist for the user to a profile
+	ErrUnknownEquipment = errors.New("equipment does not exist")
+
+	// ErrDuplicateEquipment is returned when adding equipment that is already in the profile
+	ErrDuplicateEquipment = errors.New("equipment is already in the profile")
+
+	// ErrEquipmentNotInProfile is returned when removing equipment that is not in the profile
+	ErrEquipmentNotInProfile = errors.New("equipment is not in the profile")
+)
+
+// ProfileParams contains the parameters needed to create a new Profile
+type ProfileParams struct {
+	ID           uuid.UUID
+	Name         string
+	EquipmentIDs []uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Profile is a named part of the equipment a user owns, such as "home" or "travel".
+// Together the profiles of a user make up their inventory.
+type Profile struct {
+	id           uuid.UUID
+	name         string
+	equipmentIDs []uuid.UUID
+	createdAt    time.Time
+	updatedAt    time.Time
+}
+
+// NewProfile creates a new Profile aggregate with validation
+func NewProfile(params ProfileParams) (*Profile, error) {
+	if params.Name == "" {
+		return &Profile{}, ErrInvalidProfile
+	}
+
+	equipmentIDs := make([]uuid.UUID, 0, len(params.EquipmentIDs))
+	for _, id := range params.EquipmentIDs {
+		if slices.Contains(equipmentIDs, id) {
+			return &Profile{}, ErrDuplicateEquipment
+		}
+		equipmentIDs = append(equipmentIDs, id)
+	}
+
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	now := time.Now()
+	if params.CreatedAt.IsZero() {
+		params.CreatedAt = now
+	}
+	if params.UpdatedAt.IsZero() {
+		params.UpdatedAt = now
+	}
+
+	return &Profile{
+		id:           params.ID,
+		name:         params.Name,
+		equipmentIDs: equipmentIDs,
+		createdAt:    params.CreatedAt,
+		updatedAt:    params.UpdatedAt,
+	}, nil
+}
+
+func (p *Profile) GetID() uuid.UUID {
+	return p.id
+}
+
+func (p *Profile) GetName() string {
+	return p.name
+}
+
+func (p *Profile) SetName(name string) error {
+	if name == "" {
+		return ErrInvalidProfile
+	}
+	p.name = name
+	p.touch()
+	return nil
+}
+
+// GetEquipmentIDs returns the equipment in the profile in the order it was added
+func (p *Profile) GetEquipmentIDs() []uuid.UUID {
+	return slices.Clone(p.equipmentIDs)
+}
+
+// HasEquipment reports whether the equipment is in the profile
+func (p *Profile) HasEquipment(equipmentID uuid.UUID) bool {
+	return slices.Contains(p.equipmentIDs, equipmentID)
+}
+
+// AddEquipment adds equipment to the profile
+func (p *Profile) AddEquipment(equipmentID uuid.UUID) error {
+	if p.HasEquipment(equipmentID) {
+		return ErrDuplicateEquipment
+	}
+	p.equipmentIDs = append(p.equipmentIDs, equipmentID)
+	p.touch()
+	return nil
+}
+
+// RemoveEquipment removes equipment from the profile
+func (p *Profile) RemoveEquipment(equipmentID uuid.UUID) error {
+	i := slices.Index(p.equipmentIDs, equipmentID)
+	if i == -1 {
+		return ErrEquipmentNotInProfile
+	}
+	p.equipmentIDs = slices.Delete(p.equipmentIDs, i, i+1)
+	p.touch()
+	return nil
+}
+
+func (p *Profile) GetCreatedAt() time.Time {
+	return p.createdAt
+}
+
+func (p *Profile) GetUpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// touch records that the aggregate was modified
+func (p *Profile) touch() {
+	p.updatedAt = time.Now()
+}
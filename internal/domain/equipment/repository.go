@@ -0,0 +1,30 @@
+package equipment
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// EquipmentRepository defines the storage operations for Equipment entities
+type EquipmentRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, equipment *Equipment) error
+	GetByID(ctx context.Context, userID, equipmentID uuid.UUID) (*Equipment, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Equipment], error)
+	Update(ctx context.Context, userID uuid.UUID, equipment *Equipment) error
+	Delete(ctx context.Context, userID, equipmentID uuid.UUID) error
+}
+
+// ProfileRepository defines the storage operations for Profile aggregates.
+// Implementations must persist a profile together with its equipment as a single unit.
+type ProfileRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, profile *Profile) error
+	GetByID(ctx context.Context, userID, profileID uuid.UUID) (*Profile, error)
+	GetByName(ctx context.Context, userID uuid.UUID, name string) (*Profile, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Profile], error)
+	Update(ctx context.Context, userID uuid.UUID, profile *Profile) error
+	Delete(ctx context.Context, userID, profileID uuid.UUID) error
+	// Inventory returns the equipment that is in any profile of the user
+	Inventory(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
@@ -0,0 +1,98 @@
+package equipment
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// EquipmentService defines the business operations available for equipment
+type EquipmentService interface {
+	AddEquipment(ctx context.Context, userID uuid.UUID, equipment *Equipment) error
+	RemoveEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error
+	ListEquipment(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Equipment], error)
+	GetEquipmentByID(ctx context.Context, userID, equipmentID uuid.UUID) (*Equipment, error)
+	UpdateEquipment(ctx context.Context, userID uuid.UUID, equipment *Equipment) error
+}
+
+type equipmentService struct {
+	repo EquipmentRepository
+}
+
+// NewEquipmentService create a new service with the provided repository
+func NewEquipmentService(repo EquipmentRepository) EquipmentService {
+	return &equipmentService{
+		repo: repo,
+	}
+}
+
+func (s *equipmentService) AddEquipment(ctx context.Context, userID uuid.UUID, equipment *Equipment) error {
+	return s.repo.Add(ctx, userID, equipment)
+}
+
+func (s *equipmentService) RemoveEquipment(ctx context.Context, userID, equipmentID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, equipmentID)
+}
+
+func (s *equipmentService) ListEquipment(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Equipment], error) {
+	return s.repo.List(ctx, userID, q)
+}
+
+func (s *equipmentService) GetEquipmentByID(ctx context.Context, userID, equipmentID uuid.UUID) (*Equipment, error) {
+	return s.repo.GetByID(ctx, userID, equipmentID)
+}
+
+func (s *equipmentService) UpdateEquipment(ctx context.Context, userID uuid.UUID, equipment *Equipment) error {
+	return s.repo.Update(ctx, userID, equipment)
+}
+
+// ProfileService defines the business operations available for equipment profiles
+type ProfileService interface {
+	AddProfile(ctx context.Context, userID uuid.UUID, profile *Profile) error
+	GetProfileByID(ctx context.Context, userID, profileID uuid.UUID) (*Profile, error)
+	GetProfileByName(ctx context.Context, userID uuid.UUID, name string) (*Profile, error)
+	ListProfiles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Profile], error)
+	UpdateProfile(ctx context.Context, userID uuid.UUID, profile *Profile) error
+	RemoveProfile(ctx context.Context, userID, profileID uuid.UUID) error
+	Inventory(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error)
+}
+
+type profileService struct {
+	repo ProfileRepository
+}
+
+// NewProfileService create a new service with the provided repository
+func NewProfileService(repo ProfileRepository) ProfileService {
+	return &profileService{
+		repo: repo,
+	}
+}
+
+func (s *profileService) AddProfile(ctx context.Context, userID uuid.UUID, profile *Profile) error {
+	return s.repo.Add(ctx, userID, profile)
+}
+
+func (s *profileService) GetProfileByID(ctx context.Context, userID, profileID uuid.UUID) (*Profile, error) {
+	return s.repo.GetByID(ctx, userID, profileID)
+}
+
+func (s *profileService) GetProfileByName(ctx context.Context, userID uuid.UUID, name string) (*Profile, error) {
+	return s.repo.GetByName(ctx, userID, name)
+}
+
+func (s *profileService) ListProfiles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Profile], error) {
+	return s.repo.List(ctx, userID, q)
+}
+
+func (s *profileService) UpdateProfile(ctx context.Context, userID uuid.UUID, profile *Profile) error {
+	return s.repo.Update(ctx, userID, profile)
+}
+
+func (s *profileService) RemoveProfile(ctx context.Context, userID, profileID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, profileID)
+}
+
+func (s *profileService) Inventory(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	return s.repo.Inventory(ctx, userID)
+}
@@ -0,0 +1,148 @@
+package exercise
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultAlternativesLimit is the number of alternatives returned when no limit is given
+	DefaultAlternativesLimit = 10
+	// MaxAlternativesLimit is the largest number of alternatives that can be returned
+	MaxAlternativesLimit = 50
+)
+
+// ErrInvalidAlternativesLimit is returned when the number of alternatives asked for is not between 1 and MaxAlternativesLimit
+var ErrInvalidAlternativesLimit = fmt.Errorf("alternatives limit must be between 1 and %d", MaxAlternativesLimit)
+
+// Weights of the parts of the score of an alternative, they add up to 1
+const (
+	muscleWeight    = 0.6
+	patternWeight   = 0.25
+	equipmentWeight = 0.15
+)
+
+// AlternativesLimit validates the number of alternatives asked for, replacing zero by DefaultAlternativesLimit
+func AlternativesLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultAlternativesLimit, nil
+	}
+	if limit < 1 || limit > MaxAlternativesLimit {
+		return 0, ErrInvalidAlternativesLimit
+	}
+	return limit, nil
+}
+
+// RelatedFilter selects the exercises that can be ranked as alternatives to an exercise:
+// those other than it that are linked to any of the muscles or follow the movement pattern.
+type RelatedFilter struct {
+	ExerciseID      uuid.UUID
+	MuscleIDs       []uuid.UUID
+	MovementPattern MovementPattern
+}
+
+// RelatedTo returns the filter of the exercises that can be ranked as alternatives to e
+func RelatedTo(e *Exercise) RelatedFilter {
+	return RelatedFilter{
+		ExerciseID:      e.GetID(),
+		MuscleIDs:       comparedMuscles(e),
+		MovementPattern: e.GetMovementPattern(),
+	}
+}
+
+// Alternative is an exercise that can replace another one
+type Alternative struct {
+	Exercise *Exercise
+	// Score grows from 0 to 1 with how well the exercise replaces the other one
+	Score float64
+	// MuscleOverlap is the share of primary target muscles the exercises have in common,
+	// from 0 for none to 1 for the same muscles
+	MuscleOverlap float64
+	// SamePattern reports whether both exercises follow the same movement pattern
+	SamePattern bool
+	// Available reports whether all the equipment of the exercise is in the user's inventory
+	Available bool
+}
+
+// Alternatives ranks the candidates that can replace e, the best first, and returns at most limit of them.
+// A candidate is kept when it shares a primary target muscle or the movement pattern of e.
+// The score weighs the overlap of primary target muscles the most, then a shared movement pattern,
+// then whether the user owns the equipment of the candidate.
+func Alternatives(e *Exercise, candidates []*Exercise, inventory []uuid.UUID, limit int) []Alternative {
+	muscles := comparedMuscles(e)
+	pattern := e.GetMovementPattern()
+
+	alternatives := []Alternative{}
+	for _, c := range candidates {
+		if c.GetID() == e.GetID() {
+			continue
+		}
+
+		a := Alternative{
+			Exercise:      c,
+			MuscleOverlap: round(overlap(muscles, comparedMuscles(c))),
+			SamePattern:   pattern != "" && c.GetMovementPattern() == pattern,
+			Available:     c.IsAvailableWith(inventory),
+		}
+		if a.MuscleOverlap == 0 && !a.SamePattern {
+			continue
+		}
+
+		a.Score = muscleWeight * a.MuscleOverlap
+		if a.SamePattern {
+			a.Score += patternWeight
+		}
+		if a.Available {
+			a.Score += equipmentWeight
+		}
+		a.Score = round(a.Score)
+
+		alternatives = append(alternatives, a)
+	}
+
+	slices.SortFunc(alternatives, func(a, b Alternative) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.Exercise.GetName(), b.Exercise.GetName()),
+			cmp.Compare(a.Exercise.GetID().String(), b.Exercise.GetID().String()),
+		)
+	})
+
+	if len(alternatives) > limit {
+		alternatives = alternatives[:limit]
+	}
+	return alternatives
+}
+
+// comparedMuscles returns the primary target muscles of the exercise,
+// or all of its target muscles when none is linked as primary
+func comparedMuscles(e *Exercise) []uuid.UUID {
+	if ids := e.GetPrimaryMuscleIDs(); len(ids) > 0 {
+		return ids
+	}
+	return e.GetTargetMuscleIDs()
+}
+
+// overlap returns the Jaccard index of two sets of muscles, 0 when both are empty
+func overlap(a, b []uuid.UUID) float64 {
+	shared := 0
+	for _, id := range a {
+		if slices.Contains(b, id) {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
+
+// round rounds a score to three decimals
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
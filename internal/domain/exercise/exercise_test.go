@@ -0,0 +1,384 @@
+package exercise_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func activation(v float64) *float64 {
+	return &v
+}
+
+// Test cases for Exercise aggregate
+func TestNewExercise(t *testing.T) {
+	muscleID := uuid.New()
+	tests := []struct {
+		name          string
+		params        exercise.ExerciseParams
+		expectedError error
+	}{
+		{
+			name:          "Valid exercise creation",
+			params:        exercise.ExerciseParams{Name: "Bench Press", Category: "strength"},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty name",
+			params:        exercise.ExerciseParams{Category: "strength"},
+			expectedError: exercise.ErrInvalidExerciseName,
+		},
+		{
+			name: "Restores persisted values",
+			params: exercise.ExerciseParams{
+				ID:   uuid.New(),
+				Name: "Squat",
+				TargetMuscles: []exercise.TargetMuscle{
+					{MuscleID: uuid.New(), Role: exercise.RolePrimary},
+					{MuscleID: uuid.New(), Role: exercise.RoleSecondary, Activation: activation(0.3)},
+				},
+				CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Unknown muscle role",
+			params: exercise.ExerciseParams{
+				Name:          "Squat",
+				TargetMuscles: []exercise.TargetMuscle{{MuscleID: uuid.New(), Role: "main"}},
+			},
+			expectedError: exercise.ErrInvalidMuscleRole,
+		},
+		{
+			name: "Activation out of range",
+			params: exercise.ExerciseParams{
+				Name:          "Squat",
+				TargetMuscles: []exercise.TargetMuscle{{MuscleID: uuid.New(), Role: exercise.RolePrimary, Activation: activation(1.5)}},
+			},
+			expectedError: exercise.ErrInvalidActivation,
+		},
+		{
+			name: "Duplicate target muscle",
+			params: exercise.ExerciseParams{
+				Name: "Squat",
+				TargetMuscles: []exercise.TargetMuscle{
+					{MuscleID: muscleID, Role: exercise.RolePrimary},
+					{MuscleID: muscleID, Role: exercise.RoleSecondary},
+				},
+			},
+			expectedError: exercise.ErrDuplicateLink,
+		},
+		{
+			name:          "Unknown movement pattern",
+			params:        exercise.ExerciseParams{Name: "Squat", MovementPattern: "twist"},
+			expectedError: exercise.ErrInvalidMovementPattern,
+		},
+		{
+			name:          "Duplicate equipment",
+			params:        exercise.ExerciseParams{Name: "Squat", EquipmentIDs: []uuid.UUID{muscleID, muscleID}},
+			expectedError: exercise.ErrDuplicateLink,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := exercise.NewExercise(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, e.GetID())
+			assert.Equal(t, tc.params.Name, e.GetName())
+			assert.Equal(t, len(tc.params.TargetMuscles), len(e.GetTargetMuscles()))
+
+			if tc.params.ID != uuid.Nil {
+				assert.Equal(t, tc.params.ID, e.GetID())
+				assert.Equal(t, tc.params.CreatedAt, e.GetCreatedAt())
+				assert.Equal(t, tc.params.UpdatedAt, e.GetUpdatedAt())
+			}
+		})
+	}
+}
+
+func TestExercise_LinksAreCopied(t *testing.T) {
+	ids := []uuid.UUID{uuid.New()}
+	e, _ := exercise.NewExercise(exercise.ExerciseParams{Name: "Row", EquipmentIDs: ids})
+
+	assert.NoError(t, e.AddEquipment(uuid.New()))
+
+	assert.Len(t, ids, 1, "adding to the aggregate must not alias the params slice")
+	assert.Len(t, e.GetEquipments(), 2)
+}
+
+func TestExercise_DuplicateLinks(t *testing.T) {
+	muscleID, equipmentID, splitID := uuid.New(), uuid.New(), uuid.New()
+	e, _ := exercise.NewExercise(exercise.ExerciseParams{
+		Name:          "Row",
+		TargetMuscles: []exercise.TargetMuscle{{MuscleID: muscleID, Role: exercise.RolePrimary}},
+		EquipmentIDs:  []uuid.UUID{equipmentID},
+		SplitIDs:      []uuid.UUID{splitID},
+	})
+
+	assert.Equal(t, exercise.ErrDuplicateLink, e.AddTargetMuscle(exercise.TargetMuscle{MuscleID: muscleID, Role: exercise.RoleSecondary}))
+	assert.Equal(t, exercise.ErrDuplicateLink, e.AddEquipment(equipmentID))
+	assert.Equal(t, exercise.ErrDuplicateLink, e.AddSplit(splitID))
+	assert.Len(t, e.GetTargetMuscles(), 1)
+}
+
+func TestExercise_SetTargetMuscle(t *testing.T) {
+	muscleID := uuid.New()
+	e, _ := exercise.NewExercise(exercise.ExerciseParams{
+		Name:          "Row",
+		TargetMuscles: []exercise.TargetMuscle{{MuscleID: muscleID, Role: exercise.RolePrimary}},
+	})
+
+	t.Run("Changes role and activation", func(t *testing.T) {
+		link := exercise.TargetMuscle{MuscleID: muscleID, Role: exercise.RoleStabilizer, Activation: activation(0.2)}
+		assert.NoError(t, e.SetTargetMuscle(link))
+		assert.Equal(t, []exercise.TargetMuscle{link}, e.GetTargetMuscles())
+	})
+
+	t.Run("Rejects invalid role", func(t *testing.T) {
+		assert.Equal(t, exercise.ErrInvalidMuscleRole, e.SetTargetMuscle(exercise.TargetMuscle{MuscleID: muscleID}))
+	})
+
+	t.Run("Unknown muscle", func(t *testing.T) {
+		assert.Equal(t, exercise.ErrLinkNotFound, e.SetTargetMuscle(exercise.TargetMuscle{MuscleID: uuid.New(), Role: exercise.RolePrimary}))
+	})
+}
+
+func TestTargetMuscle_Fraction(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     exercise.TargetMuscle
+		expected float64
+	}{
+		{name: "Primary", link: exercise.TargetMuscle{Role: exercise.RolePrimary}, expected: 1},
+		{name: "Secondary", link: exercise.TargetMuscle{Role: exercise.RoleSecondary}, expected: 0.5},
+		{name: "Stabilizer", link: exercise.TargetMuscle{Role: exercise.RoleStabilizer}, expected: 0},
+		{name: "Activation overrides role", link: exercise.TargetMuscle{Role: exercise.RoleStabilizer, Activation: activation(0.25)}, expected: 0.25},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.link.Fraction())
+		})
+	}
+}
+
+func TestExercise_RemoveTargetMuscle(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	e, _ := exercise.NewExercise(exercise.ExerciseParams{
+		Name: "Bench Press",
+		TargetMuscles: []exercise.TargetMuscle{
+			{MuscleID: first, Role: exercise.RolePrimary},
+			{MuscleID: second, Role: exercise.RoleSecondary},
+		},
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	t.Run("Removes linked muscle", func(t *testing.T) {
+		assert.NoError(t, e.RemoveTargetMuscle(first))
+		assert.Equal(t, []uuid.UUID{second}, e.GetTargetMuscleIDs())
+		assert.True(t, e.GetUpdatedAt().After(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Unknown muscle", func(t *testing.T) {
+		assert.Equal(t, exercise.ErrLinkNotFound, e.RemoveTargetMuscle(first))
+	})
+}
+
+func TestExercise_Aliases(t *testing.T) {
+	e, err := exercise.NewExercise(exercise.ExerciseParams{
+		Name:    "Barbell Overhead Press",
+		Aliases: []string{" OHP ", "", "Military Press", "ohp"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OHP", "Military Press"}, e.GetAliases())
+
+	e.SetAliases(nil)
+	assert.Empty(t, e.GetAliases())
+}
+
+// Test cases for search query validation
+func TestNewSearchQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        exercise.SearchParams
+		expected      exercise.SearchQuery
+		expectedError error
+	}{
+		{
+			name:     "Defaults the limit and collapses whitespace",
+			params:   exercise.SearchParams{Text: "  incline   db press ", Categories: []string{" Strength ", ""}},
+			expected: exercise.SearchQuery{Text: "incline db press", Categories: []string{"strength"}, Limit: exercise.DefaultSearchLimit},
+		},
+		{
+			name:          "Blank text",
+			params:        exercise.SearchParams{Text: "   "},
+			expectedError: exercise.ErrInvalidSearchText,
+		},
+		{
+			name:          "Text too long",
+			params:        exercise.SearchParams{Text: strings.Repeat("a", exercise.MaxSearchLength+1)},
+			expectedError: exercise.ErrInvalidSearchText,
+		},
+		{
+			name:          "Limit too large",
+			params:        exercise.SearchParams{Text: "row", Limit: exercise.MaxSearchLimit + 1},
+			expectedError: exercise.ErrInvalidSearchLimit,
+		},
+		{
+			name:          "Negative limit",
+			params:        exercise.SearchParams{Text: "row", Limit: -1},
+			expectedError: exercise.ErrInvalidSearchLimit,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := exercise.NewSearchQuery(tc.params)
+
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expected, q)
+			}
+		})
+	}
+}
+
+// Test cases for checking an exercise against the equipment a user owns
+func TestExercise_IsAvailableWith(t *testing.T) {
+	barbell, bench, dumbbell := uuid.New(), uuid.New(), uuid.New()
+	benchPress, _ := exercise.NewExercise(exercise.ExerciseParams{Name: "Bench Press", EquipmentIDs: []uuid.UUID{barbell, bench}})
+	pushUp, _ := exercise.NewExercise(exercise.ExerciseParams{Name: "Push-up"})
+
+	tests := []struct {
+		name      string
+		exercise  *exercise.Exercise
+		inventory []uuid.UUID
+		expected  bool
+	}{
+		{name: "All equipment owned", exercise: benchPress, inventory: []uuid.UUID{dumbbell, bench, barbell}, expected: true},
+		{name: "Missing equipment", exercise: benchPress, inventory: []uuid.UUID{barbell, dumbbell}, expected: false},
+		{name: "No equipment needed", exercise: pushUp, inventory: nil, expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.exercise.IsAvailableWith(tc.inventory))
+		})
+	}
+}
+
+func TestExercise_SetMovementPattern(t *testing.T) {
+	e, _ := exercise.NewExercise(exercise.ExerciseParams{Name: "Row"})
+
+	assert.NoError(t, e.SetMovementPattern(exercise.PatternHorizontalPull))
+	assert.Equal(t, exercise.PatternHorizontalPull, e.GetMovementPattern())
+
+	assert.Equal(t, exercise.ErrInvalidMovementPattern, e.SetMovementPattern("pull"))
+	assert.Equal(t, exercise.PatternHorizontalPull, e.GetMovementPattern())
+
+	assert.NoError(t, e.SetMovementPattern(""))
+	assert.Empty(t, e.GetMovementPattern())
+}
+
+// Test cases for ranking the exercises that can replace another one
+func TestAlternatives(t *testing.T) {
+	chest, triceps, delts, lats := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	barbell, bench, machine := uuid.New(), uuid.New(), uuid.New()
+
+	newExercise := func(name string, pattern exercise.MovementPattern, equipment []uuid.UUID, muscles ...exercise.TargetMuscle) *exercise.Exercise {
+		e, err := exercise.NewExercise(exercise.ExerciseParams{Name: name, MovementPattern: pattern, EquipmentIDs: equipment, TargetMuscles: muscles})
+		assert.NoError(t, err)
+		return e
+	}
+	primary := func(id uuid.UUID) exercise.TargetMuscle {
+		return exercise.TargetMuscle{MuscleID: id, Role: exercise.RolePrimary}
+	}
+	secondary := func(id uuid.UUID) exercise.TargetMuscle {
+		return exercise.TargetMuscle{MuscleID: id, Role: exercise.RoleSecondary}
+	}
+
+	benchPress := newExercise("Bench Press", exercise.PatternHorizontalPush, []uuid.UUID{barbell, bench}, primary(chest), secondary(triceps))
+	chestPress := newExercise("Machine Chest Press", exercise.PatternHorizontalPush, []uuid.UUID{machine}, primary(chest), secondary(triceps))
+	pushUp := newExercise("Push-up", exercise.PatternHorizontalPush, nil, primary(chest), secondary(delts))
+	fly := newExercise("Chest Fly", "", nil, primary(chest))
+	closeGrip := newExercise("Close-Grip Bench Press", exercise.PatternHorizontalPush, []uuid.UUID{barbell, bench}, primary(chest), primary(triceps))
+	dip := newExercise("Triceps Dip", exercise.PatternHorizontalPush, nil, primary(triceps))
+	pullUp := newExercise("Pull-up", exercise.PatternVerticalPull, nil, primary(lats))
+	unlinked := newExercise("Pec Stretch", "", nil, secondary(chest))
+
+	candidates := []*exercise.Exercise{pullUp, dip, closeGrip, fly, pushUp, chestPress, benchPress, unlinked}
+
+	t.Run("Ranks by muscles, then pattern, then equipment", func(t *testing.T) {
+		alternatives := exercise.Alternatives(benchPress, candidates, []uuid.UUID{barbell, bench}, 10)
+
+		var names []string
+		for _, a := range alternatives {
+			names = append(names, a.Exercise.GetName())
+		}
+		assert.Equal(t, []string{"Push-up", "Machine Chest Press", "Chest Fly", "Pec Stretch", "Close-Grip Bench Press", "Triceps Dip"}, names)
+
+		assert.Equal(t, exercise.Alternative{Exercise: pushUp, Score: 1, MuscleOverlap: 1, SamePattern: true, Available: true}, alternatives[0])
+		assert.Equal(t, exercise.Alternative{Exercise: chestPress, Score: 0.85, MuscleOverlap: 1, SamePattern: true}, alternatives[1])
+		assert.Equal(t, exercise.Alternative{Exercise: closeGrip, Score: 0.7, MuscleOverlap: 0.5, SamePattern: true, Available: true}, alternatives[4])
+		assert.Equal(t, exercise.Alternative{Exercise: dip, Score: 0.4, SamePattern: true, Available: true}, alternatives[5])
+	})
+
+	t.Run("Owning the equipment changes the order", func(t *testing.T) {
+		withoutMachine := exercise.Alternatives(benchPress, []*exercise.Exercise{pushUp, chestPress}, nil, 10)
+		assert.Equal(t, []*exercise.Exercise{pushUp, chestPress}, []*exercise.Exercise{withoutMachine[0].Exercise, withoutMachine[1].Exercise})
+
+		// Equal scores are ordered by name
+		withMachine := exercise.Alternatives(benchPress, []*exercise.Exercise{pushUp, chestPress}, []uuid.UUID{machine}, 10)
+		assert.Equal(t, []*exercise.Exercise{chestPress, pushUp}, []*exercise.Exercise{withMachine[0].Exercise, withMachine[1].Exercise})
+		assert.True(t, withMachine[0].Available)
+		assert.Equal(t, withMachine[0].Score, withMachine[1].Score)
+	})
+
+	t.Run("Falls back to every target muscle without primary ones", func(t *testing.T) {
+		alternatives := exercise.Alternatives(unlinked, candidates, nil, 10)
+
+		var names []string
+		for _, a := range alternatives {
+			names = append(names, a.Exercise.GetName())
+		}
+		assert.Equal(t, []string{"Chest Fly", "Push-up", "Bench Press", "Machine Chest Press", "Close-Grip Bench Press"}, names)
+	})
+
+	t.Run("Stops at the limit", func(t *testing.T) {
+		assert.Len(t, exercise.Alternatives(benchPress, candidates, nil, 2), 2)
+	})
+}
+
+func TestAlternativesLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		expected      int
+		expectedError error
+	}{
+		{name: "Default", limit: 0, expected: exercise.DefaultAlternativesLimit},
+		{name: "Within range", limit: 5, expected: 5},
+		{name: "Too many", limit: exercise.MaxAlternativesLimit + 1, expectedError: exercise.ErrInvalidAlternativesLimit},
+		{name: "Negative", limit: -1, expectedError: exercise.ErrInvalidAlternativesLimit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := exercise.AlternativesLimit(tc.limit)
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
@@ -2,7 +2,8 @@ package exercise
 
 import (
 	"errors"
-	"net/url"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/CP-Payne/exercise/internal/domain/common/commonerrors"
@@ -12,43 +13,201 @@ import (
 // Errors
 var (
 	ErrInvalidExerciseName = errors.New("an exercise must have a name")
+
+	// ErrUnknownTargetMuscle is returned when linking a target muscle that does not exist for the user
+	ErrUnknownTargetMuscle = errors.New("target muscle does not exist")
+
+	// ErrUnknownEquipment is returned when linking equipment that does not exist for the user
+	ErrUnknownEquipment = errors.New("equipment does not exist")
+
+	// ErrUnknownSplit is returned when linking a split that does not exist for the user
+	ErrUnknownSplit = errors.New("split does not exist")
+
+	// ErrLinkNotFound is returned when removing a link the exercise does not have
+	ErrLinkNotFound = errors.New("exercise is not linked to that resource")
+
+	// ErrDuplicateLink is returned when linking a resource the exercise is already linked to
+	ErrDuplicateLink = errors.New("exercise is already linked to that resource")
+
+	// ErrInvalidMuscleRole is returned when a target muscle link has a role that is not one of MuscleRoles
+	ErrInvalidMuscleRole = errors.New("target muscle role must be primary, secondary or stabilizer")
+
+	// ErrInvalidActivation is returned when a target muscle link has an activation outside (0, 1]
+	ErrInvalidActivation = errors.New("target muscle activation must be greater than 0 and at most 1")
+
+	// ErrInvalidMovementPattern is returned when an exercise has a movement pattern that is not one of MovementPatterns
+	ErrInvalidMovementPattern = errors.New("movement pattern must be squat, hinge, lunge, horizontal_push, vertical_push, horizontal_pull, vertical_pull or carry")
+)
+
+// MovementPattern is the basic movement an exercise trains, exercises of the same pattern
+// can usually replace each other. The empty pattern is used for exercises that follow none,
+// such as most isolation exercises.
+type MovementPattern string
+
+const (
+	PatternSquat          MovementPattern = "squat"
+	PatternHinge          MovementPattern = "hinge"
+	PatternLunge          MovementPattern = "lunge"
+	PatternHorizontalPush MovementPattern = "horizontal_push"
+	PatternVerticalPush   MovementPattern = "vertical_push"
+	PatternHorizontalPull MovementPattern = "horizontal_pull"
+	PatternVerticalPull   MovementPattern = "vertical_pull"
+	PatternCarry          MovementPattern = "carry"
 )
 
+// MovementPatterns lists the supported patterns
+var MovementPatterns = []MovementPattern{
+	PatternSquat, PatternHinge, PatternLunge,
+	PatternHorizontalPush, PatternVerticalPush,
+	PatternHorizontalPull, PatternVerticalPull,
+	PatternCarry,
+}
+
+// Validate checks that the pattern is empty or one of MovementPatterns
+func (p MovementPattern) Validate() error {
+	if p != "" && !slices.Contains(MovementPatterns, p) {
+		return ErrInvalidMovementPattern
+	}
+	return nil
+}
+
+// MuscleRole is the part a target muscle plays in an exercise
+type MuscleRole string
+
+const (
+	// RolePrimary is a muscle the exercise is meant to train
+	RolePrimary MuscleRole = "primary"
+	// RoleSecondary is a muscle that assists the primary muscles
+	RoleSecondary MuscleRole = "secondary"
+	// RoleStabilizer is a muscle that holds the body in position without moving the load
+	RoleStabilizer MuscleRole = "stabilizer"
+)
+
+// MuscleRoles lists the supported roles
+var MuscleRoles = []MuscleRole{RolePrimary, RoleSecondary, RoleStabilizer}
+
+// defaultActivations is the fraction of a set that counts for a muscle of each role
+// when its link has no activation of its own
+var defaultActivations = map[MuscleRole]float64{
+	RolePrimary:    1,
+	RoleSecondary:  0.5,
+	RoleStabilizer: 0,
+}
+
+// TargetMuscle is the link between an exercise and one of the muscles it trains
+type TargetMuscle struct {
+	MuscleID uuid.UUID
+	Role     MuscleRole
+	// Activation is the fraction of a set that counts for the muscle, nil for the default of its role
+	Activation *float64
+}
+
+// Validate checks the role and activation of the link
+func (t TargetMuscle) Validate() error {
+	if !slices.Contains(MuscleRoles, t.Role) {
+		return ErrInvalidMuscleRole
+	}
+	if t.Activation != nil && (*t.Activation <= 0 || *t.Activation > 1) {
+		return ErrInvalidActivation
+	}
+	return nil
+}
+
+// Fraction returns how much a set of the exercise counts for the muscle
+func (t TargetMuscle) Fraction() float64 {
+	if t.Activation != nil {
+		return *t.Activation
+	}
+	return defaultActivations[t.Role]
+}
+
+// ExerciseParams contains the parameters needed to create a new Exercise
+type ExerciseParams struct {
+	ID              uuid.UUID
+	Name            string
+	Description     string
+	Aliases         []string
+	ImageID         uuid.UUID
+	SplitIDs        []uuid.UUID
+	TargetMuscles   []TargetMuscle
+	EquipmentIDs    []uuid.UUID
+	Category        string
+	MovementPattern MovementPattern
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
 // Aggregates
 type Exercise struct {
 	id              uuid.UUID
 	name            string
 	description     string
-	displayImage    url.URL
+	aliases         []string
+	imageID         uuid.UUID
 	splitIDs        []uuid.UUID
-	targetMuscleIDs []uuid.UUID
+	targetMuscles   []TargetMuscle
 	equipmentIDs    []uuid.UUID
 	category        string
+	movementPattern MovementPattern
 	createdAt       time.Time
 	updatedAt       time.Time
 }
 
-func NewExercise(name, description, category string) (Exercise, error) {
-	if name == "" {
-		return Exercise{}, ErrInvalidExerciseName
+// NewExercise creates a new Exercise aggregate with validation
+func NewExercise(params ExerciseParams) (*Exercise, error) {
+	if params.Name == "" {
+		return &Exercise{}, ErrInvalidExerciseName
+	}
+
+	if err := params.MovementPattern.Validate(); err != nil {
+		return &Exercise{}, err
+	}
+
+	for _, t := range params.TargetMuscles {
+		if err := t.Validate(); err != nil {
+			return &Exercise{}, err
+		}
+	}
+
+	if hasDuplicates(params.SplitIDs) || hasDuplicates(targetMuscleIDs(params.TargetMuscles)) || hasDuplicates(params.EquipmentIDs) {
+		return &Exercise{}, ErrDuplicateLink
 	}
 
-	return Exercise{
-		id:              uuid.New(),
-		name:            name,
-		description:     description,
-		displayImage:    url.URL{},
-		splitIDs:        make([]uuid.UUID, 0),
-		targetMuscleIDs: make([]uuid.UUID, 0),
-		equipmentIDs:    make([]uuid.UUID, 0),
-		category:        category,
-		createdAt:       time.Now(),
-		updatedAt:       time.Now(),
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	now := time.Now()
+	if params.CreatedAt.IsZero() {
+		params.CreatedAt = now
+	}
+	if params.UpdatedAt.IsZero() {
+		params.UpdatedAt = now
+	}
+
+	return &Exercise{
+		id:              params.ID,
+		name:            params.Name,
+		description:     params.Description,
+		aliases:         normalizeAliases(params.Aliases),
+		imageID:         params.ImageID,
+		splitIDs:        append(make([]uuid.UUID, 0, len(params.SplitIDs)), params.SplitIDs...),
+		targetMuscles:   append(make([]TargetMuscle, 0, len(params.TargetMuscles)), params.TargetMuscles...),
+		equipmentIDs:    append(make([]uuid.UUID, 0, len(params.EquipmentIDs)), params.EquipmentIDs...),
+		category:        params.Category,
+		movementPattern: params.MovementPattern,
+		createdAt:       params.CreatedAt,
+		updatedAt:       params.UpdatedAt,
 	}, nil
 }
 
+func (e *Exercise) GetID() uuid.UUID {
+	return e.id
+}
+
 func (e *Exercise) SetName(name string) {
 	e.name = name
+	e.touch()
 }
 
 func (e *Exercise) GetName() string {
@@ -57,52 +216,168 @@ func (e *Exercise) GetName() string {
 
 func (e *Exercise) SetDescription(description string) {
 	e.description = description
+	e.touch()
 }
 
 func (e *Exercise) GetDescription() string {
 	return e.description
 }
 
-func (e *Exercise) SetDisplayImage(displayImage url.URL) {
-	e.displayImage = displayImage
+// SetAliases replaces the other names the exercise is known by, such as abbreviations
+func (e *Exercise) SetAliases(aliases []string) {
+	e.aliases = normalizeAliases(aliases)
+	e.touch()
+}
+
+func (e *Exercise) GetAliases() []string {
+	return e.aliases
+}
+
+// SetImageID changes the uploaded display image of the exercise, uuid.Nil removes it
+func (e *Exercise) SetImageID(imageID uuid.UUID) {
+	e.imageID = imageID
+	e.touch()
+}
+
+// GetImageID returns the ID of the uploaded display image, uuid.Nil when the exercise has none
+func (e *Exercise) GetImageID() uuid.UUID {
+	return e.imageID
 }
 
-func (e *Exercise) GetDisplayImage() url.URL {
-	return e.displayImage
+// AddSplit links a split to the exercise
+func (e *Exercise) AddSplit(splitID uuid.UUID) error {
+	return e.addLink(&e.splitIDs, splitID)
 }
 
-func (e *Exercise) AddSplit(splitID uuid.UUID) {
-	e.splitIDs = append(e.splitIDs, splitID)
+// RemoveSplit unlinks a split from the exercise
+func (e *Exercise) RemoveSplit(splitID uuid.UUID) error {
+	return e.removeLink(&e.splitIDs, splitID)
 }
 
 func (e *Exercise) GetSplits() []uuid.UUID {
 	return e.splitIDs
 }
 
-func (e *Exercise) AddTargetMuscle(targetMuscleID uuid.UUID) {
-	e.targetMuscleIDs = append(e.targetMuscleIDs, targetMuscleID)
+// AddTargetMuscle links a target muscle to the exercise with its role and activation
+func (e *Exercise) AddTargetMuscle(link TargetMuscle) error {
+	if err := link.Validate(); err != nil {
+		return err
+	}
+	if e.HasTargetMuscle(link.MuscleID) {
+		return ErrDuplicateLink
+	}
+	e.targetMuscles = append(e.targetMuscles, link)
+	e.touch()
+	return nil
+}
+
+// SetTargetMuscle changes the role and activation of a linked target muscle
+func (e *Exercise) SetTargetMuscle(link TargetMuscle) error {
+	if err := link.Validate(); err != nil {
+		return err
+	}
+	i := e.targetMuscleIndex(link.MuscleID)
+	if i == -1 {
+		return ErrLinkNotFound
+	}
+	e.targetMuscles[i] = link
+	e.touch()
+	return nil
 }
 
-func (e *Exercise) GetTargetMuscles() []uuid.UUID {
-	return e.targetMuscleIDs
+// RemoveTargetMuscle unlinks a target muscle from the exercise
+func (e *Exercise) RemoveTargetMuscle(targetMuscleID uuid.UUID) error {
+	i := e.targetMuscleIndex(targetMuscleID)
+	if i == -1 {
+		return ErrLinkNotFound
+	}
+	e.targetMuscles = slices.Delete(e.targetMuscles, i, i+1)
+	e.touch()
+	return nil
 }
 
-func (e *Exercise) AddEquipment(equipmentID uuid.UUID) {
-	e.equipmentIDs = append(e.equipmentIDs, equipmentID)
+// HasTargetMuscle reports whether the muscle is linked to the exercise
+func (e *Exercise) HasTargetMuscle(targetMuscleID uuid.UUID) bool {
+	return e.targetMuscleIndex(targetMuscleID) != -1
+}
+
+// GetTargetMuscles returns the target muscle links in the order they were added
+func (e *Exercise) GetTargetMuscles() []TargetMuscle {
+	return e.targetMuscles
+}
+
+// GetPrimaryMuscleIDs returns the IDs of the target muscles linked as RolePrimary
+func (e *Exercise) GetPrimaryMuscleIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for _, t := range e.targetMuscles {
+		if t.Role == RolePrimary {
+			ids = append(ids, t.MuscleID)
+		}
+	}
+	return ids
+}
+
+// GetTargetMuscleIDs returns the IDs of the target muscles in the order they were added
+func (e *Exercise) GetTargetMuscleIDs() []uuid.UUID {
+	return targetMuscleIDs(e.targetMuscles)
+}
+
+func (e *Exercise) targetMuscleIndex(targetMuscleID uuid.UUID) int {
+	return slices.IndexFunc(e.targetMuscles, func(t TargetMuscle) bool { return t.MuscleID == targetMuscleID })
+}
+
+// AddEquipment links equipment to the exercise
+func (e *Exercise) AddEquipment(equipmentID uuid.UUID) error {
+	return e.addLink(&e.equipmentIDs, equipmentID)
+}
+
+// RemoveEquipment unlinks equipment from the exercise
+func (e *Exercise) RemoveEquipment(equipmentID uuid.UUID) error {
+	return e.removeLink(&e.equipmentIDs, equipmentID)
 }
 
 func (e *Exercise) GetEquipments() []uuid.UUID {
 	return e.equipmentIDs
 }
 
+// IsAvailableWith reports whether all the equipment of the exercise is in equipmentIDs.
+// An exercise without equipment is always available.
+func (e *Exercise) IsAvailableWith(equipmentIDs []uuid.UUID) bool {
+	for _, id := range e.equipmentIDs {
+		if !slices.Contains(equipmentIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *Exercise) SetCategory(category string) {
 	e.category = category
+	e.touch()
 }
 
 func (e *Exercise) GetCategory() string {
 	return e.category
 }
 
+// SetMovementPattern changes the movement pattern of the exercise, empty for none
+func (e *Exercise) SetMovementPattern(pattern MovementPattern) error {
+	if err := pattern.Validate(); err != nil {
+		return err
+	}
+	e.movementPattern = pattern
+	e.touch()
+	return nil
+}
+
+func (e *Exercise) GetMovementPattern() MovementPattern {
+	return e.movementPattern
+}
+
+func (e *Exercise) GetCreatedAt() time.Time {
+	return e.createdAt
+}
+
 func (e *Exercise) GetUpdatedAt() time.Time {
 	return e.updatedAt
 }
@@ -114,3 +389,61 @@ func (e *Exercise) SetUpdatedAt(t time.Time) error {
 	e.updatedAt = t
 	return nil
 }
+
+// touch records that the aggregate was modified
+func (e *Exercise) touch() {
+	e.updatedAt = time.Now()
+}
+
+// addLink appends id to links unless it is already there
+func (e *Exercise) addLink(links *[]uuid.UUID, id uuid.UUID) error {
+	if slices.Contains(*links, id) {
+		return ErrDuplicateLink
+	}
+	*links = append(*links, id)
+	e.touch()
+	return nil
+}
+
+// removeLink deletes id from links, keeping the order of the remaining links
+func (e *Exercise) removeLink(links *[]uuid.UUID, id uuid.UUID) error {
+	i := slices.Index(*links, id)
+	if i == -1 {
+		return ErrLinkNotFound
+	}
+	*links = slices.Delete(*links, i, i+1)
+	e.touch()
+	return nil
+}
+
+// normalizeAliases trims the aliases and drops empty ones and those repeated with a different case
+func normalizeAliases(aliases []string) []string {
+	normalized := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		if a == "" || slices.ContainsFunc(normalized, func(n string) bool { return strings.EqualFold(n, a) }) {
+			continue
+		}
+		normalized = append(normalized, a)
+	}
+	return normalized
+}
+
+func targetMuscleIDs(links []TargetMuscle) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(links))
+	for _, t := range links {
+		ids = append(ids, t.MuscleID)
+	}
+	return ids
+}
+
+func hasDuplicates(ids []uuid.UUID) bool {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return true
+		}
+		seen[id] = true
+	}
+	return false
+}
@@ -1,10 +1,28 @@
 package exercise
 
-import "github.com/google/uuid"
+import (
+	"context"
 
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// ExerciseRepository defines the storage operations for Exercise aggregates.
+// Implementations must persist an aggregate together with its splits,
+// target muscles and equipment as a single unit.
 type ExerciseRepository interface {
-	Get(uuid.UUID) (Exercise, error)
-	Add(Exercise) error
-	Update(Exercise) error
+	Add(ctx context.Context, userID uuid.UUID, exercise *Exercise) error
+	GetByID(ctx context.Context, userID, exerciseID uuid.UUID) (*Exercise, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query, filter ListFilter) (listquery.Page[*Exercise], error)
+	Search(ctx context.Context, userID uuid.UUID, q SearchQuery) ([]SearchResult, error)
+	ListRelated(ctx context.Context, userID uuid.UUID, filter RelatedFilter) ([]*Exercise, error)
+	Update(ctx context.Context, userID uuid.UUID, exercise *Exercise) error
+	Delete(ctx context.Context, userID, exerciseID uuid.UUID) error
 }
 
+// ListFilter narrows a list of exercises down. The zero value keeps every exercise.
+type ListFilter struct {
+	// AvailableWith keeps only the exercises whose equipment is all in it, see Exercise.IsAvailableWith.
+	// It is ignored when nil.
+	AvailableWith []uuid.UUID
+}
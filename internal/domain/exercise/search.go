@@ -0,0 +1,95 @@
+package exercise
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSearchLimit is the number of results returned when no limit is given
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest number of results a search can return
+	MaxSearchLimit = 100
+	// MaxSearchLength is the longest search text accepted, in characters
+	MaxSearchLength = 200
+)
+
+var (
+	// ErrInvalidSearchText is returned when the search text is empty or longer than MaxSearchLength
+	ErrInvalidSearchText = fmt.Errorf("search text must contain between 1 and %d characters", MaxSearchLength)
+
+	// ErrInvalidSearchLimit is returned when the number of results asked for is not between 1 and MaxSearchLimit
+	ErrInvalidSearchLimit = fmt.Errorf("search limit must be between 1 and %d", MaxSearchLimit)
+)
+
+// SearchParams contains the parameters needed to create a new SearchQuery
+type SearchParams struct {
+	Text         string
+	MuscleIDs    []uuid.UUID
+	EquipmentIDs []uuid.UUID
+	SplitIDs     []uuid.UUID
+	Categories   []string
+	Limit        int
+}
+
+// SearchQuery describes a search over the name, aliases and description of exercises.
+// Results must match every filter that is set and any of the values given for it.
+type SearchQuery struct {
+	Text         string
+	MuscleIDs    []uuid.UUID
+	EquipmentIDs []uuid.UUID
+	SplitIDs     []uuid.UUID
+	Categories   []string
+	Limit        int
+}
+
+// NewSearchQuery creates a SearchQuery with validation. Runs of whitespace in the text are collapsed
+// and a zero limit is replaced by DefaultSearchLimit.
+func NewSearchQuery(params SearchParams) (SearchQuery, error) {
+	text := strings.Join(strings.Fields(params.Text), " ")
+	if text == "" || len([]rune(text)) > MaxSearchLength {
+		return SearchQuery{}, ErrInvalidSearchText
+	}
+
+	if params.Limit == 0 {
+		params.Limit = DefaultSearchLimit
+	}
+	if params.Limit < 1 || params.Limit > MaxSearchLimit {
+		return SearchQuery{}, ErrInvalidSearchLimit
+	}
+
+	categories := make([]string, 0, len(params.Categories))
+	for _, c := range params.Categories {
+		if c = strings.TrimSpace(c); c != "" {
+			categories = append(categories, strings.ToLower(c))
+		}
+	}
+
+	return SearchQuery{
+		Text:         text,
+		MuscleIDs:    params.MuscleIDs,
+		EquipmentIDs: params.EquipmentIDs,
+		SplitIDs:     params.SplitIDs,
+		Categories:   categories,
+		Limit:        params.Limit,
+	}, nil
+}
+
+// Highlights are the parts of an exercise that matched a search with the matched words marked
+type Highlights struct {
+	Name string
+	// Description holds the fragments of the description that matched, empty when none did
+	Description string
+	// Aliases holds the aliases that matched
+	Aliases []string
+}
+
+// SearchResult is an exercise found by a search.
+// Rank grows with relevance and is only comparable between results of the same search.
+type SearchResult struct {
+	Exercise   *Exercise
+	Rank       float64
+	Highlights Highlights
+}
@@ -1 +1,58 @@
 package exercise
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// ExerciseService defines the business operations available for exercises
+type ExerciseService interface {
+	AddExercise(ctx context.Context, userID uuid.UUID, exercise *Exercise) error
+	GetExerciseByID(ctx context.Context, userID, exerciseID uuid.UUID) (*Exercise, error)
+	ListExercises(ctx context.Context, userID uuid.UUID, q listquery.Query, filter ListFilter) (listquery.Page[*Exercise], error)
+	SearchExercises(ctx context.Context, userID uuid.UUID, q SearchQuery) ([]SearchResult, error)
+	ListRelatedExercises(ctx context.Context, userID uuid.UUID, filter RelatedFilter) ([]*Exercise, error)
+	UpdateExercise(ctx context.Context, userID uuid.UUID, exercise *Exercise) error
+	RemoveExercise(ctx context.Context, userID, exerciseID uuid.UUID) error
+}
+
+type exerciseService struct {
+	repo ExerciseRepository
+}
+
+// NewExerciseService create a new service with the provided repository
+func NewExerciseService(repo ExerciseRepository) ExerciseService {
+	return &exerciseService{
+		repo: repo,
+	}
+}
+
+func (s *exerciseService) AddExercise(ctx context.Context, userID uuid.UUID, exercise *Exercise) error {
+	return s.repo.Add(ctx, userID, exercise)
+}
+
+func (s *exerciseService) GetExerciseByID(ctx context.Context, userID, exerciseID uuid.UUID) (*Exercise, error) {
+	return s.repo.GetByID(ctx, userID, exerciseID)
+}
+
+func (s *exerciseService) UpdateExercise(ctx context.Context, userID uuid.UUID, exercise *Exercise) error {
+	return s.repo.Update(ctx, userID, exercise)
+}
+
+func (s *exerciseService) ListExercises(ctx context.Context, userID uuid.UUID, q listquery.Query, filter ListFilter) (listquery.Page[*Exercise], error) {
+	return s.repo.List(ctx, userID, q, filter)
+}
+
+func (s *exerciseService) SearchExercises(ctx context.Context, userID uuid.UUID, q SearchQuery) ([]SearchResult, error) {
+	return s.repo.Search(ctx, userID, q)
+}
+
+func (s *exerciseService) ListRelatedExercises(ctx context.Context, userID uuid.UUID, filter RelatedFilter) ([]*Exercise, error) {
+	return s.repo.ListRelated(ctx, userID, filter)
+}
+
+func (s *exerciseService) RemoveExercise(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, exerciseID)
+}
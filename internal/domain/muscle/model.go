@@ -9,21 +9,79 @@ import (
 var (
 	// ErrInvalidMuscle is returned when attempting to create a muscle without a name
 	ErrInvalidMuscle = errors.New("a muscle must have a name")
+
+	// ErrCatalogMuscleReadOnly is returned when a user attempts to modify or delete a catalog muscle
+	ErrCatalogMuscleReadOnly = errors.New("catalog muscles cannot be modified, hide them instead")
+
+	// ErrNotCatalogMuscle is returned when a user attempts to hide one of their own muscles
+	ErrNotCatalogMuscle = errors.New("only catalog muscles can be hidden")
+
+	// ErrInvalidKind is returned when a muscle kind is not one of region, group, muscle or head
+	ErrInvalidKind = errors.New("a muscle kind must be one of region, group, muscle or head")
+
+	// ErrUnknownParent is returned when the parent of a muscle does not exist for the user
+	ErrUnknownParent = errors.New("parent muscle does not exist")
+
+	// ErrInvalidParent is returned when a muscle would be placed below a muscle of the same or a lower level,
+	// below itself, or below a private muscle while being part of the catalog
+	ErrInvalidParent = errors.New("parent muscle must be a higher level in the hierarchy")
+
+	// ErrVersionConflict is returned when a muscle was changed since the version the caller last read
+	ErrVersionConflict = errors.New("muscle was modified by another request")
+)
+
+// Origin describes who owns a muscle
+type Origin string
+
+const (
+	// OriginCatalog marks a muscle from the shared catalog, visible to every user
+	OriginCatalog Origin = "catalog"
+	// OriginUser marks a private muscle created by a user
+	OriginUser Origin = "user"
 )
 
+// Kind is the level of a muscle in the hierarchy, e.g. Legs > Quadriceps > Rectus Femoris
+type Kind string
+
+const (
+	KindRegion Kind = "region"
+	KindGroup  Kind = "group"
+	KindMuscle Kind = "muscle"
+	KindHead   Kind = "head"
+)
+
+// kindLevels orders the kinds from the top of the hierarchy to the bottom
+var kindLevels = map[Kind]int{
+	KindRegion: 0,
+	KindGroup:  1,
+	KindMuscle: 2,
+	KindHead:   3,
+}
+
 // MuscleParams contains the parameters needed to create a new Muscle
 type MuscleParams struct {
-	ID   uuid.UUID
-	Name string
+	ID       uuid.UUID
+	Name     string
+	Origin   Origin
+	Kind     Kind
+	ParentID uuid.UUID
+	Version  int
 }
 
-// Muscle represents a muscle in the exercise system
+// Muscle represents a muscle in the exercise system.
+// Muscles form a tree where a region holds groups, a group holds muscles and a muscle holds heads.
 type Muscle struct {
-	id   uuid.UUID
-	name string
+	id       uuid.UUID
+	name     string
+	origin   Origin
+	kind     Kind
+	parentID uuid.UUID
+	version  int
 }
 
-// NewMuscle creates a new Muscle entity with validation
+// NewMuscle creates a new Muscle entity with validation.
+// Muscles are private to a user unless the origin says otherwise
+// and are individual muscles unless the kind says otherwise.
 func NewMuscle(params MuscleParams) (*Muscle, error) {
 	if params.Name == "" {
 		return &Muscle{}, ErrInvalidMuscle
@@ -33,11 +91,75 @@ func NewMuscle(params MuscleParams) (*Muscle, error) {
 		params.ID = uuid.New()
 	}
 
+	if params.Origin == "" {
+		params.Origin = OriginUser
+	}
+
+	if params.Kind == "" {
+		params.Kind = KindMuscle
+	}
+
+	if _, ok := kindLevels[params.Kind]; !ok {
+		return &Muscle{}, ErrInvalidKind
+	}
+
+	if params.Version == 0 {
+		params.Version = 1
+	}
+
 	return &Muscle{
-		id:   params.ID,
-		name: params.Name,
+		id:       params.ID,
+		name:     params.Name,
+		origin:   params.Origin,
+		kind:     params.Kind,
+		parentID: params.ParentID,
+		version:  params.Version,
 	}, nil
 }
 
-func (m *Muscle) ID() uuid.UUID { return m.id }
-func (m *Muscle) Name() string  { return m.name }
+func (m *Muscle) ID() uuid.UUID       { return m.id }
+func (m *Muscle) Name() string        { return m.name }
+func (m *Muscle) Origin() Origin      { return m.origin }
+func (m *Muscle) IsCatalog() bool     { return m.origin == OriginCatalog }
+func (m *Muscle) Kind() Kind          { return m.kind }
+func (m *Muscle) ParentID() uuid.UUID { return m.parentID }
+func (m *Muscle) HasParent() bool     { return m.parentID != uuid.Nil }
+
+// Version is incremented by the repository on every update and is used to detect concurrent updates
+func (m *Muscle) Version() int { return m.version }
+
+func (m *Muscle) Rename(name string) error {
+	if name == "" {
+		return ErrInvalidMuscle
+	}
+	m.name = name
+	return nil
+}
+
+func (m *Muscle) SetKind(kind Kind) error {
+	if _, ok := kindLevels[kind]; !ok {
+		return ErrInvalidKind
+	}
+	m.kind = kind
+	return nil
+}
+
+// SetParent moves the muscle below another muscle, or to the top of the hierarchy for uuid.Nil.
+// The caller is responsible for checking the new parent with CanHaveParent.
+func (m *Muscle) SetParent(parentID uuid.UUID) {
+	m.parentID = parentID
+}
+
+// CanHaveParent checks that parent may hold this muscle in the hierarchy
+func (m *Muscle) CanHaveParent(parent *Muscle) error {
+	if parent.id == m.id {
+		return ErrInvalidParent
+	}
+	if kindLevels[parent.kind] >= kindLevels[m.kind] {
+		return ErrInvalidParent
+	}
+	if m.IsCatalog() && !parent.IsCatalog() {
+		return ErrInvalidParent
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -29,15 +30,45 @@ func (m *MockMuscleRepository) GetByID(ctx context.Context, userID, muscleID uui
 	return args.Get(0).(*muscle.Muscle), args.Error(1)
 }
 
-func (m *MockMuscleRepository) List(ctx context.Context, userID uuid.UUID) ([]*muscle.Muscle, error) {
-	args := m.Called(ctx, userID)
+func (m *MockMuscleRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
+	args := m.Called(ctx, userID, q)
+	return args.Get(0).(listquery.Page[*muscle.Muscle]), args.Error(1)
+}
+
+func (m *MockMuscleRepository) Update(ctx context.Context, userID uuid.UUID, muscle *muscle.Muscle) error {
+	args := m.Called(ctx, userID, muscle)
+	return args.Error(0)
+}
+
+func (m *MockMuscleRepository) Delete(ctx context.Context, userID, muscleID uuid.UUID) error {
+	args := m.Called(ctx, userID, muscleID)
+	return args.Error(0)
+}
+
+func (m *MockMuscleRepository) Subtree(ctx context.Context, userID, muscleID uuid.UUID) ([]*muscle.Muscle, error) {
+	args := m.Called(ctx, userID, muscleID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*muscle.Muscle), args.Error(1)
 }
 
-func (m *MockMuscleRepository) Delete(ctx context.Context, userID, muscleID uuid.UUID) error {
+func (m *MockMuscleRepository) AddToCatalog(ctx context.Context, muscle *muscle.Muscle) (uuid.UUID, error) {
+	args := m.Called(ctx, muscle)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockMuscleRepository) ListHidden(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
+	args := m.Called(ctx, userID, q)
+	return args.Get(0).(listquery.Page[*muscle.Muscle]), args.Error(1)
+}
+
+func (m *MockMuscleRepository) Hide(ctx context.Context, userID, muscleID uuid.UUID) error {
+	args := m.Called(ctx, userID, muscleID)
+	return args.Error(0)
+}
+
+func (m *MockMuscleRepository) Unhide(ctx context.Context, userID, muscleID uuid.UUID) error {
 	args := m.Called(ctx, userID, muscleID)
 	return args.Error(0)
 }
@@ -73,6 +104,14 @@ func TestNewMuscle(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "Unknown kind",
+			params: muscle.MuscleParams{
+				Name: "Triceps",
+				Kind: "bone",
+			},
+			expectedError: muscle.ErrInvalidKind,
+		},
 	}
 
 	for _, tc := range tests {
@@ -171,41 +210,43 @@ func TestMuscleService_ListMuscles(t *testing.T) {
 
 	ctx := context.Background()
 	userID := uuid.New()
+	q := listquery.Default()
 
 	muscle1, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Biceps"})
 	muscle2, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Triceps"})
-	muscleList := []*muscle.Muscle{muscle1, muscle2}
+	musclePage := listquery.Page[*muscle.Muscle]{Items: []*muscle.Muscle{muscle1, muscle2}, NextCursor: "next"}
 
 	t.Run("Successful list", func(t *testing.T) {
-		mockRepo.On("List", ctx, userID).Return(muscleList, nil).Once()
+		mockRepo.On("List", ctx, userID, q).Return(musclePage, nil).Once()
 
-		result, err := service.ListMuscles(ctx, userID)
+		result, err := service.ListMuscles(ctx, userID, q)
 
 		assert.NoError(t, err)
-		assert.Equal(t, muscleList, result)
-		assert.Len(t, result, 2)
+		assert.Equal(t, musclePage, result)
+		assert.Len(t, result.Items, 2)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Empty list", func(t *testing.T) {
-		emptyList := []*muscle.Muscle{}
-		mockRepo.On("List", ctx, userID).Return(emptyList, nil).Once()
+		emptyPage := listquery.Page[*muscle.Muscle]{Items: []*muscle.Muscle{}}
+		mockRepo.On("List", ctx, userID, q).Return(emptyPage, nil).Once()
 
-		result, err := service.ListMuscles(ctx, userID)
+		result, err := service.ListMuscles(ctx, userID, q)
 
 		assert.NoError(t, err)
-		assert.Empty(t, result)
+		assert.Empty(t, result.Items)
+		assert.Empty(t, result.NextCursor)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Repository error", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		mockRepo.On("List", ctx, userID).Return(nil, expectedErr).Once()
+		mockRepo.On("List", ctx, userID, q).Return(listquery.Page[*muscle.Muscle]{}, expectedErr).Once()
 
-		result, err := service.ListMuscles(ctx, userID)
+		result, err := service.ListMuscles(ctx, userID, q)
 
 		assert.Equal(t, expectedErr, err)
-		assert.Nil(t, result)
+		assert.Nil(t, result.Items)
 		mockRepo.AssertExpectations(t)
 	})
 }
@@ -237,3 +278,166 @@ func TestMuscleService_RemoveMuscle(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestMuscleService_HideMuscle(t *testing.T) {
+	mockRepo := new(MockMuscleRepository)
+	service := muscle.NewMuscleService(mockRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	catalogMuscle, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Biceps", Origin: muscle.OriginCatalog})
+	privateMuscle, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Brachialis"})
+
+	t.Run("Hide catalog muscle", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, userID, catalogMuscle.ID()).Return(catalogMuscle, nil).Once()
+		mockRepo.On("Hide", ctx, userID, catalogMuscle.ID()).Return(nil).Once()
+
+		err := service.HideMuscle(ctx, userID, catalogMuscle.ID())
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Private muscles cannot be hidden", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, userID, privateMuscle.ID()).Return(privateMuscle, nil).Once()
+
+		err := service.HideMuscle(ctx, userID, privateMuscle.ID())
+
+		assert.Equal(t, muscle.ErrNotCatalogMuscle, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMuscleService_AddCatalogMuscle(t *testing.T) {
+	mockRepo := new(MockMuscleRepository)
+	service := muscle.NewMuscleService(mockRepo)
+
+	ctx := context.Background()
+	m, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Deltoids"})
+
+	storedID := uuid.New()
+	mockRepo.On("AddToCatalog", ctx, m).Return(storedID, nil).Once()
+
+	id, err := service.AddCatalogMuscle(ctx, m)
+
+	assert.NoError(t, err)
+	assert.Equal(t, storedID, id)
+	assert.Equal(t, muscle.OriginCatalog, m.Origin())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestMuscle_CanHaveParent(t *testing.T) {
+	legs, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Legs", Kind: muscle.KindRegion, Origin: muscle.OriginCatalog})
+	quads, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Quadriceps", Kind: muscle.KindGroup, Origin: muscle.OriginCatalog})
+	private, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "My Group", Kind: muscle.KindGroup})
+	rectus, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Rectus Femoris", Origin: muscle.OriginCatalog})
+
+	tests := []struct {
+		name          string
+		child         *muscle.Muscle
+		parent        *muscle.Muscle
+		expectedError error
+	}{
+		{name: "Group below region", child: quads, parent: legs},
+		{name: "Muscle below region skipping a level", child: rectus, parent: legs},
+		{name: "Region below group", child: legs, parent: quads, expectedError: muscle.ErrInvalidParent},
+		{name: "Same level", child: private, parent: quads, expectedError: muscle.ErrInvalidParent},
+		{name: "Itself", child: quads, parent: quads, expectedError: muscle.ErrInvalidParent},
+		{name: "Catalog muscle below private muscle", child: rectus, parent: private, expectedError: muscle.ErrInvalidParent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedError, tc.child.CanHaveParent(tc.parent))
+		})
+	}
+}
+
+func TestMuscleService_GetMuscleTree(t *testing.T) {
+	mockRepo := new(MockMuscleRepository)
+	service := muscle.NewMuscleService(mockRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	legs, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Legs", Kind: muscle.KindRegion})
+	quads, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Quadriceps", Kind: muscle.KindGroup, ParentID: legs.ID()})
+	calves, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Calves", Kind: muscle.KindGroup, ParentID: legs.ID()})
+	rectus, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Rectus Femoris", ParentID: quads.ID()})
+
+	t.Run("Builds nested tree", func(t *testing.T) {
+		mockRepo.On("Subtree", ctx, userID, legs.ID()).Return([]*muscle.Muscle{legs, calves, quads, rectus}, nil).Once()
+
+		tree, err := service.GetMuscleTree(ctx, userID, legs.ID())
+
+		assert.NoError(t, err)
+		assert.Equal(t, legs, tree.Muscle)
+		assert.Len(t, tree.Children, 2)
+		assert.Equal(t, calves, tree.Children[0].Muscle)
+		assert.Equal(t, quads, tree.Children[1].Muscle)
+		assert.Equal(t, rectus, tree.Children[1].Children[0].Muscle)
+		assert.Equal(t, []uuid.UUID{legs.ID(), calves.ID(), quads.ID(), rectus.ID()}, tree.IDs())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		expectedErr := errors.New("not found")
+		mockRepo.On("Subtree", ctx, userID, legs.ID()).Return(nil, expectedErr).Once()
+
+		tree, err := service.GetMuscleTree(ctx, userID, legs.ID())
+
+		assert.Nil(t, tree)
+		assert.Equal(t, expectedErr, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestMuscleService_UpdateMuscle(t *testing.T) {
+	mockRepo := new(MockMuscleRepository)
+	service := muscle.NewMuscleService(mockRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	privateMuscle, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Quadriceps"})
+	catalogMuscle, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Chest", Origin: muscle.OriginCatalog})
+
+	t.Run("Successful update", func(t *testing.T) {
+		assert.NoError(t, privateMuscle.Rename("Quads"))
+		mockRepo.On("Update", ctx, userID, privateMuscle).Return(nil).Once()
+
+		assert.NoError(t, service.UpdateMuscle(ctx, userID, privateMuscle))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent update", func(t *testing.T) {
+		mockRepo.On("Update", ctx, userID, privateMuscle).Return(muscle.ErrVersionConflict).Once()
+
+		assert.Equal(t, muscle.ErrVersionConflict, service.UpdateMuscle(ctx, userID, privateMuscle))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Catalog muscles are read-only", func(t *testing.T) {
+		assert.Equal(t, muscle.ErrCatalogMuscleReadOnly, service.UpdateMuscle(ctx, userID, catalogMuscle))
+		mockRepo.AssertNotCalled(t, "Update", ctx, userID, catalogMuscle)
+	})
+}
+
+func TestMuscle_Mutators(t *testing.T) {
+	m, _ := muscle.NewMuscle(muscle.MuscleParams{Name: "Biceps"})
+	assert.Equal(t, 1, m.Version())
+
+	assert.Equal(t, muscle.ErrInvalidMuscle, m.Rename(""))
+	assert.Equal(t, "Biceps", m.Name())
+
+	assert.Equal(t, muscle.ErrInvalidKind, m.SetKind("bone"))
+	assert.NoError(t, m.SetKind(muscle.KindGroup))
+	assert.Equal(t, muscle.KindGroup, m.Kind())
+
+	parentID := uuid.New()
+	m.SetParent(parentID)
+	assert.Equal(t, parentID, m.ParentID())
+	m.SetParent(uuid.Nil)
+	assert.False(t, m.HasParent())
+}
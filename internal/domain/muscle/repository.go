@@ -3,6 +3,7 @@ package muscle
 import (
 	"context"
 
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/google/uuid"
 )
 
@@ -10,6 +11,20 @@ import (
 type MuscleRepository interface {
 	Add(ctx context.Context, userId uuid.UUID, muscle *Muscle) error
 	GetByID(ctx context.Context, userID, muscleID uuid.UUID) (*Muscle, error)
-	List(ctx context.Context, userId uuid.UUID) ([]*Muscle, error)
+	List(ctx context.Context, userId uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error)
+	// Update stores the muscle if the stored version still equals muscle.Version(), incrementing the version.
+	// Returns ErrVersionConflict otherwise.
+	Update(ctx context.Context, userID uuid.UUID, muscle *Muscle) error
 	Delete(ctx context.Context, userID, muscleID uuid.UUID) error
+
+	// Subtree returns the muscle and all muscles below it, parents before children
+	Subtree(ctx context.Context, userID, muscleID uuid.UUID) ([]*Muscle, error)
+
+	// AddToCatalog stores a muscle in the shared catalog and returns its stored ID.
+	// If the catalog already has one with that name, that muscle is moved to the new place in the hierarchy instead.
+	AddToCatalog(ctx context.Context, muscle *Muscle) (uuid.UUID, error)
+	// ListHidden returns the catalog muscles the user has hidden from their view
+	ListHidden(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error)
+	Hide(ctx context.Context, userID, muscleID uuid.UUID) error
+	Unhide(ctx context.Context, userID, muscleID uuid.UUID) error
 }
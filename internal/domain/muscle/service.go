@@ -3,15 +3,23 @@ package muscle
 import (
 	"context"
 
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/google/uuid"
 )
 
 // MuscleService defines the business operations available for muscles
 type MuscleService interface {
 	AddMuscle(ctx context.Context, userID uuid.UUID, muscle *Muscle) error
+	UpdateMuscle(ctx context.Context, userID uuid.UUID, muscle *Muscle) error
 	RemoveMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
-	ListMuscles(ctx context.Context, userID uuid.UUID) ([]*Muscle, error)
+	ListMuscles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error)
 	GetMuscleByID(ctx context.Context, userID, muscleID uuid.UUID) (*Muscle, error)
+	GetMuscleTree(ctx context.Context, userID, muscleID uuid.UUID) (*Node, error)
+
+	AddCatalogMuscle(ctx context.Context, muscle *Muscle) (uuid.UUID, error)
+	ListHiddenMuscles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error)
+	HideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
+	UnhideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error
 }
 
 type muscleService struct {
@@ -29,14 +37,69 @@ func (s *muscleService) AddMuscle(ctx context.Context, userID uuid.UUID, muscle
 	return s.repo.Add(ctx, userID, muscle)
 }
 
+// UpdateMuscle stores changes to one of the user's muscles, catalog muscles are read-only
+func (s *muscleService) UpdateMuscle(ctx context.Context, userID uuid.UUID, muscle *Muscle) error {
+	if muscle.IsCatalog() {
+		return ErrCatalogMuscleReadOnly
+	}
+	return s.repo.Update(ctx, userID, muscle)
+}
+
 func (s *muscleService) RemoveMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
 	return s.repo.Delete(ctx, userID, muscleID)
 }
 
-func (s *muscleService) ListMuscles(ctx context.Context, userID uuid.UUID) ([]*Muscle, error) {
-	return s.repo.List(ctx, userID)
+// ListMuscles returns the catalog muscles the user has not hidden merged with their private muscles
+func (s *muscleService) ListMuscles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error) {
+	return s.repo.List(ctx, userID, q)
 }
 
 func (s *muscleService) GetMuscleByID(ctx context.Context, userID, muscleID uuid.UUID) (*Muscle, error) {
 	return s.repo.GetByID(ctx, userID, muscleID)
 }
+
+// GetMuscleTree returns the muscle with everything below it in the hierarchy
+func (s *muscleService) GetMuscleTree(ctx context.Context, userID, muscleID uuid.UUID) (*Node, error) {
+	muscles, err := s.repo.Subtree(ctx, userID, muscleID)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(muscleID, muscles), nil
+}
+
+// AddCatalogMuscle adds the muscle to the catalog and returns the ID it is stored under,
+// which differs from the muscle's own ID when the catalog already had a muscle with that name
+func (s *muscleService) AddCatalogMuscle(ctx context.Context, muscle *Muscle) (uuid.UUID, error) {
+	muscle.origin = OriginCatalog
+	return s.repo.AddToCatalog(ctx, muscle)
+}
+
+func (s *muscleService) ListHiddenMuscles(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Muscle], error) {
+	return s.repo.ListHidden(ctx, userID, q)
+}
+
+func (s *muscleService) HideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	if err := s.requireCatalogMuscle(ctx, userID, muscleID); err != nil {
+		return err
+	}
+	return s.repo.Hide(ctx, userID, muscleID)
+}
+
+func (s *muscleService) UnhideMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	if err := s.requireCatalogMuscle(ctx, userID, muscleID); err != nil {
+		return err
+	}
+	return s.repo.Unhide(ctx, userID, muscleID)
+}
+
+// requireCatalogMuscle returns ErrNotCatalogMuscle unless the muscle belongs to the catalog
+func (s *muscleService) requireCatalogMuscle(ctx context.Context, userID, muscleID uuid.UUID) error {
+	m, err := s.repo.GetByID(ctx, userID, muscleID)
+	if err != nil {
+		return err
+	}
+	if !m.IsCatalog() {
+		return ErrNotCatalogMuscle
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package muscle
+
+import "github.com/google/uuid"
+
+// Node is a muscle together with the muscles directly below it in the hierarchy
+type Node struct {
+	Muscle   *Muscle
+	Children []*Node
+}
+
+// BuildTree arranges muscles into the tree rooted at rootID.
+// Muscles that are not descendants of the root are ignored.
+// It returns nil if the root is not among the muscles.
+func BuildTree(rootID uuid.UUID, muscles []*Muscle) *Node {
+	nodes := make(map[uuid.UUID]*Node, len(muscles))
+	for _, m := range muscles {
+		nodes[m.ID()] = &Node{Muscle: m, Children: []*Node{}}
+	}
+
+	root, ok := nodes[rootID]
+	if !ok {
+		return nil
+	}
+
+	// Iterate over the input slice so children keep the order they were given in
+	for _, m := range muscles {
+		if m.ID() == rootID || !m.HasParent() {
+			continue
+		}
+		if parent, ok := nodes[m.ParentID()]; ok {
+			parent.Children = append(parent.Children, nodes[m.ID()])
+		}
+	}
+
+	return root
+}
+
+// IDs returns the IDs of every muscle in the tree, the root first
+func (n *Node) IDs() []uuid.UUID {
+	ids := []uuid.UUID{n.Muscle.ID()}
+	for _, c := range n.Children {
+		ids = append(ids, c.IDs()...)
+	}
+	return ids
+}
@@ -0,0 +1,190 @@
+package record
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidKind is returned when a record kind is not one of Kinds
+	ErrInvalidKind = errors.New("record kind must be heaviest_weight, reps_at_weight, estimated_1rm or session_volume")
+
+	// ErrInvalidValue is returned when a record value is not positive
+	ErrInvalidValue = errors.New("record value must be positive")
+)
+
+// Kind is what a personal record is the best of
+type Kind string
+
+const (
+	// KindHeaviestWeight is the heaviest weight lifted in a working set
+	KindHeaviestWeight Kind = "heaviest_weight"
+	// KindRepsAtWeight is the most reps done with a weight, there is one per weight
+	KindRepsAtWeight Kind = "reps_at_weight"
+	// KindEstimated1RM is the best one-rep max estimated from a set
+	KindEstimated1RM Kind = "estimated_1rm"
+	// KindSessionVolume is the most weight moved in an exercise during one session
+	KindSessionVolume Kind = "session_volume"
+)
+
+// Formula estimates the one-rep max of estimated_1rm records. It is fixed rather than the formula
+// a user prefers, so that records set before and after a change of preference stay comparable.
+const Formula = strength.FormulaEpley
+
+// Kinds lists the supported record kinds
+var Kinds = []Kind{KindHeaviestWeight, KindRepsAtWeight, KindEstimated1RM, KindSessionVolume}
+
+// RecordParams contains the parameters needed to create or restore a Record
+type RecordParams struct {
+	ID         uuid.UUID
+	ExerciseID uuid.UUID
+	SessionID  uuid.UUID
+	SetID      uuid.UUID
+	Kind       Kind
+	// Value is in kg, except for reps records where it is the number of reps
+	Value float64
+	// Weight is the weight in kg a reps record was set with, zero for other kinds
+	Weight     float64
+	AchievedAt time.Time
+}
+
+// Record is a personal record of an exercise, referring to the set that produced it
+type Record struct {
+	id         uuid.UUID
+	exerciseID uuid.UUID
+	sessionID  uuid.UUID
+	setID      uuid.UUID
+	kind       Kind
+	value      float64
+	weight     float64
+	achievedAt time.Time
+}
+
+// NewRecord creates a new Record with validation
+func NewRecord(params RecordParams) (*Record, error) {
+	if !slices.Contains(Kinds, params.Kind) {
+		return &Record{}, ErrInvalidKind
+	}
+	if params.Value <= 0 {
+		return &Record{}, ErrInvalidValue
+	}
+	if params.Kind != KindRepsAtWeight {
+		params.Weight = 0
+	}
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	return &Record{
+		id:         params.ID,
+		exerciseID: params.ExerciseID,
+		sessionID:  params.SessionID,
+		setID:      params.SetID,
+		kind:       params.Kind,
+		value:      params.Value,
+		weight:     params.Weight,
+		achievedAt: params.AchievedAt,
+	}, nil
+}
+
+func (r *Record) GetID() uuid.UUID         { return r.id }
+func (r *Record) GetExerciseID() uuid.UUID { return r.exerciseID }
+func (r *Record) GetSessionID() uuid.UUID  { return r.sessionID }
+func (r *Record) GetSetID() uuid.UUID      { return r.setID }
+func (r *Record) GetKind() Kind            { return r.kind }
+func (r *Record) GetValue() float64        { return r.value }
+func (r *Record) GetWeight() float64       { return r.weight }
+func (r *Record) GetAchievedAt() time.Time { return r.achievedAt }
+
+// key identifies what the record is the best of, reps records are kept per weight
+type key struct {
+	kind   Kind
+	weight float64
+}
+
+func (r *Record) key() key {
+	return key{kind: r.kind, weight: r.weight}
+}
+
+// Current returns the record that currently stands for each kind, and for each weight of reps records.
+// records must be ordered from the oldest to the newest, a newer record always beats the ones before it.
+func Current(records []*Record) []*Record {
+	index := map[key]int{}
+	var current []*Record
+
+	for _, r := range records {
+		if i, ok := index[r.key()]; ok {
+			current[i] = r
+			continue
+		}
+		index[r.key()] = len(current)
+		current = append(current, r)
+	}
+	return current
+}
+
+// Detect returns the records a working set beats among the current records of its exercise.
+// sessionVolume is the volume of the exercise in the session including the set.
+// A session holds at most one record of each kind, so a record beaten again in the same session
+// keeps its ID and is replaced instead of becoming history.
+func Detect(current []*Record, exerciseID, sessionID uuid.UUID, set workout.Set, sessionVolume float64, at time.Time) []*Record {
+	if set.IsWarmup() {
+		return nil
+	}
+
+	best := make(map[key]*Record, len(current))
+	for _, r := range current {
+		best[r.key()] = r
+	}
+
+	weight := round(set.GetWeightKg())
+	candidates := []key{{kind: KindRepsAtWeight, weight: weight}}
+	values := []float64{float64(set.GetReps())}
+	if weight > 0 {
+		candidates = append(candidates, key{kind: KindHeaviestWeight}, key{kind: KindSessionVolume})
+		values = append(values, weight, round(sessionVolume))
+	}
+	if e1rm, err := strength.Estimate(Formula, weight, set.GetReps(), nil); err == nil {
+		candidates = append(candidates, key{kind: KindEstimated1RM})
+		values = append(values, round(e1rm))
+	}
+
+	var records []*Record
+	for i, k := range candidates {
+		params := RecordParams{
+			ExerciseID: exerciseID,
+			SessionID:  sessionID,
+			SetID:      set.GetID(),
+			Kind:       k.kind,
+			Value:      values[i],
+			Weight:     k.weight,
+			AchievedAt: at,
+		}
+
+		if previous, ok := best[k]; ok {
+			if params.Value <= previous.value {
+				continue
+			}
+			if previous.sessionID == sessionID {
+				params.ID = previous.id
+			}
+		}
+
+		r, err := NewRecord(params)
+		if err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// round rounds to the two decimals stored for weights
+func round(v float64) float64 {
+	return float64(int64(v*100+0.5)) / 100
+}
@@ -0,0 +1,164 @@
+package record_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSet(t *testing.T, params workout.SetParams) workout.Set {
+	t.Helper()
+	set, err := workout.NewSet(params)
+	assert.NoError(t, err)
+	return set
+}
+
+func newRecord(t *testing.T, params record.RecordParams) *record.Record {
+	t.Helper()
+	r, err := record.NewRecord(params)
+	assert.NoError(t, err)
+	return r
+}
+
+// Test cases for Record validation
+func TestNewRecord(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        record.RecordParams
+		expectedError error
+	}{
+		{
+			name:          "Valid record",
+			params:        record.RecordParams{Kind: record.KindHeaviestWeight, Value: 100},
+			expectedError: nil,
+		},
+		{
+			name:          "Unknown kind",
+			params:        record.RecordParams{Kind: "fastest", Value: 100},
+			expectedError: record.ErrInvalidKind,
+		},
+		{
+			name:          "Zero value",
+			params:        record.RecordParams{Kind: record.KindSessionVolume, Value: 0},
+			expectedError: record.ErrInvalidValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := record.NewRecord(tt.params)
+			assert.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+// Test cases for detecting the records a set beats
+func TestDetect(t *testing.T) {
+	exerciseID, sessionID := uuid.New(), uuid.New()
+	at := time.Now()
+
+	t.Run("First set sets every record", func(t *testing.T) {
+		set := newSet(t, workout.SetParams{Reps: 5, Weight: 100, Unit: workout.UnitKg})
+
+		records := record.Detect(nil, exerciseID, sessionID, set, 500, at)
+
+		values := map[record.Kind]float64{}
+		for _, r := range records {
+			assert.Equal(t, set.GetID(), r.GetSetID())
+			values[r.GetKind()] = r.GetValue()
+		}
+		assert.Equal(t, map[record.Kind]float64{
+			record.KindRepsAtWeight:   5,
+			record.KindHeaviestWeight: 100,
+			record.KindEstimated1RM:   116.67,
+			record.KindSessionVolume:  500,
+		}, values)
+	})
+
+	t.Run("Warmup sets never set records", func(t *testing.T) {
+		set := newSet(t, workout.SetParams{Reps: 5, Weight: 100, Unit: workout.UnitKg, Warmup: true})
+		assert.Empty(t, record.Detect(nil, exerciseID, sessionID, set, 0, at))
+	})
+
+	t.Run("Bodyweight sets only set reps records", func(t *testing.T) {
+		set := newSet(t, workout.SetParams{Reps: 12})
+
+		records := record.Detect(nil, exerciseID, sessionID, set, 0, at)
+
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, record.KindRepsAtWeight, records[0].GetKind())
+			assert.Equal(t, 0.0, records[0].GetWeight())
+		}
+	})
+
+	t.Run("Pounds are compared in kg", func(t *testing.T) {
+		current := []*record.Record{
+			newRecord(t, record.RecordParams{Kind: record.KindHeaviestWeight, Value: 100, SessionID: uuid.New()}),
+		}
+		set := newSet(t, workout.SetParams{Reps: 1, Weight: 225, Unit: workout.UnitLb})
+
+		records := record.Detect(current, exerciseID, sessionID, set, 0, at)
+
+		kinds := map[record.Kind]float64{}
+		for _, r := range records {
+			kinds[r.GetKind()] = r.GetValue()
+		}
+		assert.Equal(t, 102.06, kinds[record.KindHeaviestWeight])
+	})
+
+	t.Run("Equal values are not records", func(t *testing.T) {
+		current := []*record.Record{
+			newRecord(t, record.RecordParams{Kind: record.KindRepsAtWeight, Value: 5, Weight: 100}),
+			newRecord(t, record.RecordParams{Kind: record.KindHeaviestWeight, Value: 100}),
+			newRecord(t, record.RecordParams{Kind: record.KindEstimated1RM, Value: 120}),
+			newRecord(t, record.RecordParams{Kind: record.KindSessionVolume, Value: 1000}),
+		}
+		set := newSet(t, workout.SetParams{Reps: 5, Weight: 100, Unit: workout.UnitKg})
+
+		assert.Empty(t, record.Detect(current, exerciseID, sessionID, set, 500, at))
+	})
+
+	t.Run("Reps records are kept per weight", func(t *testing.T) {
+		current := []*record.Record{
+			newRecord(t, record.RecordParams{Kind: record.KindRepsAtWeight, Value: 10, Weight: 80}),
+		}
+		set := newSet(t, workout.SetParams{Reps: 6, Weight: 90, Unit: workout.UnitKg})
+
+		var reps []*record.Record
+		for _, r := range record.Detect(current, exerciseID, sessionID, set, 0, at) {
+			if r.GetKind() == record.KindRepsAtWeight {
+				reps = append(reps, r)
+			}
+		}
+		if assert.Len(t, reps, 1) {
+			assert.Equal(t, 90.0, reps[0].GetWeight())
+		}
+	})
+
+	t.Run("Beating a record of the same session replaces it", func(t *testing.T) {
+		sameSession := newRecord(t, record.RecordParams{Kind: record.KindHeaviestWeight, Value: 100, SessionID: sessionID})
+		set := newSet(t, workout.SetParams{Reps: 1, Weight: 105, Unit: workout.UnitKg})
+
+		for _, r := range record.Detect([]*record.Record{sameSession}, exerciseID, sessionID, set, 0, at) {
+			if r.GetKind() == record.KindHeaviestWeight {
+				assert.Equal(t, sameSession.GetID(), r.GetID())
+			}
+		}
+	})
+}
+
+// Test cases for the records that currently stand
+func TestCurrent(t *testing.T) {
+	old := newRecord(t, record.RecordParams{Kind: record.KindHeaviestWeight, Value: 100})
+	newer := newRecord(t, record.RecordParams{Kind: record.KindHeaviestWeight, Value: 110})
+	reps80 := newRecord(t, record.RecordParams{Kind: record.KindRepsAtWeight, Value: 8, Weight: 80})
+	reps90 := newRecord(t, record.RecordParams{Kind: record.KindRepsAtWeight, Value: 5, Weight: 90})
+
+	current := record.Current([]*record.Record{old, reps80, newer, reps90})
+
+	assert.Equal(t, []*record.Record{newer, reps80, reps90}, current)
+}
@@ -0,0 +1,17 @@
+package record
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RecordRepository defines the storage operations for personal records.
+// Save inserts new records and replaces stored records with the same ID.
+type RecordRepository interface {
+	Save(ctx context.Context, userID uuid.UUID, records []*Record) error
+	// ListByExercise returns the records of an exercise ordered from the oldest to the newest
+	ListByExercise(ctx context.Context, userID, exerciseID uuid.UUID) ([]*Record, error)
+	// ListBySession returns the records set during a workout session
+	ListBySession(ctx context.Context, userID, sessionID uuid.UUID) ([]*Record, error)
+}
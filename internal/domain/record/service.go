@@ -0,0 +1,40 @@
+package record
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RecordService defines the business operations available for personal records
+type RecordService interface {
+	SaveRecords(ctx context.Context, userID uuid.UUID, records []*Record) error
+	ListExerciseRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*Record, error)
+	ListSessionRecords(ctx context.Context, userID, sessionID uuid.UUID) ([]*Record, error)
+}
+
+type recordService struct {
+	repo RecordRepository
+}
+
+// NewRecordService create a new service with the provided repository
+func NewRecordService(repo RecordRepository) RecordService {
+	return &recordService{
+		repo: repo,
+	}
+}
+
+func (s *recordService) SaveRecords(ctx context.Context, userID uuid.UUID, records []*Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	return s.repo.Save(ctx, userID, records)
+}
+
+func (s *recordService) ListExerciseRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*Record, error) {
+	return s.repo.ListByExercise(ctx, userID, exerciseID)
+}
+
+func (s *recordService) ListSessionRecords(ctx context.Context, userID, sessionID uuid.UUID) ([]*Record, error) {
+	return s.repo.ListBySession(ctx, userID, sessionID)
+}
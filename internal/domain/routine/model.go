@@ -0,0 +1,214 @@
+package routine
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRoutine = errors.New("a routine must have a name")
+
+	// ErrInvalidTargetSets is returned when an exercise targets fewer than one or more than MaxTargetSets sets
+	ErrInvalidTargetSets = errors.New("target sets must be between 1 and 20")
+
+	// ErrInvalidRepRange is returned when a rep range does not start at one or more, or ends before it starts
+	ErrInvalidRepRange = errors.New("rep range must start at 1 or more and not end before it starts")
+
+	// ErrExerciseNotFound is returned when an exercise does not belong to the routine
+	ErrExerciseNotFound = errors.New("exercise not found in routine")
+
+	// ErrInvalidExerciseOrder is returned when a new exercise order is not a permutation of the existing exercises
+	ErrInvalidExerciseOrder = errors.New("exercise order must list every exercise of the routine exactly once")
+
+	// ErrUnknownExercise is returned when adding an exercise that does not exist for the user
+	ErrUnknownExercise = errors.New("exercise does not exist")
+
+	// ErrUnknownSplitDay is returned when copying the exercises of a split day that does not exist for the user
+	ErrUnknownSplitDay = errors.New("split day does not exist")
+)
+
+// MaxTargetSets is the largest number of sets an exercise of a routine can target
+const MaxTargetSets = 20
+
+// DefaultTarget is used for exercises added without a target, such as those copied from a split day
+var DefaultTarget = Target{Sets: 3, MinReps: 8, MaxReps: 12}
+
+// Target is the number of sets to perform and the rep range to aim for in each of them
+type Target struct {
+	Sets    int
+	MinReps int
+	MaxReps int
+}
+
+// Validate checks that the target describes at least one set with a valid rep range
+func (t Target) Validate() error {
+	if t.Sets < 1 || t.Sets > MaxTargetSets {
+		return ErrInvalidTargetSets
+	}
+	if t.MinReps < 1 || t.MaxReps < t.MinReps {
+		return ErrInvalidRepRange
+	}
+	return nil
+}
+
+// ExerciseParams contains the parameters needed to restore an exercise of a routine
+type ExerciseParams struct {
+	ID         uuid.UUID
+	ExerciseID uuid.UUID
+	Target     Target
+}
+
+// RoutineParams contains the parameters needed to create a new Routine
+type RoutineParams struct {
+	ID        uuid.UUID
+	Name      string
+	Exercises []ExerciseParams
+}
+
+// Exercise is an exercise of a routine with the sets and reps to aim for.
+// The same exercise can appear more than once, for example as a heavy and a light variation.
+type Exercise struct {
+	id         uuid.UUID
+	exerciseID uuid.UUID
+	target     Target
+}
+
+func (e Exercise) GetID() uuid.UUID         { return e.id }
+func (e Exercise) GetExerciseID() uuid.UUID { return e.exerciseID }
+func (e Exercise) GetTarget() Target        { return e.target }
+
+// Routine is an aggregate holding an ordered list of exercises that is repeated from one workout to the next
+type Routine struct {
+	id        uuid.UUID
+	name      string
+	exercises []Exercise
+}
+
+// NewRoutine creates a new Routine aggregate with validation
+func NewRoutine(params RoutineParams) (*Routine, error) {
+	if params.Name == "" {
+		return &Routine{}, ErrInvalidRoutine
+	}
+
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	r := &Routine{
+		id:        params.ID,
+		name:      params.Name,
+		exercises: make([]Exercise, 0, len(params.Exercises)),
+	}
+
+	for _, ep := range params.Exercises {
+		if err := ep.Target.Validate(); err != nil {
+			return &Routine{}, err
+		}
+		if ep.ID == uuid.Nil {
+			ep.ID = uuid.New()
+		}
+		r.exercises = append(r.exercises, Exercise{id: ep.ID, exerciseID: ep.ExerciseID, target: ep.Target})
+	}
+
+	return r, nil
+}
+
+func (r *Routine) GetID() uuid.UUID {
+	return r.id
+}
+
+func (r *Routine) GetName() string {
+	return r.name
+}
+
+func (r *Routine) SetName(name string) error {
+	if name == "" {
+		return ErrInvalidRoutine
+	}
+	r.name = name
+	return nil
+}
+
+// GetExercises returns the exercises in the order they are performed
+func (r *Routine) GetExercises() []Exercise {
+	return slices.Clone(r.exercises)
+}
+
+// GetExerciseIDs returns the IDs of the exercises performed, without repeats
+func (r *Routine) GetExerciseIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(r.exercises))
+	for _, e := range r.exercises {
+		if !slices.Contains(ids, e.exerciseID) {
+			ids = append(ids, e.exerciseID)
+		}
+	}
+	return ids
+}
+
+// AddExercise appends an exercise with its target to the end of the routine
+func (r *Routine) AddExercise(exerciseID uuid.UUID, target Target) (Exercise, error) {
+	if err := target.Validate(); err != nil {
+		return Exercise{}, err
+	}
+
+	e := Exercise{id: uuid.New(), exerciseID: exerciseID, target: target}
+	r.exercises = append(r.exercises, e)
+	return e, nil
+}
+
+// SetTarget changes the sets and rep range of an exercise of the routine
+func (r *Routine) SetTarget(id uuid.UUID, target Target) error {
+	if err := target.Validate(); err != nil {
+		return err
+	}
+
+	i, err := r.exerciseIndex(id)
+	if err != nil {
+		return err
+	}
+	r.exercises[i].target = target
+	return nil
+}
+
+// RemoveExercise deletes an exercise from the routine
+func (r *Routine) RemoveExercise(id uuid.UUID) error {
+	i, err := r.exerciseIndex(id)
+	if err != nil {
+		return err
+	}
+	r.exercises = slices.Delete(r.exercises, i, i+1)
+	return nil
+}
+
+// ReorderExercises arranges the exercises in the order of ids,
+// which must contain every exercise of the routine exactly once
+func (r *Routine) ReorderExercises(ids []uuid.UUID) error {
+	if len(ids) != len(r.exercises) {
+		return ErrInvalidExerciseOrder
+	}
+
+	ordered := make([]Exercise, 0, len(r.exercises))
+	for _, id := range ids {
+		i, err := r.exerciseIndex(id)
+		if err != nil {
+			return ErrInvalidExerciseOrder
+		}
+		if slices.ContainsFunc(ordered, func(e Exercise) bool { return e.id == id }) {
+			return ErrInvalidExerciseOrder
+		}
+		ordered = append(ordered, r.exercises[i])
+	}
+
+	r.exercises = ordered
+	return nil
+}
+
+func (r *Routine) exerciseIndex(id uuid.UUID) (int, error) {
+	i := slices.IndexFunc(r.exercises, func(e Exercise) bool { return e.id == id })
+	if i == -1 {
+		return -1, ErrExerciseNotFound
+	}
+	return i, nil
+}
@@ -0,0 +1,20 @@
+package routine
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// RoutineRepository defines the storage operations for Routine aggregates.
+// Implementations must persist a routine together with its ordered exercises as a single unit.
+type RoutineRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, routine *Routine) error
+	GetByID(ctx context.Context, userID, routineID uuid.UUID) (*Routine, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Routine], error)
+	// Modify loads a routine, applies fn to it and saves the result atomically. Concurrent calls for the
+	// same routine must not overlap, so a change is never lost to another one saving an older copy.
+	Modify(ctx context.Context, userID, routineID uuid.UUID, fn func(*Routine) error) (*Routine, error)
+	Delete(ctx context.Context, userID, routineID uuid.UUID) error
+}
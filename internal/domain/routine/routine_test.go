@@ -0,0 +1,87 @@
+package routine_test
+
+import (
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases for Routine aggregate
+func TestNewRoutine(t *testing.T) {
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name          string
+		params        routine.RoutineParams
+		expectedError error
+	}{
+		{
+			name: "Valid routine creation",
+			params: routine.RoutineParams{Name: "Upper A", Exercises: []routine.ExerciseParams{
+				{ExerciseID: exerciseID, Target: routine.Target{Sets: 4, MinReps: 6, MaxReps: 8}},
+				{ExerciseID: exerciseID, Target: routine.Target{Sets: 2, MinReps: 12, MaxReps: 15}},
+			}},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty name",
+			params:        routine.RoutineParams{},
+			expectedError: routine.ErrInvalidRoutine,
+		},
+		{
+			name: "No sets",
+			params: routine.RoutineParams{Name: "Upper A", Exercises: []routine.ExerciseParams{
+				{ExerciseID: exerciseID, Target: routine.Target{Sets: 0, MinReps: 6, MaxReps: 8}},
+			}},
+			expectedError: routine.ErrInvalidTargetSets,
+		},
+		{
+			name: "Inverted rep range",
+			params: routine.RoutineParams{Name: "Upper A", Exercises: []routine.ExerciseParams{
+				{ExerciseID: exerciseID, Target: routine.Target{Sets: 3, MinReps: 10, MaxReps: 8}},
+			}},
+			expectedError: routine.ErrInvalidRepRange,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := routine.NewRoutine(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, r.GetExercises(), len(tc.params.Exercises))
+			assert.Equal(t, []uuid.UUID{exerciseID}, r.GetExerciseIDs(), "repeated exercises are listed once")
+		})
+	}
+}
+
+func TestRoutine_Exercises(t *testing.T) {
+	r, _ := routine.NewRoutine(routine.RoutineParams{Name: "Lower"})
+
+	squat, err := r.AddExercise(uuid.New(), routine.DefaultTarget)
+	assert.NoError(t, err)
+	curl, err := r.AddExercise(uuid.New(), routine.Target{Sets: 3, MinReps: 10, MaxReps: 15})
+	assert.NoError(t, err)
+
+	_, err = r.AddExercise(uuid.New(), routine.Target{Sets: routine.MaxTargetSets + 1, MinReps: 5, MaxReps: 5})
+	assert.Equal(t, routine.ErrInvalidTargetSets, err)
+
+	assert.NoError(t, r.SetTarget(squat.GetID(), routine.Target{Sets: 5, MinReps: 5, MaxReps: 5}))
+	assert.Equal(t, 5, r.GetExercises()[0].GetTarget().Sets)
+	assert.Equal(t, routine.ErrExerciseNotFound, r.SetTarget(uuid.New(), routine.DefaultTarget))
+
+	assert.Equal(t, routine.ErrInvalidExerciseOrder, r.ReorderExercises([]uuid.UUID{curl.GetID(), curl.GetID()}))
+	assert.NoError(t, r.ReorderExercises([]uuid.UUID{curl.GetID(), squat.GetID()}))
+	assert.Equal(t, curl.GetID(), r.GetExercises()[0].GetID())
+
+	assert.NoError(t, r.RemoveExercise(curl.GetID()))
+	assert.Equal(t, routine.ErrExerciseNotFound, r.RemoveExercise(curl.GetID()))
+	assert.Len(t, r.GetExercises(), 1)
+}
@@ -0,0 +1,48 @@
+package routine
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// RoutineService defines the business operations available for routines
+type RoutineService interface {
+	AddRoutine(ctx context.Context, userID uuid.UUID, routine *Routine) error
+	RemoveRoutine(ctx context.Context, userID, routineID uuid.UUID) error
+	ListRoutines(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Routine], error)
+	GetRoutineByID(ctx context.Context, userID, routineID uuid.UUID) (*Routine, error)
+	ModifyRoutine(ctx context.Context, userID, routineID uuid.UUID, fn func(*Routine) error) (*Routine, error)
+}
+
+type routineService struct {
+	repo RoutineRepository
+}
+
+// NewRoutineService create a new service with the provided repository
+func NewRoutineService(repo RoutineRepository) RoutineService {
+	return &routineService{
+		repo: repo,
+	}
+}
+
+func (s *routineService) AddRoutine(ctx context.Context, userID uuid.UUID, routine *Routine) error {
+	return s.repo.Add(ctx, userID, routine)
+}
+
+func (s *routineService) RemoveRoutine(ctx context.Context, userID, routineID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, routineID)
+}
+
+func (s *routineService) ListRoutines(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Routine], error) {
+	return s.repo.List(ctx, userID, q)
+}
+
+func (s *routineService) GetRoutineByID(ctx context.Context, userID, routineID uuid.UUID) (*Routine, error) {
+	return s.repo.GetByID(ctx, userID, routineID)
+}
+
+func (s *routineService) ModifyRoutine(ctx context.Context, userID, routineID uuid.UUID, fn func(*Routine) error) (*Routine, error) {
+	return s.repo.Modify(ctx, userID, routineID, fn)
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired or revoked
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+	// ErrRefreshTokenReused is returned when an already consumed refresh token is presented again.
+	// The whole token family is revoked when this happens.
+	ErrRefreshTokenReused = errors.New("refresh token has already been used")
+)
+
+// refreshTokenBytes is the amount of random bytes in a plaintext refresh token
+const refreshTokenBytes = 32
+
+// RefreshTokenParams contains the parameters needed to create a new RefreshToken
+type RefreshTokenParams struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	TokenHash  []byte
+	ExpiresAt  time.Time
+	ConsumedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// RefreshToken represents one link in a chain of rotated refresh tokens.
+// Every token issued from the same login shares a family ID.
+// Only the hash of the token is stored, the plaintext is handed to the client once.
+type RefreshToken struct {
+	id         uuid.UUID
+	userID     uuid.UUID
+	familyID   uuid.UUID
+	tokenHash  []byte
+	expiresAt  time.Time
+	consumedAt *time.Time
+	revokedAt  *time.Time
+	createdAt  time.Time
+}
+
+// NewRefreshToken creates a RefreshToken entity from existing values
+func NewRefreshToken(params RefreshTokenParams) *RefreshToken {
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	if params.CreatedAt.IsZero() {
+		params.CreatedAt = time.Now()
+	}
+
+	return &RefreshToken{
+		id:         params.ID,
+		userID:     params.UserID,
+		familyID:   params.FamilyID,
+		tokenHash:  params.TokenHash,
+		expiresAt:  params.ExpiresAt,
+		consumedAt: params.ConsumedAt,
+		revokedAt:  params.RevokedAt,
+		createdAt:  params.CreatedAt,
+	}
+}
+
+// GenerateRefreshToken creates a new random refresh token in the given family.
+// It returns the entity holding the hash and the plaintext to give to the client.
+func GenerateRefreshToken(userID, familyID uuid.UUID, ttl time.Duration) (*RefreshToken, string, error) {
+	b := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	plaintext := base64.RawURLEncoding.EncodeToString(b)
+
+	now := time.Now()
+	token := NewRefreshToken(RefreshTokenParams{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashToken(plaintext),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	})
+
+	return token, plaintext, nil
+}
+
+// HashToken returns the SHA-256 hash of a plaintext refresh token
+func HashToken(plaintext string) []byte {
+	hash := sha256.Sum256([]byte(plaintext))
+	return hash[:]
+}
+
+func (t *RefreshToken) ID() uuid.UUID                { return t.id }
+func (t *RefreshToken) UserID() uuid.UUID            { return t.userID }
+func (t *RefreshToken) FamilyID() uuid.UUID          { return t.familyID }
+func (t *RefreshToken) TokenHash() []byte            { return t.tokenHash }
+func (t *RefreshToken) ExpiresAt() time.Time         { return t.expiresAt }
+func (t *RefreshToken) ConsumedAt() *time.Time       { return t.consumedAt }
+func (t *RefreshToken) RevokedAt() *time.Time        { return t.revokedAt }
+func (t *RefreshToken) CreatedAt() time.Time         { return t.createdAt }
+func (t *RefreshToken) IsConsumed() bool             { return t.consumedAt != nil }
+func (t *RefreshToken) IsRevoked() bool              { return t.revokedAt != nil }
+func (t *RefreshToken) IsExpired(now time.Time) bool { return !now.Before(t.expiresAt) }
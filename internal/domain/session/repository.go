@@ -0,0 +1,19 @@
+package session
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SessionRepository defines the storage operations for RefreshToken entities
+type SessionRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, token *RefreshToken) error
+	GetByHash(ctx context.Context, tokenHash []byte) (*RefreshToken, error)
+	// Rotate marks the token as used and adds its successor in a single transaction.
+	// It returns ErrRefreshTokenReused when the token was already consumed, so concurrent rotations
+	// cannot both succeed, and ErrInvalidRefreshToken when its family was revoked in the meantime.
+	// Nothing is added when it fails.
+	Rotate(ctx context.Context, userID, tokenID uuid.UUID, next *RefreshToken) error
+	RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error
+}
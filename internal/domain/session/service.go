@@ -0,0 +1,93 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionService defines the business operations available for refresh token sessions
+type SessionService interface {
+	StartSession(ctx context.Context, userID uuid.UUID) (*RefreshToken, string, error)
+	RotateSession(ctx context.Context, plaintext string) (*RefreshToken, string, error)
+	EndSession(ctx context.Context, plaintext string) error
+}
+
+type sessionService struct {
+	repo SessionRepository
+	ttl  time.Duration
+}
+
+// NewSessionService create a new service with the provided repository.
+// Refresh tokens issued by the service expire after ttl.
+func NewSessionService(repo SessionRepository, ttl time.Duration) SessionService {
+	return &sessionService{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+// StartSession issues the first refresh token of a new token family
+func (s *sessionService) StartSession(ctx context.Context, userID uuid.UUID) (*RefreshToken, string, error) {
+	token, plaintext, err := GenerateRefreshToken(userID, uuid.New(), s.ttl)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repo.Add(ctx, userID, token); err != nil {
+		return nil, "", err
+	}
+	return token, plaintext, nil
+}
+
+// RotateSession consumes the presented refresh token and issues its successor in the same family.
+// Presenting a token that was already consumed revokes the whole family.
+func (s *sessionService) RotateSession(ctx context.Context, plaintext string) (*RefreshToken, string, error) {
+	current, err := s.repo.GetByHash(ctx, HashToken(plaintext))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if current.IsRevoked() || current.IsExpired(time.Now()) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	if current.IsConsumed() {
+		return nil, "", s.revokeReusedFamily(ctx, current)
+	}
+
+	next, nextPlaintext, err := GenerateRefreshToken(current.UserID(), current.FamilyID(), s.ttl)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repo.Rotate(ctx, current.UserID(), current.ID(), next); err != nil {
+		if errors.Is(err, ErrRefreshTokenReused) {
+			return nil, "", s.revokeReusedFamily(ctx, current)
+		}
+		return nil, "", err
+	}
+	return next, nextPlaintext, nil
+}
+
+// EndSession revokes the family of the presented refresh token
+func (s *sessionService) EndSession(ctx context.Context, plaintext string) error {
+	current, err := s.repo.GetByHash(ctx, HashToken(plaintext))
+	if err != nil {
+		return err
+	}
+
+	if current.IsRevoked() {
+		return nil
+	}
+	return s.repo.RevokeFamily(ctx, current.UserID(), current.FamilyID())
+}
+
+func (s *sessionService) revokeReusedFamily(ctx context.Context, token *RefreshToken) error {
+	if err := s.repo.RevokeFamily(ctx, token.UserID(), token.FamilyID()); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
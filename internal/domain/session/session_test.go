@@ -0,0 +1,179 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSessionRepository is a mock implementation of the SessionRepository interface
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Add(ctx context.Context, userID uuid.UUID, token *session.RefreshToken) error {
+	args := m.Called(ctx, userID, token)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetByHash(ctx context.Context, tokenHash []byte) (*session.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*session.RefreshToken), args.Error(1)
+}
+
+func (m *MockSessionRepository) Rotate(ctx context.Context, userID, tokenID uuid.UUID, next *session.RefreshToken) error {
+	args := m.Called(ctx, userID, tokenID, next)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	args := m.Called(ctx, userID, familyID)
+	return args.Error(0)
+}
+
+func TestSessionService_StartSession(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := session.NewSessionService(mockRepo, time.Hour)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockRepo.On("Add", ctx, userID, mock.AnythingOfType("*session.RefreshToken")).Return(nil).Once()
+
+	token, plaintext, err := service.StartSession(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, plaintext)
+	assert.Equal(t, session.HashToken(plaintext), token.TokenHash())
+	assert.Equal(t, userID, token.UserID())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSessionService_RotateSession(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	familyID := uuid.New()
+
+	newToken := func(params session.RefreshTokenParams) (*session.RefreshToken, string) {
+		token, plaintext, _ := session.GenerateRefreshToken(userID, familyID, time.Hour)
+		params.ID = token.ID()
+		params.UserID = userID
+		params.FamilyID = familyID
+		params.TokenHash = token.TokenHash()
+		if params.ExpiresAt.IsZero() {
+			params.ExpiresAt = token.ExpiresAt()
+		}
+		return session.NewRefreshToken(params), plaintext
+	}
+
+	t.Run("Successful rotation", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		current, plaintext := newToken(session.RefreshTokenParams{})
+
+		mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(current, nil).Once()
+		mockRepo.On("Rotate", ctx, userID, current.ID(), mock.AnythingOfType("*session.RefreshToken")).Return(nil).Once()
+
+		next, nextPlaintext, err := service.RotateSession(ctx, plaintext)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, plaintext, nextPlaintext)
+		assert.Equal(t, familyID, next.FamilyID())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reused token revokes family", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		consumedAt := time.Now().Add(-time.Minute)
+		current, plaintext := newToken(session.RefreshTokenParams{ConsumedAt: &consumedAt})
+
+		mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(current, nil).Once()
+		mockRepo.On("RevokeFamily", ctx, userID, familyID).Return(nil).Once()
+
+		_, _, err := service.RotateSession(ctx, plaintext)
+
+		assert.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent consume revokes family", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		current, plaintext := newToken(session.RefreshTokenParams{})
+
+		mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(current, nil).Once()
+		mockRepo.On("Rotate", ctx, userID, current.ID(), mock.AnythingOfType("*session.RefreshToken")).Return(session.ErrRefreshTokenReused).Once()
+		mockRepo.On("RevokeFamily", ctx, userID, familyID).Return(nil).Once()
+
+		_, _, err := service.RotateSession(ctx, plaintext)
+
+		assert.ErrorIs(t, err, session.ErrRefreshTokenReused)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent logout issues no token", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		current, plaintext := newToken(session.RefreshTokenParams{})
+
+		mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(current, nil).Once()
+		mockRepo.On("Rotate", ctx, userID, current.ID(), mock.AnythingOfType("*session.RefreshToken")).Return(session.ErrInvalidRefreshToken).Once()
+
+		next, _, err := service.RotateSession(ctx, plaintext)
+
+		assert.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+		assert.Nil(t, next)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		current, plaintext := newToken(session.RefreshTokenParams{ExpiresAt: time.Now().Add(-time.Minute)})
+
+		mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(current, nil).Once()
+
+		_, _, err := service.RotateSession(ctx, plaintext)
+
+		assert.ErrorIs(t, err, session.ErrInvalidRefreshToken)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo := new(MockSessionRepository)
+		service := session.NewSessionService(mockRepo, time.Hour)
+		expectedErr := errors.New("database error")
+
+		mockRepo.On("GetByHash", ctx, session.HashToken("unknown")).Return(nil, expectedErr).Once()
+
+		_, _, err := service.RotateSession(ctx, "unknown")
+
+		assert.Equal(t, expectedErr, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestSessionService_EndSession(t *testing.T) {
+	mockRepo := new(MockSessionRepository)
+	service := session.NewSessionService(mockRepo, time.Hour)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	token, plaintext, _ := session.GenerateRefreshToken(userID, uuid.New(), time.Hour)
+
+	mockRepo.On("GetByHash", ctx, session.HashToken(plaintext)).Return(token, nil).Once()
+	mockRepo.On("RevokeFamily", ctx, userID, token.FamilyID()).Return(nil).Once()
+
+	assert.NoError(t, service.EndSession(ctx, plaintext))
+	mockRepo.AssertExpectations(t)
+}
@@ -2,27 +2,93 @@ package split
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/google/uuid"
 )
 
 var (
 	ErrInvalidSplit = errors.New("a split must have a name")
+
+	// ErrInvalidDay is returned when attempting to add a training day without a name
+	ErrInvalidDay = errors.New("a training day must have a name")
+
+	// ErrDayNotFound is returned when a training day does not belong to the split
+	ErrDayNotFound = errors.New("training day not found in split")
+
+	// ErrInvalidDayOrder is returned when a new day order is not a permutation of the existing days
+	ErrInvalidDayOrder = errors.New("day order must list every day of the split exactly once")
+
+	// ErrExerciseNotAssigned is returned when removing an exercise that is not assigned to the day
+	ErrExerciseNotAssigned = errors.New("exercise is not assigned to that training day")
+
+	// ErrUnknownExercise is returned when assigning an exercise that does not exist for the user
+	ErrUnknownExercise = errors.New("exercise does not exist")
 )
 
+// DayParams contains the parameters needed to restore a training day
+type DayParams struct {
+	ID          uuid.UUID
+	Name        string
+	ExerciseIDs []uuid.UUID
+}
+
+// SplitParams contains the parameters needed to create a new Split
+type SplitParams struct {
+	ID   uuid.UUID
+	Name string
+	Days []DayParams
+}
+
+// Day is a named training day within a split, e.g. "Push", holding the exercises trained on it
+type Day struct {
+	id          uuid.UUID
+	name        string
+	exerciseIDs []uuid.UUID
+}
+
+func (d Day) GetId() uuid.UUID            { return d.id }
+func (d Day) GetName() string             { return d.name }
+func (d Day) GetExerciseIDs() []uuid.UUID { return slices.Clone(d.exerciseIDs) }
+
+// Split is an aggregate holding an ordered list of training days
 type Split struct {
 	id   uuid.UUID
 	name string
+	days []Day
 }
 
-func NewSplit(name string) (Split, error) {
-	if name == "" {
-		return Split{}, ErrInvalidSplit
+// NewSplit creates a new Split aggregate with validation
+func NewSplit(params SplitParams) (*Split, error) {
+	if params.Name == "" {
+		return &Split{}, ErrInvalidSplit
+	}
+
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
 	}
-	return Split{
-		id:   uuid.New(),
-		name: name,
-	}, nil
+
+	s := &Split{
+		id:   params.ID,
+		name: params.Name,
+		days: make([]Day, 0, len(params.Days)),
+	}
+
+	for _, dp := range params.Days {
+		if dp.Name == "" {
+			return &Split{}, ErrInvalidDay
+		}
+		if dp.ID == uuid.Nil {
+			dp.ID = uuid.New()
+		}
+		s.days = append(s.days, Day{
+			id:          dp.ID,
+			name:        dp.Name,
+			exerciseIDs: slices.Clone(dp.ExerciseIDs),
+		})
+	}
+
+	return s, nil
 }
 
 func (m *Split) GetId() uuid.UUID {
@@ -33,6 +99,111 @@ func (m *Split) GetName() string {
 	return m.name
 }
 
-func (m *Split) SetName(name string) {
+func (m *Split) SetName(name string) error {
+	if name == "" {
+		return ErrInvalidSplit
+	}
 	m.name = name
+	return nil
+}
+
+// GetDays returns the training days in order
+func (m *Split) GetDays() []Day {
+	return slices.Clone(m.days)
+}
+
+// AddDay appends a new training day to the end of the split
+func (m *Split) AddDay(name string) (Day, error) {
+	if name == "" {
+		return Day{}, ErrInvalidDay
+	}
+
+	day := Day{id: uuid.New(), name: name}
+	m.days = append(m.days, day)
+	return day, nil
+}
+
+// RenameDay changes the name of a training day
+func (m *Split) RenameDay(dayID uuid.UUID, name string) error {
+	if name == "" {
+		return ErrInvalidDay
+	}
+
+	i, err := m.dayIndex(dayID)
+	if err != nil {
+		return err
+	}
+	m.days[i].name = name
+	return nil
+}
+
+// RemoveDay deletes a training day and its exercise assignments
+func (m *Split) RemoveDay(dayID uuid.UUID) error {
+	i, err := m.dayIndex(dayID)
+	if err != nil {
+		return err
+	}
+	m.days = slices.Delete(m.days, i, i+1)
+	return nil
+}
+
+// ReorderDays arranges the training days in the order of dayIDs,
+// which must contain every day of the split exactly once
+func (m *Split) ReorderDays(dayIDs []uuid.UUID) error {
+	if len(dayIDs) != len(m.days) {
+		return ErrInvalidDayOrder
+	}
+
+	ordered := make([]Day, 0, len(m.days))
+	for _, id := range dayIDs {
+		i, err := m.dayIndex(id)
+		if err != nil {
+			return ErrInvalidDayOrder
+		}
+		if slices.ContainsFunc(ordered, func(d Day) bool { return d.id == id }) {
+			return ErrInvalidDayOrder
+		}
+		ordered = append(ordered, m.days[i])
+	}
+
+	m.days = ordered
+	return nil
+}
+
+// AssignExercise adds an exercise to a training day.
+// Assigning an exercise the day already has is a no-op.
+func (m *Split) AssignExercise(dayID, exerciseID uuid.UUID) error {
+	i, err := m.dayIndex(dayID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(m.days[i].exerciseIDs, exerciseID) {
+		return nil
+	}
+	m.days[i].exerciseIDs = append(m.days[i].exerciseIDs, exerciseID)
+	return nil
+}
+
+// UnassignExercise removes an exercise from a training day
+func (m *Split) UnassignExercise(dayID, exerciseID uuid.UUID) error {
+	i, err := m.dayIndex(dayID)
+	if err != nil {
+		return err
+	}
+
+	j := slices.Index(m.days[i].exerciseIDs, exerciseID)
+	if j == -1 {
+		return ErrExerciseNotAssigned
+	}
+	m.days[i].exerciseIDs = slices.Delete(m.days[i].exerciseIDs, j, j+1)
+	return nil
+}
+
+func (m *Split) dayIndex(dayID uuid.UUID) (int, error) {
+	i := slices.IndexFunc(m.days, func(d Day) bool { return d.id == dayID })
+	if i == -1 {
+		return -1, ErrDayNotFound
+	}
+	return i, nil
 }
@@ -0,0 +1,20 @@
+package split
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// SplitRepository defines the storage operations for Split aggregates.
+// Implementations must persist a split together with its days and their exercises as a single unit.
+type SplitRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, split *Split) error
+	GetByID(ctx context.Context, userID, splitID uuid.UUID) (*Split, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Split], error)
+	// Modify loads a split, applies fn to it and saves the result atomically. Concurrent calls for the
+	// same split must not overlap, so a change is never lost to another one saving an older copy.
+	Modify(ctx context.Context, userID, splitID uuid.UUID, fn func(*Split) error) (*Split, error)
+	Delete(ctx context.Context, userID, splitID uuid.UUID) error
+}
@@ -0,0 +1,48 @@
+package split
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// SplitService defines the business operations available for splits
+type SplitService interface {
+	AddSplit(ctx context.Context, userID uuid.UUID, split *Split) error
+	RemoveSplit(ctx context.Context, userID, splitID uuid.UUID) error
+	ListSplits(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Split], error)
+	GetSplitByID(ctx context.Context, userID, splitID uuid.UUID) (*Split, error)
+	ModifySplit(ctx context.Context, userID, splitID uuid.UUID, fn func(*Split) error) (*Split, error)
+}
+
+type splitService struct {
+	repo SplitRepository
+}
+
+// NewSplitService create a new service with the provided repository
+func NewSplitService(repo SplitRepository) SplitService {
+	return &splitService{
+		repo: repo,
+	}
+}
+
+func (s *splitService) AddSplit(ctx context.Context, userID uuid.UUID, split *Split) error {
+	return s.repo.Add(ctx, userID, split)
+}
+
+func (s *splitService) RemoveSplit(ctx context.Context, userID, splitID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, splitID)
+}
+
+func (s *splitService) ListSplits(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Split], error) {
+	return s.repo.List(ctx, userID, q)
+}
+
+func (s *splitService) GetSplitByID(ctx context.Context, userID, splitID uuid.UUID) (*Split, error) {
+	return s.repo.GetByID(ctx, userID, splitID)
+}
+
+func (s *splitService) ModifySplit(ctx context.Context, userID, splitID uuid.UUID, fn func(*Split) error) (*Split, error) {
+	return s.repo.Modify(ctx, userID, splitID, fn)
+}
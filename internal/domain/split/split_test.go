@@ -0,0 +1,92 @@
+package split_test
+
+import (
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases for Split aggregate
+func TestNewSplit(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        split.SplitParams
+		expectedError error
+	}{
+		{
+			name:          "Valid split creation",
+			params:        split.SplitParams{Name: "PPL", Days: []split.DayParams{{Name: "Push"}, {Name: "Pull"}, {Name: "Legs"}}},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty name",
+			params:        split.SplitParams{},
+			expectedError: split.ErrInvalidSplit,
+		},
+		{
+			name:          "Unnamed day",
+			params:        split.SplitParams{Name: "PPL", Days: []split.DayParams{{Name: ""}}},
+			expectedError: split.ErrInvalidDay,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := split.NewSplit(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, s.GetDays(), len(tc.params.Days))
+			for i, d := range s.GetDays() {
+				assert.Equal(t, tc.params.Days[i].Name, d.GetName())
+				assert.NotEqual(t, uuid.Nil, d.GetId())
+			}
+		})
+	}
+}
+
+func TestSplit_ReorderDays(t *testing.T) {
+	s, _ := split.NewSplit(split.SplitParams{Name: "PPL", Days: []split.DayParams{{Name: "Push"}, {Name: "Pull"}, {Name: "Legs"}}})
+	days := s.GetDays()
+
+	t.Run("Missing day", func(t *testing.T) {
+		err := s.ReorderDays([]uuid.UUID{days[0].GetId(), days[1].GetId()})
+		assert.Equal(t, split.ErrInvalidDayOrder, err)
+	})
+
+	t.Run("Repeated day", func(t *testing.T) {
+		err := s.ReorderDays([]uuid.UUID{days[0].GetId(), days[0].GetId(), days[1].GetId()})
+		assert.Equal(t, split.ErrInvalidDayOrder, err)
+	})
+
+	t.Run("Valid order", func(t *testing.T) {
+		err := s.ReorderDays([]uuid.UUID{days[2].GetId(), days[0].GetId(), days[1].GetId()})
+		assert.NoError(t, err)
+
+		reordered := s.GetDays()
+		assert.Equal(t, "Legs", reordered[0].GetName())
+		assert.Equal(t, "Push", reordered[1].GetName())
+		assert.Equal(t, "Pull", reordered[2].GetName())
+	})
+}
+
+func TestSplit_AssignExercise(t *testing.T) {
+	s, _ := split.NewSplit(split.SplitParams{Name: "Upper/Lower", Days: []split.DayParams{{Name: "Upper"}}})
+	dayID := s.GetDays()[0].GetId()
+	exerciseID := uuid.New()
+
+	assert.Equal(t, split.ErrDayNotFound, s.AssignExercise(uuid.New(), exerciseID))
+
+	assert.NoError(t, s.AssignExercise(dayID, exerciseID))
+	assert.NoError(t, s.AssignExercise(dayID, exerciseID), "assigning twice is a no-op")
+	assert.Equal(t, []uuid.UUID{exerciseID}, s.GetDays()[0].GetExerciseIDs())
+
+	assert.NoError(t, s.UnassignExercise(dayID, exerciseID))
+	assert.Equal(t, split.ErrExerciseNotAssigned, s.UnassignExercise(dayID, exerciseID))
+}
@@ -0,0 +1,159 @@
+package strength
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidFormula is returned when a formula is not one of Formulas
+	ErrInvalidFormula = errors.New("formula must be epley, brzycki, lombardi or rpe")
+
+	// ErrInvalidWeight is returned when estimating from a weight that is not positive
+	ErrInvalidWeight = errors.New("weight must be positive to estimate a one-rep max")
+
+	// ErrInvalidReps is returned when estimating from a rep count the formulas do not cover
+	ErrInvalidReps = errors.New("reps must be between 1 and 30 to estimate a one-rep max")
+
+	// ErrRPERequired is returned when the RPE formula is used without an RPE
+	ErrRPERequired = errors.New("the rpe formula needs the RPE of the set")
+
+	// ErrRPEOutOfRange is returned when reps and RPE fall outside the RPE table
+	ErrRPEOutOfRange = errors.New("the rpe table covers sets of at most 15.5 reps counting the reps in reserve")
+)
+
+// MaxReps is the highest rep count a one-rep max is estimated from, beyond it the estimates mean little
+const MaxReps = 30
+
+// Formula is a way of estimating a one-rep max from a set
+type Formula string
+
+const (
+	// FormulaEpley estimates weight × (1 + reps / 30)
+	FormulaEpley Formula = "epley"
+	// FormulaBrzycki estimates weight × 36 / (37 − reps)
+	FormulaBrzycki Formula = "brzycki"
+	// FormulaLombardi estimates weight × reps^0.1
+	FormulaLombardi Formula = "lombardi"
+	// FormulaRPE looks up the percentage of the one-rep max a set was at from its reps and RPE
+	FormulaRPE Formula = "rpe"
+)
+
+// Formulas lists the supported formulas
+var Formulas = []Formula{FormulaEpley, FormulaBrzycki, FormulaLombardi, FormulaRPE}
+
+// DefaultFormula is used until a user picks another one
+const DefaultFormula = FormulaEpley
+
+// Validate checks that the formula is one of Formulas
+func (f Formula) Validate() error {
+	if !slices.Contains(Formulas, f) {
+		return ErrInvalidFormula
+	}
+	return nil
+}
+
+// rpePercentages holds the percentage of the one-rep max that can be lifted for a number of reps
+// to failure, from 1 to 15.5 in steps of half a rep. A set of reps at an RPE is read as
+// reps + (10 − RPE) reps to failure.
+var rpePercentages = []float64{
+	100, 97.8, 95.5, 93.9, 92.2, 90.7, 89.2, 87.8, 86.3, 85.0,
+	83.7, 82.4, 81.1, 79.9, 78.6, 77.4, 76.2, 75.1, 73.9, 72.3,
+	70.7, 69.4, 68.0, 66.7, 65.3, 64.0, 62.6, 61.3, 59.9, 58.6,
+}
+
+// Estimate estimates the one-rep max of a set with the formula.
+// rpe is only used, and then required, by FormulaRPE. A single rep is its own one-rep max
+// for every other formula.
+func Estimate(f Formula, weight float64, reps int, rpe *float64) (float64, error) {
+	if err := f.Validate(); err != nil {
+		return 0, err
+	}
+	if weight <= 0 {
+		return 0, ErrInvalidWeight
+	}
+	if reps < 1 || reps > MaxReps {
+		return 0, ErrInvalidReps
+	}
+
+	if f == FormulaRPE {
+		return estimateFromRPE(weight, reps, rpe)
+	}
+	if reps == 1 {
+		return weight, nil
+	}
+
+	r := float64(reps)
+	switch f {
+	case FormulaBrzycki:
+		return weight * 36 / (37 - r), nil
+	case FormulaLombardi:
+		return weight * math.Pow(r, 0.1), nil
+	default:
+		return weight * (1 + r/30), nil
+	}
+}
+
+func estimateFromRPE(weight float64, reps int, rpe *float64) (float64, error) {
+	if rpe == nil {
+		return 0, ErrRPERequired
+	}
+
+	// RPE is logged in half steps, so the index is a whole number of half reps
+	i := int(math.Round((float64(reps) + 10 - *rpe - 1) * 2))
+	if *rpe > 10 || i < 0 || i >= len(rpePercentages) {
+		return 0, ErrRPEOutOfRange
+	}
+	return weight * 100 / rpePercentages[i], nil
+}
+
+// Point is the best estimated one-rep max of an exercise in a workout session, in kg
+type Point struct {
+	SessionID uuid.UUID
+	Date      time.Time
+	SetID     uuid.UUID
+	Weight    float64
+	Reps      int
+	OneRepMax float64
+}
+
+// Series returns one point for each session in which the formula could estimate a one-rep max
+// from a working set of the exercise. history must be ordered from the oldest session to the newest.
+func Series(f Formula, history []workout.SessionEntry) []Point {
+	var points []Point
+	for _, h := range history {
+		var best Point
+		for _, set := range h.Entry.GetSets() {
+			if set.IsWarmup() {
+				continue
+			}
+			weight := set.GetWeightKg()
+			e1rm, err := Estimate(f, weight, set.GetReps(), set.GetRPE())
+			if err != nil || e1rm <= best.OneRepMax {
+				continue
+			}
+			best = Point{
+				SessionID: h.SessionID,
+				Date:      h.StartedAt,
+				SetID:     set.GetID(),
+				Weight:    round(weight),
+				Reps:      set.GetReps(),
+				OneRepMax: round(e1rm),
+			}
+		}
+		if best.OneRepMax > 0 {
+			points = append(points, best)
+		}
+	}
+	return points
+}
+
+// round rounds to the two decimals stored for weights
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
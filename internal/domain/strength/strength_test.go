@@ -0,0 +1,84 @@
+package strength_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// Test cases for one-rep max estimates
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name          string
+		formula       strength.Formula
+		weight        float64
+		reps          int
+		rpe           *float64
+		expected      float64
+		expectedError error
+	}{
+		{name: "Epley", formula: strength.FormulaEpley, weight: 100, reps: 10, expected: 133.33},
+		{name: "Brzycki", formula: strength.FormulaBrzycki, weight: 100, reps: 10, expected: 133.33},
+		{name: "Lombardi", formula: strength.FormulaLombardi, weight: 100, reps: 10, expected: 125.89},
+		{name: "Single rep is its own max", formula: strength.FormulaBrzycki, weight: 140, reps: 1, expected: 140},
+		{name: "RPE 10 single", formula: strength.FormulaRPE, weight: 140, reps: 1, rpe: ptr(10.0), expected: 140},
+		{name: "RPE 8 for 5", formula: strength.FormulaRPE, weight: 100, reps: 5, rpe: ptr(8.0), expected: 123.3},
+		{name: "RPE half step", formula: strength.FormulaRPE, weight: 100, reps: 3, rpe: ptr(9.5), expected: 110.25},
+		{name: "RPE missing", formula: strength.FormulaRPE, weight: 100, reps: 5, expectedError: strength.ErrRPERequired},
+		{name: "RPE outside the table", formula: strength.FormulaRPE, weight: 100, reps: 15, rpe: ptr(7.0), expectedError: strength.ErrRPEOutOfRange},
+		{name: "Unknown formula", formula: "guess", weight: 100, reps: 5, expectedError: strength.ErrInvalidFormula},
+		{name: "No weight", formula: strength.FormulaEpley, weight: 0, reps: 5, expectedError: strength.ErrInvalidWeight},
+		{name: "Too many reps", formula: strength.FormulaEpley, weight: 100, reps: 31, expectedError: strength.ErrInvalidReps},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e1rm, err := strength.Estimate(tt.formula, tt.weight, tt.reps, tt.rpe)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.InDelta(t, tt.expected, e1rm, 0.01)
+		})
+	}
+}
+
+// Test cases for the estimated one-rep max over time
+func TestSeries(t *testing.T) {
+	newEntry := func(sets ...workout.SetParams) workout.ExerciseEntry {
+		e, err := workout.NewExerciseEntry(workout.ExerciseEntryParams{ExerciseID: uuid.New(), Sets: sets})
+		assert.NoError(t, err)
+		return e
+	}
+
+	day1, day2, day3 := time.Now().AddDate(0, 0, -14), time.Now().AddDate(0, 0, -7), time.Now()
+	history := []workout.SessionEntry{
+		{SessionID: uuid.New(), StartedAt: day1, Entry: newEntry(
+			workout.SetParams{Reps: 5, Weight: 200, Unit: workout.UnitKg, Warmup: true},
+			workout.SetParams{Reps: 5, Weight: 100, Unit: workout.UnitKg},
+			workout.SetParams{Reps: 3, Weight: 105, Unit: workout.UnitKg},
+		)},
+		{SessionID: uuid.New(), StartedAt: day2, Entry: newEntry(
+			workout.SetParams{Reps: 12},
+		)},
+		{SessionID: uuid.New(), StartedAt: day3, Entry: newEntry(
+			workout.SetParams{Reps: 1, Weight: 265, Unit: workout.UnitLb},
+		)},
+	}
+
+	points := strength.Series(strength.FormulaEpley, history)
+
+	if assert.Len(t, points, 2) {
+		assert.Equal(t, day1, points[0].Date)
+		assert.Equal(t, 5, points[0].Reps)
+		assert.Equal(t, 116.67, points[0].OneRepMax)
+		assert.Equal(t, day3, points[1].Date)
+		assert.Equal(t, 120.2, points[1].OneRepMax)
+	}
+
+	assert.Empty(t, strength.Series(strength.FormulaRPE, history), "sets without RPE cannot use the rpe formula")
+}
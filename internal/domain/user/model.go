@@ -0,0 +1,140 @@
+package user
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrInvalidEmail is returned when attempting to create a user without an email
+	ErrInvalidEmail = errors.New("a user must have an email")
+
+	// ErrInvalidUsername is returned when attempting to create a user without a username
+	ErrInvalidUsername = errors.New("a user must have a username")
+
+	// ErrInvalidPassword is returned when a plaintext password does not meet the length requirements
+	ErrInvalidPassword = errors.New("a password must be between 8 and 72 characters")
+
+	// ErrInvalidCredentials is returned when an email and password combination does not match a user
+	ErrInvalidCredentials = errors.New("invalid email or password")
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt ignores everything past 72 bytes
+	maxPasswordLength = 72
+)
+
+// Settings holds the preferences of a user
+type Settings struct {
+	OneRepMaxFormula strength.Formula
+}
+
+// DefaultSettings are the settings of a user who has not changed any
+var DefaultSettings = Settings{OneRepMaxFormula: strength.DefaultFormula}
+
+// Validate checks every setting
+func (s Settings) Validate() error {
+	return s.OneRepMaxFormula.Validate()
+}
+
+// UserParams contains the parameters needed to create a new User
+type UserParams struct {
+	ID           uuid.UUID
+	Email        string
+	Username     string
+	PasswordHash []byte
+	Settings     Settings
+	CreatedAt    time.Time
+}
+
+// User represents an account that owns muscles, exercises and workouts
+type User struct {
+	id           uuid.UUID
+	email        string
+	username     string
+	passwordHash []byte
+	settings     Settings
+	createdAt    time.Time
+}
+
+// NewUser creates a new User entity with validation
+func NewUser(params UserParams) (*User, error) {
+	if params.Email == "" {
+		return &User{}, ErrInvalidEmail
+	}
+
+	if params.Username == "" {
+		return &User{}, ErrInvalidUsername
+	}
+
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	if params.Settings == (Settings{}) {
+		params.Settings = DefaultSettings
+	}
+
+	if params.CreatedAt.IsZero() {
+		params.CreatedAt = time.Now()
+	}
+
+	return &User{
+		id:           params.ID,
+		email:        params.Email,
+		username:     params.Username,
+		passwordHash: params.PasswordHash,
+		settings:     params.Settings,
+		createdAt:    params.CreatedAt,
+	}, nil
+}
+
+func (u *User) ID() uuid.UUID        { return u.id }
+func (u *User) Email() string        { return u.email }
+func (u *User) Username() string     { return u.username }
+func (u *User) PasswordHash() []byte { return u.passwordHash }
+func (u *User) Settings() Settings   { return u.settings }
+func (u *User) CreatedAt() time.Time { return u.createdAt }
+
+// SetSettings replaces the settings of the user after validating them
+func (u *User) SetSettings(s Settings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	u.settings = s
+	return nil
+}
+
+// SetPassword hashes the plaintext password with bcrypt and stores the hash on the user
+func (u *User) SetPassword(plaintext string) error {
+	if len(plaintext) < minPasswordLength || len(plaintext) > maxPasswordLength {
+		return ErrInvalidPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(plaintext), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	u.passwordHash = hash
+	return nil
+}
+
+// PasswordMatches reports whether the plaintext password matches the stored hash
+func (u *User) PasswordMatches(plaintext string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword(u.passwordHash, []byte(plaintext))
+	if err != nil {
+		switch {
+		case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+	return true, nil
+}
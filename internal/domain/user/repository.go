@@ -0,0 +1,15 @@
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserRepository defines the storage operations for User entities
+type UserRepository interface {
+	Add(ctx context.Context, user *User) error
+	GetByID(ctx context.Context, userID uuid.UUID) (*User, error)
+	GetByEmail(ctx context.Context, email string) (*User, error)
+	UpdateSettings(ctx context.Context, user *User) error
+}
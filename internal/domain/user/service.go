@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// UserService defines the business operations available for users
+type UserService interface {
+	RegisterUser(ctx context.Context, user *User) error
+	GetUserByID(ctx context.Context, userID uuid.UUID) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	UpdateUserSettings(ctx context.Context, user *User) error
+}
+
+type userService struct {
+	repo UserRepository
+}
+
+// NewUserService create a new service with the provided repository
+func NewUserService(repo UserRepository) UserService {
+	return &userService{
+		repo: repo,
+	}
+}
+
+func (s *userService) RegisterUser(ctx context.Context, user *User) error {
+	return s.repo.Add(ctx, user)
+}
+
+func (s *userService) GetUserByID(ctx context.Context, userID uuid.UUID) (*User, error) {
+	return s.repo.GetByID(ctx, userID)
+}
+
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	return s.repo.GetByEmail(ctx, email)
+}
+
+func (s *userService) UpdateUserSettings(ctx context.Context, user *User) error {
+	return s.repo.UpdateSettings(ctx, user)
+}
@@ -0,0 +1,85 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test cases for User domain model
+func TestNewUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        user.UserParams
+		expectedError error
+	}{
+		{
+			name:          "Valid user creation",
+			params:        user.UserParams{ID: uuid.New(), Email: "jane@example.com", Username: "jane"},
+			expectedError: nil,
+		},
+		{
+			name:          "Empty email",
+			params:        user.UserParams{Username: "jane"},
+			expectedError: user.ErrInvalidEmail,
+		},
+		{
+			name:          "Empty username",
+			params:        user.UserParams{Email: "jane@example.com"},
+			expectedError: user.ErrInvalidUsername,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			u, err := user.NewUser(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.params.ID, u.ID())
+			assert.Equal(t, tc.params.Email, u.Email())
+			assert.False(t, u.CreatedAt().IsZero())
+		})
+	}
+}
+
+func TestUser_SetPassword(t *testing.T) {
+	u, _ := user.NewUser(user.UserParams{Email: "jane@example.com", Username: "jane"})
+
+	t.Run("Too short", func(t *testing.T) {
+		assert.Equal(t, user.ErrInvalidPassword, u.SetPassword("short"))
+	})
+
+	t.Run("Hash and match", func(t *testing.T) {
+		assert.NoError(t, u.SetPassword("correct horse battery"))
+		assert.NotEqual(t, []byte("correct horse battery"), u.PasswordHash())
+
+		ok, err := u.PasswordMatches("correct horse battery")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = u.PasswordMatches("wrong password")
+		assert.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+// Test cases for user settings
+func TestSetSettings(t *testing.T) {
+	u, err := user.NewUser(user.UserParams{Email: "jane@example.com", Username: "jane"})
+	assert.NoError(t, err)
+	assert.Equal(t, user.DefaultSettings, u.Settings())
+
+	assert.NoError(t, u.SetSettings(user.Settings{OneRepMaxFormula: strength.FormulaBrzycki}))
+	assert.Equal(t, strength.FormulaBrzycki, u.Settings().OneRepMaxFormula)
+
+	assert.Equal(t, strength.ErrInvalidFormula, u.SetSettings(user.Settings{OneRepMaxFormula: "guess"}))
+	assert.Equal(t, strength.FormulaBrzycki, u.Settings().OneRepMaxFormula)
+}
@@ -0,0 +1,384 @@
+package workout
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidReps is returned when a set does not have at least one repetition
+	ErrInvalidReps = errors.New("a set must have at least one repetition")
+
+	// ErrInvalidWeight is returned when a set has a negative weight
+	ErrInvalidWeight = errors.New("weight must not be negative")
+
+	// ErrInvalidUnit is returned when a weight unit is neither kg nor lb
+	ErrInvalidUnit = errors.New("unit must be kg or lb")
+
+	// ErrInvalidRPE is returned when an RPE is not between 1 and 10 in steps of 0.5
+	ErrInvalidRPE = errors.New("rpe must be between 1 and 10 in steps of 0.5")
+
+	// ErrInvalidRIR is returned when the reps in reserve are not between 0 and 10
+	ErrInvalidRIR = errors.New("rir must be between 0 and 10")
+
+	// ErrInvalidFinish is returned when a session would finish before it started
+	ErrInvalidFinish = errors.New("a session cannot finish before it started")
+
+	// ErrSessionFinished is returned when changing a session that has already finished
+	ErrSessionFinished = errors.New("workout session is already finished")
+
+	// ErrEntryNotFound is returned when an exercise entry does not belong to the session
+	ErrEntryNotFound = errors.New("exercise entry not found in workout session")
+
+	// ErrInvalidTarget is returned when a planned entry does not target at least one set with a valid rep range
+	ErrInvalidTarget = errors.New("a target must have at least one set, a valid rep range and a valid load")
+
+	// ErrUnknownExercise is returned when logging an exercise that does not exist for the user
+	ErrUnknownExercise = errors.New("exercise does not exist")
+)
+
+// Unit is the unit a weight is recorded in
+type Unit string
+
+const (
+	UnitKg Unit = "kg"
+	UnitLb Unit = "lb"
+)
+
+// Units lists the supported weight units
+var Units = []Unit{UnitKg, UnitLb}
+
+// kgPerLb converts pounds to kilograms
+const kgPerLb = 0.45359237
+
+// ToKg converts a weight in the unit to kilograms
+func (u Unit) ToKg(weight float64) float64 {
+	if u == UnitLb {
+		return weight * kgPerLb
+	}
+	return weight
+}
+
+// SetParams contains the parameters needed to record a set
+type SetParams struct {
+	ID     uuid.UUID
+	Reps   int
+	Weight float64
+	// Unit defaults to kg when empty
+	Unit Unit
+	// RPE is the rate of perceived exertion, nil when not recorded
+	RPE *float64
+	// RIR is the number of reps in reserve, nil when not recorded
+	RIR    *int
+	Warmup bool
+}
+
+// Target is what a planned entry aims for: a number of sets in a rep range with a suggested load.
+// Weight is zero when there is no load to suggest.
+type Target struct {
+	Sets    int
+	MinReps int
+	MaxReps int
+	Weight  float64
+	Unit    Unit
+}
+
+// ExerciseEntryParams contains the parameters needed to restore an exercise entry
+type ExerciseEntryParams struct {
+	ID         uuid.UUID
+	ExerciseID uuid.UUID
+	// Target is nil for entries that were not planned
+	Target *Target
+	Sets   []SetParams
+}
+
+// SessionParams contains the parameters needed to start or restore a workout session
+type SessionParams struct {
+	ID    uuid.UUID
+	Name  string
+	Notes string
+	// StartedAt defaults to the current time when zero
+	StartedAt time.Time
+	// FinishedAt is zero while the session is in progress
+	FinishedAt time.Time
+	Entries    []ExerciseEntryParams
+}
+
+// Set is one set of an exercise: the repetitions performed with a load and how hard it felt
+type Set struct {
+	id     uuid.UUID
+	reps   int
+	weight float64
+	unit   Unit
+	rpe    *float64
+	rir    *int
+	warmup bool
+}
+
+// NewSet creates a set with validation
+func NewSet(params SetParams) (Set, error) {
+	if params.Reps < 1 {
+		return Set{}, ErrInvalidReps
+	}
+	if params.Weight < 0 || math.IsNaN(params.Weight) || math.IsInf(params.Weight, 0) {
+		return Set{}, ErrInvalidWeight
+	}
+	if params.Unit == "" {
+		params.Unit = UnitKg
+	}
+	if !slices.Contains(Units, params.Unit) {
+		return Set{}, ErrInvalidUnit
+	}
+	if params.RPE != nil {
+		rpe := *params.RPE
+		if rpe < 1 || rpe > 10 || rpe*2 != math.Trunc(rpe*2) {
+			return Set{}, ErrInvalidRPE
+		}
+	}
+	if params.RIR != nil && (*params.RIR < 0 || *params.RIR > 10) {
+		return Set{}, ErrInvalidRIR
+	}
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	return Set{
+		id:     params.ID,
+		reps:   params.Reps,
+		weight: params.Weight,
+		unit:   params.Unit,
+		rpe:    clonePtr(params.RPE),
+		rir:    clonePtr(params.RIR),
+		warmup: params.Warmup,
+	}, nil
+}
+
+func (s Set) GetID() uuid.UUID   { return s.id }
+func (s Set) GetReps() int       { return s.reps }
+func (s Set) GetWeight() float64 { return s.weight }
+func (s Set) GetUnit() Unit      { return s.unit }
+func (s Set) GetRPE() *float64   { return clonePtr(s.rpe) }
+func (s Set) GetRIR() *int       { return clonePtr(s.rir) }
+func (s Set) IsWarmup() bool     { return s.warmup }
+
+// GetWeightKg returns the weight converted to kilograms, so sets logged in different units can be compared
+func (s Set) GetWeightKg() float64 { return s.unit.ToKg(s.weight) }
+
+// SessionEntry is an entry together with the session it was performed in
+type SessionEntry struct {
+	SessionID uuid.UUID
+	StartedAt time.Time
+	Entry     ExerciseEntry
+}
+
+// ExerciseEntry is an exercise performed during a session with its sets in the order they were done.
+// Entries planned from a routine also hold the target they were planned with.
+type ExerciseEntry struct {
+	id         uuid.UUID
+	exerciseID uuid.UUID
+	target     *Target
+	sets       []Set
+}
+
+// NewExerciseEntry creates an exercise entry with validation
+func NewExerciseEntry(params ExerciseEntryParams) (ExerciseEntry, error) {
+	if params.Target != nil {
+		t := *params.Target
+		if t.Unit == "" {
+			t.Unit = UnitKg
+		}
+		if t.Sets < 1 || t.MinReps < 1 || t.MaxReps < t.MinReps || t.Weight < 0 || !slices.Contains(Units, t.Unit) {
+			return ExerciseEntry{}, ErrInvalidTarget
+		}
+		params.Target = &t
+	}
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+
+	entry := ExerciseEntry{
+		id:         params.ID,
+		exerciseID: params.ExerciseID,
+		target:     params.Target,
+		sets:       make([]Set, 0, len(params.Sets)),
+	}
+	for _, sp := range params.Sets {
+		set, err := NewSet(sp)
+		if err != nil {
+			return ExerciseEntry{}, err
+		}
+		entry.sets = append(entry.sets, set)
+	}
+
+	return entry, nil
+}
+
+func (e ExerciseEntry) GetID() uuid.UUID         { return e.id }
+func (e ExerciseEntry) GetExerciseID() uuid.UUID { return e.exerciseID }
+func (e ExerciseEntry) GetTarget() *Target       { return clonePtr(e.target) }
+func (e ExerciseEntry) GetSets() []Set           { return slices.Clone(e.sets) }
+
+// TopSet returns the heaviest working set of the entry, the earliest one when several share the weight.
+// Weights are compared in kg, so sets logged in different units are ranked by what they actually weigh.
+// ok is false when the entry has no working sets.
+func (e ExerciseEntry) TopSet() (top Set, ok bool) {
+	for _, s := range e.sets {
+		if s.warmup {
+			continue
+		}
+		if !ok || s.GetWeightKg() > top.GetWeightKg() {
+			top, ok = s, true
+		}
+	}
+	return top, ok
+}
+
+// Session is an aggregate holding the exercises performed during one workout
+type Session struct {
+	id         uuid.UUID
+	name       string
+	notes      string
+	startedAt  time.Time
+	finishedAt time.Time
+	entries    []ExerciseEntry
+}
+
+// NewSession creates a new Session aggregate with validation
+func NewSession(params SessionParams) (*Session, error) {
+	if params.ID == uuid.Nil {
+		params.ID = uuid.New()
+	}
+	if params.StartedAt.IsZero() {
+		params.StartedAt = time.Now()
+	}
+	if !params.FinishedAt.IsZero() && params.FinishedAt.Before(params.StartedAt) {
+		return &Session{}, ErrInvalidFinish
+	}
+
+	s := &Session{
+		id:         params.ID,
+		name:       params.Name,
+		notes:      params.Notes,
+		startedAt:  params.StartedAt,
+		finishedAt: params.FinishedAt,
+		entries:    make([]ExerciseEntry, 0, len(params.Entries)),
+	}
+
+	for _, ep := range params.Entries {
+		entry, err := NewExerciseEntry(ep)
+		if err != nil {
+			return &Session{}, err
+		}
+		s.entries = append(s.entries, entry)
+	}
+
+	return s, nil
+}
+
+func (s *Session) GetID() uuid.UUID {
+	return s.id
+}
+
+func (s *Session) GetName() string {
+	return s.name
+}
+
+func (s *Session) GetNotes() string {
+	return s.notes
+}
+
+func (s *Session) GetStartedAt() time.Time {
+	return s.startedAt
+}
+
+// GetFinishedAt returns when the session finished, zero while it is in progress
+func (s *Session) GetFinishedAt() time.Time {
+	return s.finishedAt
+}
+
+func (s *Session) IsFinished() bool {
+	return !s.finishedAt.IsZero()
+}
+
+// GetEntries returns the exercise entries in the order they were started
+func (s *Session) GetEntries() []ExerciseEntry {
+	entries := make([]ExerciseEntry, 0, len(s.entries))
+	for _, e := range s.entries {
+		e.sets = slices.Clone(e.sets)
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// AddEntry appends an exercise to the session
+func (s *Session) AddEntry(exerciseID uuid.UUID) (ExerciseEntry, error) {
+	if s.IsFinished() {
+		return ExerciseEntry{}, ErrSessionFinished
+	}
+
+	entry := ExerciseEntry{id: uuid.New(), exerciseID: exerciseID}
+	s.entries = append(s.entries, entry)
+	return entry, nil
+}
+
+// AddSet records a set at the end of an exercise entry
+func (s *Session) AddSet(entryID uuid.UUID, params SetParams) (Set, error) {
+	if s.IsFinished() {
+		return Set{}, ErrSessionFinished
+	}
+
+	i := slices.IndexFunc(s.entries, func(e ExerciseEntry) bool { return e.id == entryID })
+	if i == -1 {
+		return Set{}, ErrEntryNotFound
+	}
+
+	params.ID = uuid.Nil
+	set, err := NewSet(params)
+	if err != nil {
+		return Set{}, err
+	}
+
+	s.entries[i].sets = append(s.entries[i].sets, set)
+	return set, nil
+}
+
+// Volume returns the weight in kg moved in the working sets of an exercise, summed over its entries
+func (s *Session) Volume(exerciseID uuid.UUID) float64 {
+	volume := 0.0
+	for _, e := range s.entries {
+		if e.exerciseID != exerciseID {
+			continue
+		}
+		for _, set := range e.sets {
+			if !set.warmup {
+				volume += float64(set.reps) * set.GetWeightKg()
+			}
+		}
+	}
+	return volume
+}
+
+// Finish marks the session as finished at the given time, after which it can no longer be changed
+func (s *Session) Finish(at time.Time) error {
+	if s.IsFinished() {
+		return ErrSessionFinished
+	}
+	if at.Before(s.startedAt) {
+		return ErrInvalidFinish
+	}
+
+	s.finishedAt = at
+	return nil
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
@@ -0,0 +1,28 @@
+package workout
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// WorkoutRepository defines the storage operations for workout Session aggregates.
+// Implementations must persist a session together with its entries and sets as a single unit,
+// and keep the IDs of entries and sets stable across updates.
+type WorkoutRepository interface {
+	Add(ctx context.Context, userID uuid.UUID, session *Session) error
+	GetByID(ctx context.Context, userID, sessionID uuid.UUID) (*Session, error)
+	List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Session], error)
+	// Modify loads a session, applies fn to it and saves the result atomically. Concurrent calls for the
+	// same session must not overlap, so a change is never lost to another one saving an older copy.
+	Modify(ctx context.Context, userID, sessionID uuid.UUID, fn func(*Session) error) (*Session, error)
+	Delete(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	// LatestEntries returns, for each of the exercises, its entry with working sets from the most recent session.
+	// Exercises the user has not performed yet are missing from the map.
+	LatestEntries(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]ExerciseEntry, error)
+
+	// ExerciseHistory returns every entry of the exercise with its session, from the oldest session to the newest
+	ExerciseHistory(ctx context.Context, userID, exerciseID uuid.UUID) ([]SessionEntry, error)
+}
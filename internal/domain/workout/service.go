@@ -0,0 +1,58 @@
+package workout
+
+import (
+	"context"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+// WorkoutService defines the business operations available for workout sessions
+type WorkoutService interface {
+	AddSession(ctx context.Context, userID uuid.UUID, session *Session) error
+	RemoveSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	ListSessions(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Session], error)
+	GetSessionByID(ctx context.Context, userID, sessionID uuid.UUID) (*Session, error)
+	ModifySession(ctx context.Context, userID, sessionID uuid.UUID, fn func(*Session) error) (*Session, error)
+	LatestEntries(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]ExerciseEntry, error)
+	ExerciseHistory(ctx context.Context, userID, exerciseID uuid.UUID) ([]SessionEntry, error)
+}
+
+type workoutService struct {
+	repo WorkoutRepository
+}
+
+// NewWorkoutService create a new service with the provided repository
+func NewWorkoutService(repo WorkoutRepository) WorkoutService {
+	return &workoutService{
+		repo: repo,
+	}
+}
+
+func (s *workoutService) AddSession(ctx context.Context, userID uuid.UUID, session *Session) error {
+	return s.repo.Add(ctx, userID, session)
+}
+
+func (s *workoutService) RemoveSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.repo.Delete(ctx, userID, sessionID)
+}
+
+func (s *workoutService) ListSessions(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*Session], error) {
+	return s.repo.List(ctx, userID, q)
+}
+
+func (s *workoutService) GetSessionByID(ctx context.Context, userID, sessionID uuid.UUID) (*Session, error) {
+	return s.repo.GetByID(ctx, userID, sessionID)
+}
+
+func (s *workoutService) ModifySession(ctx context.Context, userID, sessionID uuid.UUID, fn func(*Session) error) (*Session, error) {
+	return s.repo.Modify(ctx, userID, sessionID, fn)
+}
+
+func (s *workoutService) LatestEntries(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]ExerciseEntry, error) {
+	return s.repo.LatestEntries(ctx, userID, exerciseIDs)
+}
+
+func (s *workoutService) ExerciseHistory(ctx context.Context, userID, exerciseID uuid.UUID) ([]SessionEntry, error) {
+	return s.repo.ExerciseHistory(ctx, userID, exerciseID)
+}
@@ -0,0 +1,180 @@
+package workout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+// Test cases for Set validation
+func TestNewSet(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        workout.SetParams
+		expectedError error
+	}{
+		{
+			name:          "Valid set",
+			params:        workout.SetParams{Reps: 5, Weight: 100, Unit: workout.UnitKg, RPE: ptr(8.5), RIR: ptr(2)},
+			expectedError: nil,
+		},
+		{
+			name:          "Bodyweight set without unit",
+			params:        workout.SetParams{Reps: 12},
+			expectedError: nil,
+		},
+		{
+			name:          "No reps",
+			params:        workout.SetParams{Reps: 0, Weight: 100},
+			expectedError: workout.ErrInvalidReps,
+		},
+		{
+			name:          "Negative weight",
+			params:        workout.SetParams{Reps: 5, Weight: -1},
+			expectedError: workout.ErrInvalidWeight,
+		},
+		{
+			name:          "Unknown unit",
+			params:        workout.SetParams{Reps: 5, Weight: 10, Unit: "stone"},
+			expectedError: workout.ErrInvalidUnit,
+		},
+		{
+			name:          "RPE out of range",
+			params:        workout.SetParams{Reps: 5, RPE: ptr(11.0)},
+			expectedError: workout.ErrInvalidRPE,
+		},
+		{
+			name:          "RPE not in half steps",
+			params:        workout.SetParams{Reps: 5, RPE: ptr(7.3)},
+			expectedError: workout.ErrInvalidRPE,
+		},
+		{
+			name:          "Negative RIR",
+			params:        workout.SetParams{Reps: 5, RIR: ptr(-1)},
+			expectedError: workout.ErrInvalidRIR,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := workout.NewSet(tc.params)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEqual(t, uuid.Nil, s.GetID())
+			assert.Contains(t, workout.Units, s.GetUnit())
+		})
+	}
+}
+
+func TestSession_AddSet(t *testing.T) {
+	s, _ := workout.NewSession(workout.SessionParams{Name: "Push"})
+	entry, err := s.AddEntry(uuid.New())
+	assert.NoError(t, err)
+
+	_, err = s.AddSet(uuid.New(), workout.SetParams{Reps: 5})
+	assert.Equal(t, workout.ErrEntryNotFound, err)
+
+	_, err = s.AddSet(entry.GetID(), workout.SetParams{Reps: 10, Weight: 60, Warmup: true})
+	assert.NoError(t, err)
+	_, err = s.AddSet(entry.GetID(), workout.SetParams{Reps: 5, Weight: 100, RPE: ptr(9.0)})
+	assert.NoError(t, err)
+
+	sets := s.GetEntries()[0].GetSets()
+	assert.Len(t, sets, 2)
+	assert.True(t, sets[0].IsWarmup())
+	assert.Equal(t, 100.0, sets[1].GetWeight())
+	assert.Equal(t, 9.0, *sets[1].GetRPE())
+	assert.Nil(t, sets[1].GetRIR())
+}
+
+func TestSession_Finish(t *testing.T) {
+	start := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+	s, _ := workout.NewSession(workout.SessionParams{StartedAt: start})
+	entry, _ := s.AddEntry(uuid.New())
+
+	assert.Equal(t, workout.ErrInvalidFinish, s.Finish(start.Add(-time.Minute)))
+	assert.False(t, s.IsFinished())
+
+	assert.NoError(t, s.Finish(start.Add(time.Hour)))
+	assert.True(t, s.IsFinished())
+	assert.Equal(t, start.Add(time.Hour), s.GetFinishedAt())
+
+	assert.Equal(t, workout.ErrSessionFinished, s.Finish(start.Add(2*time.Hour)))
+	_, err := s.AddEntry(uuid.New())
+	assert.Equal(t, workout.ErrSessionFinished, err)
+	_, err = s.AddSet(entry.GetID(), workout.SetParams{Reps: 5})
+	assert.Equal(t, workout.ErrSessionFinished, err)
+}
+
+func TestNewSession_RestoresEntries(t *testing.T) {
+	entryID, setID := uuid.New(), uuid.New()
+
+	s, err := workout.NewSession(workout.SessionParams{
+		Entries: []workout.ExerciseEntryParams{{
+			ID:         entryID,
+			ExerciseID: uuid.New(),
+			Sets:       []workout.SetParams{{ID: setID, Reps: 3, Weight: 225, Unit: workout.UnitLb}},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, entryID, s.GetEntries()[0].GetID())
+	assert.Equal(t, setID, s.GetEntries()[0].GetSets()[0].GetID())
+
+	_, err = workout.NewSession(workout.SessionParams{
+		Entries: []workout.ExerciseEntryParams{{ExerciseID: uuid.New(), Sets: []workout.SetParams{{Reps: 0}}}},
+	})
+	assert.Equal(t, workout.ErrInvalidReps, err)
+}
+
+func TestNewExerciseEntry_Target(t *testing.T) {
+	entry, err := workout.NewExerciseEntry(workout.ExerciseEntryParams{
+		ExerciseID: uuid.New(),
+		Target:     &workout.Target{Sets: 3, MinReps: 8, MaxReps: 12, Weight: 60},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, workout.UnitKg, entry.GetTarget().Unit, "the unit defaults to kg")
+
+	_, err = workout.NewExerciseEntry(workout.ExerciseEntryParams{
+		ExerciseID: uuid.New(),
+		Target:     &workout.Target{Sets: 3, MinReps: 12, MaxReps: 8},
+	})
+	assert.Equal(t, workout.ErrInvalidTarget, err)
+}
+
+func TestExerciseEntry_TopSet(t *testing.T) {
+	s, _ := workout.NewSession(workout.SessionParams{})
+	entry, _ := s.AddEntry(uuid.New())
+
+	_, ok := s.GetEntries()[0].TopSet()
+	assert.False(t, ok)
+
+	s.AddSet(entry.GetID(), workout.SetParams{Reps: 5, Weight: 140, Warmup: true})
+	_, ok = s.GetEntries()[0].TopSet()
+	assert.False(t, ok, "warmup sets are not working sets")
+
+	s.AddSet(entry.GetID(), workout.SetParams{Reps: 5, Weight: 100})
+	heaviest, _ := s.AddSet(entry.GetID(), workout.SetParams{Reps: 3, Weight: 110})
+	s.AddSet(entry.GetID(), workout.SetParams{Reps: 2, Weight: 110})
+
+	top, ok := s.GetEntries()[0].TopSet()
+	assert.True(t, ok)
+	assert.Equal(t, heaviest.GetID(), top.GetID())
+
+	s.AddSet(entry.GetID(), workout.SetParams{Reps: 5, Weight: 200, Unit: workout.UnitLb})
+	top, _ = s.GetEntries()[0].TopSet()
+	assert.Equal(t, heaviest.GetID(), top.GetID(), "200 lb is lighter than 110 kg")
+
+	pounds, _ := s.AddSet(entry.GetID(), workout.SetParams{Reps: 1, Weight: 250, Unit: workout.UnitLb})
+	top, _ = s.GetEntries()[0].TopSet()
+	assert.Equal(t, pounds.GetID(), top.GetID(), "250 lb is heavier than 110 kg")
+}
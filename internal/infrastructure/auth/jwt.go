@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidToken is returned when a token cannot be parsed, is expired or fails signature validation
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Authenticator issues and verifies access tokens
+type Authenticator interface {
+	GenerateToken(userID uuid.UUID) (string, error)
+	ValidateToken(token string) (uuid.UUID, error)
+	TokenExpiry() time.Duration
+}
+
+// JWTAuthenticator implements Authenticator using HMAC signed JWTs
+type JWTAuthenticator struct {
+	secret   []byte
+	issuer   string
+	audience string
+	expiry   time.Duration
+}
+
+// NewJWTAuthenticator creates a new authenticator that signs tokens with the provided secret
+func NewJWTAuthenticator(secret, issuer, audience string, expiry time.Duration) *JWTAuthenticator {
+	return &JWTAuthenticator{
+		secret:   []byte(secret),
+		issuer:   issuer,
+		audience: audience,
+		expiry:   expiry,
+	}
+}
+
+// GenerateToken issues a signed access token with the user ID as subject
+func (a *JWTAuthenticator) GenerateToken(userID uuid.UUID) (string, error) {
+	now := time.Now()
+
+	claims := jwt.RegisteredClaims{
+		Subject:   userID.String(),
+		Issuer:    a.issuer,
+		Audience:  jwt.ClaimStrings{a.audience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(a.expiry)),
+		ID:        uuid.NewString(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(a.secret)
+}
+
+// ValidateToken verifies the token signature and registered claims
+// and returns the user ID stored in the subject
+func (a *JWTAuthenticator) ValidateToken(token string) (uuid.UUID, error) {
+	var claims jwt.RegisteredClaims
+
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return a.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(a.issuer),
+		jwt.WithAudience(a.audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return userID, nil
+}
+
+// TokenExpiry returns how long issued access tokens remain valid
+func (a *JWTAuthenticator) TokenExpiry() time.Duration {
+	return a.expiry
+}
@@ -0,0 +1,48 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTAuthenticator(t *testing.T) {
+	authenticator := auth.NewJWTAuthenticator("secret", "exercise-api", "exercise-api", time.Minute)
+	userID := uuid.New()
+
+	t.Run("Round trip", func(t *testing.T) {
+		token, err := authenticator.GenerateToken(userID)
+		assert.NoError(t, err)
+
+		got, err := authenticator.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, userID, got)
+	})
+
+	t.Run("Wrong secret", func(t *testing.T) {
+		other := auth.NewJWTAuthenticator("other", "exercise-api", "exercise-api", time.Minute)
+		token, _ := other.GenerateToken(userID)
+
+		_, err := authenticator.ValidateToken(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired := auth.NewJWTAuthenticator("secret", "exercise-api", "exercise-api", -time.Minute)
+		token, _ := expired.GenerateToken(userID)
+
+		_, err := authenticator.ValidateToken(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("Wrong audience", func(t *testing.T) {
+		other := auth.NewJWTAuthenticator("secret", "exercise-api", "someone-else", time.Minute)
+		token, _ := other.GenerateToken(userID)
+
+		_, err := authenticator.ValidateToken(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,168 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+)
+
+const (
+	// MaxUploadSize is the largest image accepted, in bytes
+	MaxUploadSize = 5 << 20
+	// MaxPixels is the largest number of pixels an image may decode to, it guards against decompression bombs
+	MaxPixels = 40_000_000
+	// ThumbnailSize is the longest side of a thumbnail, in pixels
+	ThumbnailSize = 320
+)
+
+var (
+	// ErrUnsupportedType is returned for uploads that are not JPEG, PNG or GIF images
+	ErrUnsupportedType = errors.New("image must be a JPEG, PNG or GIF")
+
+	// ErrTooLarge is returned for uploads larger than MaxUploadSize or MaxPixels
+	ErrTooLarge = fmt.Errorf("image must be at most %d MB and %d megapixels", MaxUploadSize>>20, MaxPixels/1_000_000)
+
+	// ErrInvalidImage is returned for uploads that look like an image but cannot be decoded
+	ErrInvalidImage = errors.New("image could not be decoded")
+)
+
+// supportedTypes lists the content types accepted, as sniffed from the data
+var supportedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Encoded is an image ready to be stored
+type Encoded struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Processed holds an uploaded image and its thumbnail, both re-encoded
+type Processed struct {
+	Original  Encoded
+	Thumbnail Encoded
+}
+
+// Process checks and re-encodes an uploaded image and generates its thumbnail.
+// The type is sniffed from the data, whatever the client claims. Re-encoding drops EXIF and every other
+// metadata block, the EXIF orientation of JPEG images is applied to the pixels first.
+// JPEG images stay JPEG, PNG and GIF images become PNG, keeping only the first frame of an animation.
+func Process(r io.Reader) (Processed, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxUploadSize+1))
+	if err != nil {
+		return Processed{}, err
+	}
+	if len(data) > MaxUploadSize {
+		return Processed{}, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !supportedTypes[contentType] {
+		return Processed{}, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return Processed{}, ErrTooLarge
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Processed{}, ErrInvalidImage
+	}
+
+	img := toRGBA(decoded)
+	if contentType == "image/jpeg" {
+		img = orient(img, jpegOrientation(data))
+	} else {
+		contentType = "image/png"
+	}
+
+	original, err := encode(img, contentType)
+	if err != nil {
+		return Processed{}, err
+	}
+	thumbnail, err := encode(thumbnail(img, ThumbnailSize), contentType)
+	if err != nil {
+		return Processed{}, err
+	}
+	return Processed{Original: original, Thumbnail: thumbnail}, nil
+}
+
+func encode(img *image.RGBA, contentType string) (Encoded, error) {
+	var buf bytes.Buffer
+	var err error
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return Encoded{}, err
+	}
+
+	bounds := img.Bounds()
+	return Encoded{Data: buf.Bytes(), ContentType: contentType, Width: bounds.Dx(), Height: bounds.Dy()}, nil
+}
+
+// toRGBA copies an image into an RGBA image whose bounds start at the origin
+func toRGBA(src image.Image) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, b.Min, draw.Src)
+	return dst
+}
+
+// thumbnail scales the image down to fit in a size × size square, averaging the pixels each one covers.
+// Images that already fit are returned as they are.
+func thumbnail(src *image.RGBA, size int) *image.RGBA {
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	if w <= size && h <= size {
+		return src
+	}
+
+	tw, th := size, max(1, h*size/w)
+	if h > w {
+		tw, th = max(1, w*size/h), size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for ty := 0; ty < th; ty++ {
+		y0, y1 := ty*h/th, max((ty+1)*h/th, ty*h/th+1)
+		for tx := 0; tx < tw; tx++ {
+			x0, x1 := tx*w/tw, max((tx+1)*w/tw, tx*w/tw+1)
+
+			var sum [4]int
+			for y := y0; y < y1; y++ {
+				row := src.Pix[y*src.Stride+x0*4 : y*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			o := ty*dst.Stride + tx*4
+			for c := range sum {
+				dst.Pix[o+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
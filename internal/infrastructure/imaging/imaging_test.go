@@ -0,0 +1,160 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// halves returns a w × h image whose left half is red and right half is blue
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+// withOrientation inserts an Exif APP1 segment holding the orientation after the start of a JPEG image
+func withOrientation(t *testing.T, jpg []byte, orientation uint16) []byte {
+	t.Helper()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := binary.BigEndian.AppendUint16([]byte{0xFF, 0xE1}, uint16(len(segment)+2))
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, app1...)
+	out = append(out, segment...)
+	return append(out, jpg[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	return buf.Bytes()
+}
+
+func decode(t *testing.T, e imaging.Encoded) image.Image {
+	t.Helper()
+	img, _, err := image.Decode(bytes.NewReader(e.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Pt(e.Width, e.Height), img.Bounds().Size())
+	return img
+}
+
+func isRed(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return r > 0xC000 && g < 0x4000 && b < 0x4000
+}
+
+func isBlue(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	return b > 0xC000 && r < 0x4000 && g < 0x4000
+}
+
+func TestProcess_JPEG(t *testing.T) {
+	upload := withOrientation(t, encodeJPEG(t, halves(800, 400)), 6)
+	require.True(t, bytes.Contains(upload, []byte("Exif")))
+
+	processed, err := imaging.Process(bytes.NewReader(upload))
+	require.NoError(t, err)
+
+	t.Run("Metadata is stripped", func(t *testing.T) {
+		assert.Equal(t, "image/jpeg", processed.Original.ContentType)
+		assert.False(t, bytes.Contains(processed.Original.Data, []byte("Exif")))
+		assert.False(t, bytes.Contains(processed.Thumbnail.Data, []byte("Exif")))
+	})
+
+	t.Run("Orientation is applied", func(t *testing.T) {
+		img := decode(t, processed.Original)
+		assert.Equal(t, image.Pt(400, 800), img.Bounds().Size())
+
+		// Rotating clockwise moves the left half of the stored image to the top
+		assert.True(t, isRed(img.At(200, 100)))
+		assert.True(t, isBlue(img.At(200, 700)))
+	})
+
+	t.Run("Thumbnail fits the thumbnail size", func(t *testing.T) {
+		img := decode(t, processed.Thumbnail)
+		assert.Equal(t, image.Pt(imaging.ThumbnailSize/2, imaging.ThumbnailSize), img.Bounds().Size())
+		assert.True(t, isRed(img.At(80, 40)))
+		assert.True(t, isBlue(img.At(80, 280)))
+	})
+}
+
+func TestProcess_PNGAndGIF(t *testing.T) {
+	var pngData, gifData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, halves(100, 50)))
+	require.NoError(t, gif.Encode(&gifData, halves(100, 50), nil))
+
+	for name, data := range map[string][]byte{"PNG": pngData.Bytes(), "GIF": gifData.Bytes()} {
+		t.Run(name, func(t *testing.T) {
+			processed, err := imaging.Process(bytes.NewReader(data))
+			require.NoError(t, err)
+
+			assert.Equal(t, "image/png", processed.Original.ContentType)
+			assert.Equal(t, "image/png", processed.Thumbnail.ContentType)
+
+			// Small images are not scaled up
+			img := decode(t, processed.Thumbnail)
+			assert.Equal(t, image.Pt(100, 50), img.Bounds().Size())
+			assert.True(t, isRed(img.At(10, 10)))
+			assert.True(t, isBlue(img.At(90, 40)))
+		})
+	}
+}
+
+func TestProcess_Rejected(t *testing.T) {
+	tooManyPixels := image.NewGray(image.Rect(0, 0, 8000, 6000))
+	var bomb bytes.Buffer
+	require.NoError(t, png.Encode(&bomb, tooManyPixels))
+
+	tests := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{name: "Not an image", data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), expectedError: imaging.ErrUnsupportedType},
+		{name: "Empty", data: nil, expectedError: imaging.ErrUnsupportedType},
+		{name: "Truncated", data: encodeJPEG(t, halves(64, 64))[:200], expectedError: imaging.ErrInvalidImage},
+		{name: "Too many bytes", data: append([]byte("\x89PNG\r\n\x1a\n"), strings.Repeat("x", imaging.MaxUploadSize)...), expectedError: imaging.ErrTooLarge},
+		{name: "Too many pixels", data: bomb.Bytes(), expectedError: imaging.ErrTooLarge},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := imaging.Process(bytes.NewReader(tc.data))
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,354 @@
+// Package migrate applies the SQL migrations of the database schema.
+//
+// Migrations are pairs of files named NNNNNN_description.up.sql and NNNNNN_description.down.sql.
+// The current version is tracked in the schema_migrations table, which uses the same layout as the
+// migrate CLI the runner replaces, so databases migrated with the CLI can be taken over as they are.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// lockID is the key of the Postgres advisory lock held while migrating,
+// it keeps several API instances starting at once from migrating concurrently
+const lockID = 7_341_928_164
+
+var (
+	ErrDirty           = errors.New("database is dirty, fix the failed migration and force its version")
+	ErrUnknownVersion  = errors.New("no migration with this version")
+	ErrInvalidSteps    = errors.New("number of migrations must be positive")
+	ErrMissingDown     = errors.New("migration has no down file")
+	ErrInvalidFileName = errors.New("migration file name does not match NNNNNN_description.(up|down).sql")
+)
+
+// fileNamePattern matches migration file names and captures the version, description and direction
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration is one version of the schema
+type Migration struct {
+	Version     uint
+	Description string
+	up          string
+	down        string
+}
+
+// Status is the state of the database schema
+type Status struct {
+	Version    uint
+	Dirty      bool
+	Migrations []MigrationStatus
+}
+
+// MigrationStatus reports whether a migration is applied
+type MigrationStatus struct {
+	Version     uint
+	Description string
+	Applied     bool
+}
+
+// Migrator applies migrations read from a file system to a database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New reads the migrations in the root of fsys
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// load reads and pairs the up and down files of every migration, ordered by version
+func load(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, name := range names {
+		match := fileNamePattern.FindStringSubmatch(path.Base(name))
+		if match == nil {
+			return nil, fmt.Errorf("%s: %w", name, ErrInvalidFileName)
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, ErrInvalidFileName)
+		}
+
+		body, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Description: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if match[3] == "up" {
+			m.up = string(body)
+		} else {
+			m.down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// step is one migration to run in one direction
+type step struct {
+	migration Migration
+	up        bool
+	// version is the schema version once the step has run
+	version uint
+}
+
+// plan returns the steps that move the schema from version current to version target.
+// Version 0 is the empty schema before the first migration.
+func plan(migrations []Migration, current, target uint) ([]step, error) {
+	if target != 0 && indexOf(migrations, target) < 0 {
+		return nil, fmt.Errorf("version %d: %w", target, ErrUnknownVersion)
+	}
+
+	var steps []step
+	if target >= current {
+		for _, m := range migrations {
+			if m.Version > current && m.Version <= target {
+				steps = append(steps, step{migration: m, up: true, version: m.Version})
+			}
+		}
+		return steps, nil
+	}
+
+	for i := len(migrations) - 1; i >= 0; i-- {
+		m := migrations[i]
+		if m.Version > current || m.Version <= target {
+			continue
+		}
+		if m.down == "" {
+			return nil, fmt.Errorf("version %d: %w", m.Version, ErrMissingDown)
+		}
+
+		previous := uint(0)
+		if i > 0 {
+			previous = migrations[i-1].Version
+		}
+		steps = append(steps, step{migration: m, up: false, version: previous})
+	}
+	return steps, nil
+}
+
+// indexOf returns the position of the migration with version, or -1
+func indexOf(migrations []Migration, version uint) int {
+	for i, m := range migrations {
+		if m.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// latest returns the version of the newest migration
+func (m *Migrator) latest() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies all migrations that have not been applied yet
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.migrate(ctx, func(uint) uint { return m.latest() })
+}
+
+// Down reverts the last n applied migrations
+func (m *Migrator) Down(ctx context.Context, n int) error {
+	if n <= 0 {
+		return ErrInvalidSteps
+	}
+
+	return m.migrate(ctx, func(current uint) uint {
+		i := indexOf(m.migrations, current) - n
+		if i < 0 {
+			return 0
+		}
+		return m.migrations[i].Version
+	})
+}
+
+// Goto migrates up or down to version, version 0 reverts every migration
+func (m *Migrator) Goto(ctx context.Context, version uint) error {
+	return m.migrate(ctx, func(uint) uint { return version })
+}
+
+// Force sets the version without running migrations and clears the dirty flag.
+// It is used to recover after a migration failed half way and was fixed by hand.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && indexOf(m.migrations, version) < 0 {
+		return fmt.Errorf("version %d: %w", version, ErrUnknownVersion)
+	}
+
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := setVersion(ctx, tx, version); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// Status returns the current version and which migrations are applied
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	var status Status
+
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		status.Version, status.Dirty = version, dirty
+		for _, mig := range m.migrations {
+			status.Migrations = append(status.Migrations, MigrationStatus{
+				Version:     mig.Version,
+				Description: mig.Description,
+				Applied:     mig.Version <= version,
+			})
+		}
+		return nil
+	})
+
+	return status, err
+}
+
+// migrate runs the steps from the current version to the version returned by target.
+// Each migration runs in a transaction together with the version update,
+// so a failing migration leaves the schema at the last version that succeeded.
+func (m *Migrator) migrate(ctx context.Context, target func(current uint) uint) error {
+	return m.locked(ctx, func(conn *sql.Conn) error {
+		current, dirty, err := currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("version %d: %w", current, ErrDirty)
+		}
+		if current != 0 && indexOf(m.migrations, current) < 0 {
+			return fmt.Errorf("database is at version %d: %w", current, ErrUnknownVersion)
+		}
+
+		steps, err := plan(m.migrations, current, target(current))
+		if err != nil {
+			return err
+		}
+
+		for _, s := range steps {
+			if err := run(ctx, conn, s); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// run executes one step and records the resulting version
+func run(ctx context.Context, conn *sql.Conn, s step) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query, direction := s.migration.up, "up"
+	if !s.up {
+		query, direction = s.migration.down, "down"
+	}
+
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("migration %d_%s %s: %w", s.migration.Version, s.migration.Description, direction, err)
+	}
+
+	if err := setVersion(ctx, tx, s.version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// locked runs fn on a single connection that holds the migration advisory lock
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID)
+
+	if _, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)`); err != nil {
+		return fmt.Errorf("creating version table: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// currentVersion reads the schema version, 0 when no migration has been applied
+func currentVersion(ctx context.Context, conn *sql.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+
+	err := conn.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return uint(version), dirty, nil
+}
+
+// setVersion replaces the recorded version with a clean one, version 0 is stored as an empty table
+func setVersion(ctx context.Context, tx *sql.Tx, version uint) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations`); err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, false)`, int64(version))
+	return err
+}
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMigrations(t *testing.T) []Migration {
+	t.Helper()
+
+	migrations, err := load(fstest.MapFS{
+		"000002_create_b.up.sql":   {Data: []byte("CREATE TABLE b ();")},
+		"000002_create_b.down.sql": {Data: []byte("DROP TABLE b;")},
+		"000001_create_a.up.sql":   {Data: []byte("CREATE TABLE a ();")},
+		"000001_create_a.down.sql": {Data: []byte("DROP TABLE a;")},
+		"000003_create_c.up.sql":   {Data: []byte("CREATE TABLE c ();")},
+		"000003_create_c.down.sql": {Data: []byte("DROP TABLE c;")},
+		"migrations.go":            {Data: []byte("package migrations")},
+	})
+	require.NoError(t, err)
+	return migrations
+}
+
+func TestLoad(t *testing.T) {
+	migrations := testMigrations(t)
+
+	require.Len(t, migrations, 3)
+	assert.Equal(t, uint(1), migrations[0].Version)
+	assert.Equal(t, "create_a", migrations[0].Description)
+	assert.Equal(t, "CREATE TABLE a ();", migrations[0].up)
+	assert.Equal(t, "DROP TABLE a;", migrations[0].down)
+	assert.Equal(t, uint(3), migrations[2].Version)
+}
+
+func TestLoad_InvalidFileName(t *testing.T) {
+	_, err := load(fstest.MapFS{"create_a.sql": {Data: []byte("CREATE TABLE a ();")}})
+	assert.ErrorIs(t, err, ErrInvalidFileName)
+}
+
+func TestPlan(t *testing.T) {
+	migrations := testMigrations(t)
+
+	type expectedStep struct {
+		version uint
+		up      bool
+		after   uint
+	}
+
+	tests := []struct {
+		name          string
+		current       uint
+		target        uint
+		expectedSteps []expectedStep
+		expectedError error
+	}{
+		{
+			name:          "Up from empty schema",
+			current:       0,
+			target:        3,
+			expectedSteps: []expectedStep{{1, true, 1}, {2, true, 2}, {3, true, 3}},
+		},
+		{
+			name:          "Up from intermediate version",
+			current:       2,
+			target:        3,
+			expectedSteps: []expectedStep{{3, true, 3}},
+		},
+		{
+			name:    "Already at target",
+			current: 3,
+			target:  3,
+		},
+		{
+			name:          "Down to intermediate version",
+			current:       3,
+			target:        1,
+			expectedSteps: []expectedStep{{3, false, 2}, {2, false, 1}},
+		},
+		{
+			name:          "Down to empty schema",
+			current:       2,
+			target:        0,
+			expectedSteps: []expectedStep{{2, false, 1}, {1, false, 0}},
+		},
+		{
+			name:          "Unknown target",
+			current:       1,
+			target:        7,
+			expectedError: ErrUnknownVersion,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			steps, err := plan(migrations, tc.current, tc.target)
+
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			require.Len(t, steps, len(tc.expectedSteps))
+			for i, expected := range tc.expectedSteps {
+				assert.Equal(t, expected.version, steps[i].migration.Version)
+				assert.Equal(t, expected.up, steps[i].up)
+				assert.Equal(t, expected.after, steps[i].version)
+			}
+		})
+	}
+}
+
+func TestPlan_MissingDown(t *testing.T) {
+	migrations, err := load(fstest.MapFS{
+		"000001_create_a.up.sql": {Data: []byte("CREATE TABLE a ();")},
+	})
+	require.NoError(t, err)
+
+	_, err = plan(migrations, 1, 0)
+	assert.ErrorIs(t, err, ErrMissingDown)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// SignatureParam is the query parameter that holds the signature of a signed URL
+	SignatureParam = "signature"
+	// ExpiresParam is the query parameter that holds when a signed URL expires, in Unix seconds
+	ExpiresParam = "expires"
+)
+
+// URLSigner signs the URLs blobs are served from, so that they can be embedded in pages without an access token.
+// A signature covers the path and the expiry of the URL, so a leaked URL stops working once it expires.
+// URLs are meant to be signed whenever they are handed out rather than stored.
+type URLSigner struct {
+	secret []byte
+	base   *url.URL
+	ttl    time.Duration
+}
+
+// NewURLSigner creates a signer whose URLs start with baseURL, the public address of the API,
+// and stay valid for ttl after they are signed
+func NewURLSigner(secret, baseURL string, ttl time.Duration) (*URLSigner, error) {
+	base, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+	return &URLSigner{secret: []byte(secret), base: base, ttl: ttl}, nil
+}
+
+// Sign returns the absolute URL of the path with its expiry and signature
+func (s *URLSigner) Sign(path string, now time.Time) url.URL {
+	expires := strconv.FormatInt(now.Add(s.ttl).Unix(), 10)
+
+	u := *s.base
+	u.Path += path
+	u.RawQuery = url.Values{ExpiresParam: {expires}, SignatureParam: {s.signature(path, expires)}}.Encode()
+	return u
+}
+
+// Verify reports whether the query of a request for the path holds a signature made by Sign
+// that has not expired at now
+func (s *URLSigner) Verify(path string, query url.Values, now time.Time) bool {
+	expires := query.Get(ExpiresParam)
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !now.Before(time.Unix(unix, 0)) {
+		return false
+	}
+	return hmac.Equal([]byte(query.Get(SignatureParam)), []byte(s.signature(path, expires)))
+}
+
+func (s *URLSigner) signature(path, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(path))
+	mac.Write([]byte{0})
+	mac.Write([]byte(expires))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when reading a blob that does not exist
+	ErrNotFound = errors.New("blob not found")
+
+	// ErrInvalidKey is returned for keys that are not relative slash separated paths without . or .. elements
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore stores blobs by key. Keys are slash separated relative paths such as images/<id>/original.
+// Deleting a key that does not exist is not an error.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, Info, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Info describes a stored blob
+type Info struct {
+	ContentType string
+	Size        int64
+}
+
+// validateKey checks that a key cannot escape the root of a store
+func validateKey(key string) error {
+	if !fs.ValidPath(key) || key == "." || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is enough of a PNG file for its content type to be sniffed
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// testBlobStore runs the behaviour every BlobStore must have against a store
+func testBlobStore(t *testing.T, store storage.BlobStore) {
+	ctx := context.Background()
+
+	t.Run("Round trip", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "images/a/original", "image/png", pngHeader))
+
+		blob, info, err := store.Get(ctx, "images/a/original")
+		require.NoError(t, err)
+		defer blob.Close()
+
+		data, err := io.ReadAll(blob)
+		require.NoError(t, err)
+		assert.Equal(t, pngHeader, data)
+		assert.Equal(t, storage.Info{ContentType: "image/png", Size: int64(len(pngHeader))}, info)
+	})
+
+	t.Run("Put replaces the blob", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "images/b/original", "image/png", pngHeader))
+		require.NoError(t, store.Put(ctx, "images/b/original", "image/png", append(pngHeader, 0)))
+
+		_, info, err := store.Get(ctx, "images/b/original")
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(pngHeader)+1), info.Size)
+	})
+
+	t.Run("Missing blob", func(t *testing.T) {
+		_, _, err := store.Get(ctx, "images/missing/original")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Put(ctx, "images/c/original", "image/png", pngHeader))
+		require.NoError(t, store.Delete(ctx, "images/c/original"))
+
+		_, _, err := store.Get(ctx, "images/c/original")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		assert.NoError(t, store.Delete(ctx, "images/c/original"), "deleting a missing blob is not an error")
+	})
+
+	t.Run("Keys cannot escape the store", func(t *testing.T) {
+		for _, key := range []string{"", ".", "../secret", "images/../../secret", "/etc/passwd", `images\a`, "images//a"} {
+			assert.ErrorIs(t, store.Put(ctx, key, "image/png", pngHeader), storage.ErrInvalidKey, key)
+			_, _, err := store.Get(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		}
+	})
+}
+
+func TestLocalStore(t *testing.T) {
+	store, err := storage.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	testBlobStore(t, store)
+}
+
+func TestURLSigner(t *testing.T) {
+	signer, err := storage.NewURLSigner("secret", "https://api.example.com/v1/", time.Hour)
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	u := signer.Sign("/images/a", now)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "api.example.com", u.Host)
+	assert.Equal(t, "/v1/images/a", u.Path)
+	assert.Equal(t, strconv.FormatInt(now.Add(time.Hour).Unix(), 10), u.Query().Get(storage.ExpiresParam))
+
+	query := u.Query()
+	assert.True(t, signer.Verify("/images/a", query, now))
+	assert.True(t, signer.Verify("/images/a", query, now.Add(59*time.Minute)))
+	assert.False(t, signer.Verify("/images/a", query, now.Add(time.Hour)), "a signature expires")
+	assert.False(t, signer.Verify("/images/b", query, now), "a signature only covers its own path")
+	assert.False(t, signer.Verify("/images/a", url.Values{}, now))
+
+	extended := u.Query()
+	extended.Set(storage.ExpiresParam, strconv.FormatInt(now.Add(48*time.Hour).Unix(), 10))
+	assert.False(t, signer.Verify("/images/a", extended, now), "a signature covers its expiry")
+
+	other, _ := storage.NewURLSigner("other", "https://api.example.com/v1", time.Hour)
+	assert.False(t, other.Verify("/images/a", query, now))
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/google/uuid"
+)
+
+// AnalyticsRepository implements analytics.AnalyticsRepository interface using PostgreSQL
+type AnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewAnalyticsRepository creates a new repository with the provided database connection
+func NewAnalyticsRepository(db *sql.DB) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+// PostgresExerciseWeek represents a row of the weekly working sets of an exercise
+type PostgresExerciseWeek struct {
+	WeekStart  time.Time
+	ExerciseID uuid.UUID
+	Sets       int
+	Tonnage    float64
+	Muscles    []PostgresAnalyticsMuscle
+}
+
+// PostgresAnalyticsMuscle represents a target muscle of an exercise as aggregated to JSON
+type PostgresAnalyticsMuscle struct {
+	ID         uuid.UUID           `json:"id"`
+	Name       string              `json:"name"`
+	Role       exercise.MuscleRole `json:"role"`
+	Activation *float64            `json:"activation"`
+}
+
+// ExerciseWeeks returns the working sets of each exercise per ISO week for sessions started within the range.
+// Weeks are computed in UTC and tonnage is converted to kg.
+func (r *AnalyticsRepository) ExerciseWeeks(ctx context.Context, userID uuid.UUID, rg analytics.Range) ([]analytics.ExerciseWeek, error) {
+	query := `
+		SELECT date_trunc('week', w.started_at AT TIME ZONE 'UTC') AS week_start, e.exercise_id,
+			COUNT(*),
+			COALESCE(SUM(st.reps * st.weight * CASE WHEN st.unit = 'lb' THEN $4::numeric ELSE 1 END), 0),
+			COALESCE((
+				SELECT json_agg(json_build_object('id', m.id, 'name', m.muscle_name, 'role', tm.role, 'activation', tm.activation) ORDER BY tm.position)
+				FROM exercise_target_muscles tm
+				JOIN target_muscles m ON m.id = tm.muscle_id
+				WHERE tm.exercise_id = e.exercise_id
+			), '[]')
+		FROM workout_sets st
+		JOIN workout_entries e ON e.id = st.entry_id
+		JOIN workout_sessions w ON w.id = e.session_id
+		WHERE w.user_id = $1 AND w.started_at >= $2 AND w.started_at < $3 AND NOT st.warmup
+		GROUP BY week_start, e.exercise_id
+		ORDER BY week_start, e.exercise_id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, rg.From, rg.End(), workout.UnitLb.ToKg(1))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	weeks := []analytics.ExerciseWeek{}
+
+	for rows.Next() {
+		var pw PostgresExerciseWeek
+		var muscles []byte
+
+		if err := rows.Scan(&pw.WeekStart, &pw.ExerciseID, &pw.Sets, &pw.Tonnage, &muscles); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(muscles, &pw.Muscles); err != nil {
+			return nil, err
+		}
+
+		weeks = append(weeks, PostgresExerciseWeekToExerciseWeek(pw))
+	}
+
+	return weeks, rows.Err()
+}
+
+// PostgresExerciseWeekToExerciseWeek converts a database model to a domain model
+func PostgresExerciseWeekToExerciseWeek(pw PostgresExerciseWeek) analytics.ExerciseWeek {
+	muscles := make([]analytics.TargetMuscle, 0, len(pw.Muscles))
+	for _, m := range pw.Muscles {
+		link := exercise.TargetMuscle{MuscleID: m.ID, Role: m.Role, Activation: m.Activation}
+		muscles = append(muscles, analytics.TargetMuscle{
+			Muscle:   analytics.Muscle{ID: m.ID, Name: m.Name},
+			Fraction: link.Fraction(),
+		})
+	}
+
+	return analytics.ExerciseWeek{
+		Week:       analytics.WeekOf(pw.WeekStart),
+		ExerciseID: pw.ExerciseID,
+		Muscles:    muscles,
+		Sets:       pw.Sets,
+		Tonnage:    pw.Tonnage,
+	}
+}
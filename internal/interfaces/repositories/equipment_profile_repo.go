@@ -0,0 +1,288 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrDuplicateProfileName is returned when attempting to store an equipment profile with a name the user already has
+	ErrDuplicateProfileName = errors.New("an equipment profile with that name already exists")
+)
+
+// EquipmentProfileRepository implements equipment.ProfileRepository interface using PostgreSQL
+type EquipmentProfileRepository struct {
+	db *sql.DB
+}
+
+// NewEquipmentProfileRepository creates a new repository with the provided database connection
+func NewEquipmentProfileRepository(db *sql.DB) *EquipmentProfileRepository {
+	return &EquipmentProfileRepository{db: db}
+}
+
+// PostgresEquipmentProfile represents the database structure for storing equipment profiles
+type PostgresEquipmentProfile struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Name         string
+	EquipmentIDs pq.StringArray
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// selectEquipmentProfileQuery loads equipment profiles together with their ordered equipment.
+// A single statement sees one snapshot, so an aggregate is never read half updated.
+const selectEquipmentProfileQuery = `
+	SELECT p.id, p.user_id, p.profile_name,
+		ARRAY(SELECT equipment_id FROM equipment_profile_items WHERE profile_id = p.id ORDER BY position),
+		p.created_at, p.updated_at
+	FROM equipment_profiles p
+`
+
+// Add persists a new equipment profile with its equipment for a specific user in a single transaction
+// Returns ErrDuplicateProfileName if the user already has a profile with the same name
+func (r *EquipmentProfileRepository) Add(ctx context.Context, userID uuid.UUID, p *equipment.Profile) error {
+	query := `
+		INSERT INTO equipment_profiles (id, user_id, profile_name, created_at, updated_at)
+		VALUES($1, $2, $3, $4, $5)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, p.GetID(), userID, p.GetName(), p.GetCreatedAt(), p.GetUpdatedAt()); err != nil {
+			return equipmentProfileError(err)
+		}
+		return insertEquipmentProfileItems(ctx, tx, p)
+	})
+}
+
+// GetByID retrieves an equipment profile by ID for a specific user
+// Returns ErrNotFound if the profile doesn't exist for that user
+func (r *EquipmentProfileRepository) GetByID(ctx context.Context, userID, profileID uuid.UUID) (*equipment.Profile, error) {
+	return r.get(ctx, selectEquipmentProfileQuery+`WHERE p.user_id = $1 AND p.id = $2`, userID, profileID)
+}
+
+// GetByName retrieves an equipment profile by its name, ignoring case, for a specific user
+// Returns ErrNotFound if the user has no profile with that name
+func (r *EquipmentProfileRepository) GetByName(ctx context.Context, userID uuid.UUID, name string) (*equipment.Profile, error) {
+	return r.get(ctx, selectEquipmentProfileQuery+`WHERE p.user_id = $1 AND lower(p.profile_name) = lower($2)`, userID, name)
+}
+
+// get retrieves the single equipment profile selected by query
+func (r *EquipmentProfileRepository) get(ctx context.Context, query string, args ...any) (*equipment.Profile, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	pp, err := scanEquipmentProfile(r.db.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresEquipmentProfileToProfile(pp)
+}
+
+// equipmentProfileListSpec describes how equipment profiles are paginated, sorted and filtered
+var equipmentProfileListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "p.profile_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "p.created_at", Type: "timestamptz"},
+	},
+	ID:   "p.id",
+	Name: "p.profile_name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (pp PostgresEquipmentProfile) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pp.CreatedAt
+	}
+	return pp.Name
+}
+
+// List retrieves a page of the equipment profiles belonging to a specific user
+func (r *EquipmentProfileRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Profile], error) {
+	query := selectEquipmentProfileQuery + `WHERE p.user_id = $1`
+
+	query, args, err := equipmentProfileListSpec.Build(q, query, userID)
+	if err != nil {
+		return listquery.Page[*equipment.Profile]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*equipment.Profile]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*equipment.Profile](q)
+
+	for rows.Next() {
+		pp, err := scanEquipmentProfile(rows)
+		if err != nil {
+			return listquery.Page[*equipment.Profile]{}, err
+		}
+
+		p, err := PostgresEquipmentProfileToProfile(pp)
+		if err != nil {
+			return listquery.Page[*equipment.Profile]{}, err
+		}
+
+		page.Add(p, pp.ID, pp.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Update overwrites an equipment profile and replaces its equipment for a specific user in a single transaction
+// Returns ErrNotFound if the profile doesn't exist and ErrDuplicateProfileName if the name is taken
+func (r *EquipmentProfileRepository) Update(ctx context.Context, userID uuid.UUID, p *equipment.Profile) error {
+	query := `
+		UPDATE equipment_profiles SET profile_name = $3, updated_at = $4
+		WHERE user_id = $1 AND id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, query, userID, p.GetID(), p.GetName(), p.GetUpdatedAt())
+		if err != nil {
+			return equipmentProfileError(err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return ErrNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM equipment_profile_items WHERE profile_id = $1`, p.GetID()); err != nil {
+			return err
+		}
+
+		return insertEquipmentProfileItems(ctx, tx, p)
+	})
+}
+
+// Delete removes an equipment profile by ID for a specific user, the equipment itself is kept
+// Returns ErrNotFound if the profile doesn't exist
+func (r *EquipmentProfileRepository) Delete(ctx context.Context, userID, profileID uuid.UUID) error {
+	query := `
+		DELETE FROM equipment_profiles
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, profileID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Inventory returns the equipment that is in any equipment profile of a specific user
+func (r *EquipmentProfileRepository) Inventory(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+		SELECT DISTINCT i.equipment_id
+		FROM equipment_profile_items i
+		JOIN equipment_profiles p ON p.id = i.profile_id
+		WHERE p.user_id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// insertEquipmentProfileItems writes the ordered equipment of a profile
+func insertEquipmentProfileItems(ctx context.Context, tx *sql.Tx, p *equipment.Profile) error {
+	query := `INSERT INTO equipment_profile_items (profile_id, equipment_id, position) VALUES($1, $2, $3)`
+
+	for i, id := range p.GetEquipmentIDs() {
+		if _, err := tx.ExecContext(ctx, query, p.GetID(), id, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanEquipmentProfile reads a row produced by selectEquipmentProfileQuery
+func scanEquipmentProfile(row rowScanner) (PostgresEquipmentProfile, error) {
+	var pp PostgresEquipmentProfile
+	err := row.Scan(&pp.ID, &pp.UserID, &pp.Name, &pp.EquipmentIDs, &pp.CreatedAt, &pp.UpdatedAt)
+	return pp, err
+}
+
+// equipmentProfileError translates unique violations on the per-user name index to ErrDuplicateProfileName
+func equipmentProfileError(err error) error {
+	if isUniqueViolation(err, "equipment_profiles_user_id_profile_name_key") {
+		return ErrDuplicateProfileName
+	}
+	return err
+}
+
+// PostgresEquipmentProfileToProfile converts a database model to a domain model
+func PostgresEquipmentProfileToProfile(pp PostgresEquipmentProfile) (*equipment.Profile, error) {
+	equipmentIDs, err := parseUUIDs(pp.EquipmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return equipment.NewProfile(equipment.ProfileParams{
+		ID:           pp.ID,
+		Name:         pp.Name,
+		EquipmentIDs: equipmentIDs,
+		CreatedAt:    pp.CreatedAt,
+		UpdatedAt:    pp.UpdatedAt,
+	})
+}
@@ -0,0 +1,226 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDuplicateEquipmentName is returned when attempting to store equipment with a name the user already has
+	ErrDuplicateEquipmentName = errors.New("equipment with that name already exists")
+)
+
+// EquipmentRepository implements equipment.EquipmentRepository interface using PostgreSQL
+type EquipmentRepository struct {
+	db *sql.DB
+}
+
+// NewEquipmentRepository creates a new repository with the provided database connection
+func NewEquipmentRepository(db *sql.DB) *EquipmentRepository {
+	return &EquipmentRepository{db: db}
+}
+
+// PostgresEquipment represents the database structure for storing equipment
+type PostgresEquipment struct {
+	ID        uuid.UUID
+	Name      string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+// Add persists new equipment to the database for a specific user
+// Returns ErrDuplicateEquipmentName if the user already has equipment with the same name
+func (r *EquipmentRepository) Add(ctx context.Context, userID uuid.UUID, e *equipment.Equipment) error {
+	query := `
+		INSERT INTO equipment (id, equipment_name, user_id, created_at)
+		VALUES($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		e.GetId(),
+		e.GetName(),
+		userID,
+		time.Now(),
+	)
+	if err != nil {
+		return equipmentError(err)
+	}
+	return nil
+}
+
+// GetByID retrieves equipment by its ID for a specific user
+// Returns ErrNotFound if the equipment doesn't exist for that user
+func (r *EquipmentRepository) GetByID(ctx context.Context, userID, equipmentID uuid.UUID) (*equipment.Equipment, error) {
+	query := `
+		SELECT id, equipment_name, user_id, created_at FROM equipment
+		WHERE user_id = $1 AND id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var pe PostgresEquipment
+
+	err := r.db.QueryRowContext(ctx,
+		query,
+		userID,
+		equipmentID,
+	).Scan(
+		&pe.ID,
+		&pe.Name,
+		&pe.UserID,
+		&pe.CreatedAt,
+	)
+
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresEquipmentToEquipment(pe)
+}
+
+// equipmentListSpec describes how equipment is paginated, sorted and filtered
+var equipmentListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "e.equipment_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "e.created_at", Type: "timestamptz"},
+	},
+	ID:   "e.id",
+	Name: "e.equipment_name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (pe PostgresEquipment) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pe.CreatedAt
+	}
+	return pe.Name
+}
+
+// List retrieves a page of the equipment belonging to a specific user
+func (r *EquipmentRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*equipment.Equipment], error) {
+	query := `
+		SELECT e.id, e.equipment_name, e.user_id, e.created_at FROM equipment e
+		WHERE e.user_id = $1
+	`
+
+	query, args, err := equipmentListSpec.Build(q, query, userID)
+	if err != nil {
+		return listquery.Page[*equipment.Equipment]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*equipment.Equipment]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*equipment.Equipment](q)
+
+	for rows.Next() {
+		var pe PostgresEquipment
+		err := rows.Scan(&pe.ID, &pe.Name, &pe.UserID, &pe.CreatedAt)
+		if err != nil {
+			return listquery.Page[*equipment.Equipment]{}, err
+		}
+
+		e, err := PostgresEquipmentToEquipment(pe)
+		if err != nil {
+			return listquery.Page[*equipment.Equipment]{}, err
+		}
+
+		page.Add(e, pe.ID, pe.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Update renames equipment for a specific user
+// Returns ErrNotFound if the equipment doesn't exist and ErrDuplicateEquipmentName if the name is taken
+func (r *EquipmentRepository) Update(ctx context.Context, userID uuid.UUID, e *equipment.Equipment) error {
+	query := `
+		UPDATE equipment SET equipment_name = $3
+		WHERE user_id = $1 AND id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, e.GetId(), e.GetName())
+	if err != nil {
+		return equipmentError(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Delete removes equipment by its ID for a specific user
+// Returns ErrNotFound if the equipment doesn't exist
+func (r *EquipmentRepository) Delete(ctx context.Context, userID, equipmentID uuid.UUID) error {
+	query := `
+		DELETE FROM equipment
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, equipmentID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// equipmentError translates unique violations on the per-user name index to ErrDuplicateEquipmentName
+func equipmentError(err error) error {
+	if isUniqueViolation(err, "equipment_user_id_equipment_name_key") {
+		return ErrDuplicateEquipmentName
+	}
+	return err
+}
+
+// PostgresEquipmentToEquipment converts a database model to a domain model
+func PostgresEquipmentToEquipment(pe PostgresEquipment) (*equipment.Equipment, error) {
+	return equipment.NewEquipment(equipment.EquipmentParams{
+		ID:   pe.ID,
+		Name: pe.Name,
+	})
+}
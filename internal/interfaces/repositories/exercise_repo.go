@@ -0,0 +1,552 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrExerciseInUse is returned when deleting an exercise that is logged in a workout
+	ErrExerciseInUse = errors.New("exercise is logged in a workout")
+)
+
+// ExerciseRepository implements exercise.ExerciseRepository interface using PostgreSQL
+type ExerciseRepository struct {
+	db *sql.DB
+}
+
+// NewExerciseRepository creates a new repository with the provided database connection
+func NewExerciseRepository(db *sql.DB) *ExerciseRepository {
+	return &ExerciseRepository{db: db}
+}
+
+// PostgresExercise represents the database structure for storing exercises
+type PostgresExercise struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Name            string
+	Description     string
+	Aliases         pq.StringArray
+	ImageID         uuid.NullUUID
+	Category        string
+	MovementPattern string
+	SplitIDs        pq.StringArray
+	TargetMuscles   []PostgresTargetMuscle
+	EquipmentIDs    pq.StringArray
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// PostgresTargetMuscle represents the database structure for storing the target muscle links of an exercise
+type PostgresTargetMuscle struct {
+	MuscleID   uuid.UUID `json:"muscle_id"`
+	Role       string    `json:"role"`
+	Activation *float64  `json:"activation"`
+}
+
+// exerciseLinkTables maps every join table of the aggregate to its foreign key column.
+// Target muscle links carry attributes and are written by insertExerciseLinks itself.
+var exerciseLinkTables = []struct {
+	table  string
+	column string
+	ids    func(*exercise.Exercise) []uuid.UUID
+}{
+	{"exercise_equipment", "equipment_id", (*exercise.Exercise).GetEquipments},
+	{"exercise_splits", "split_id", (*exercise.Exercise).GetSplits},
+}
+
+// Add persists a new exercise and all of its links for a specific user in a single transaction
+func (r *ExerciseRepository) Add(ctx context.Context, userID uuid.UUID, e *exercise.Exercise) error {
+	query := `
+		INSERT INTO exercises (id, user_id, name, description, image_id, category, created_at, updated_at, aliases, movement_pattern)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx,
+			query,
+			e.GetID(),
+			userID,
+			e.GetName(),
+			e.GetDescription(),
+			nullableUUID(e.GetImageID()),
+			e.GetCategory(),
+			e.GetCreatedAt(),
+			e.GetUpdatedAt(),
+			pq.StringArray(e.GetAliases()),
+			string(e.GetMovementPattern()),
+		)
+		if err != nil {
+			return err
+		}
+
+		return insertExerciseLinks(ctx, tx, e)
+	})
+}
+
+// exerciseColumns lists the columns scanned by scanExercise, the exercise must be aliased e.
+// A single statement sees one snapshot, so an aggregate is never read half updated.
+const exerciseColumns = `
+	e.id, e.user_id, e.name, e.description, e.aliases, e.image_id, e.category, e.movement_pattern,
+		ARRAY(SELECT split_id FROM exercise_splits WHERE exercise_id = e.id ORDER BY position),
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'muscle_id', tm.muscle_id,
+				'role', tm.role,
+				'activation', tm.activation
+			) ORDER BY tm.position)
+			FROM exercise_target_muscles tm WHERE tm.exercise_id = e.id
+		), '[]'),
+		ARRAY(SELECT equipment_id FROM exercise_equipment WHERE exercise_id = e.id ORDER BY position),
+		e.created_at, e.updated_at
+`
+
+// selectExerciseQuery loads exercises together with their links
+const selectExerciseQuery = `SELECT ` + exerciseColumns + ` FROM exercises e `
+
+// GetByID retrieves an exercise and its links by ID for a specific user
+// Returns ErrNotFound if the exercise doesn't exist for that user
+func (r *ExerciseRepository) GetByID(ctx context.Context, userID, exerciseID uuid.UUID) (*exercise.Exercise, error) {
+	query := selectExerciseQuery + `WHERE e.user_id = $1 AND e.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	pe, err := scanExercise(r.db.QueryRowContext(ctx, query, userID, exerciseID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresExerciseToExercise(pe)
+}
+
+// exerciseListSpec describes how exercises are paginated, sorted and filtered
+var exerciseListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "e.name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "e.created_at", Type: "timestamptz"},
+	},
+	ID:   "e.id",
+	Name: "e.name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (pe PostgresExercise) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pe.CreatedAt
+	}
+	return pe.Name
+}
+
+// List retrieves a page of the exercises and their links belonging to a specific user
+func (r *ExerciseRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query, filter exercise.ListFilter) (listquery.Page[*exercise.Exercise], error) {
+	query := selectExerciseQuery + `WHERE e.user_id = $1`
+	args := []any{userID}
+
+	if filter.AvailableWith != nil {
+		args = append(args, uuidArray(filter.AvailableWith))
+		query += ` AND NOT EXISTS (
+			SELECT 1 FROM exercise_equipment ee
+			WHERE ee.exercise_id = e.id AND NOT ee.equipment_id = ANY($2)
+		)`
+	}
+
+	query, args, err := exerciseListSpec.Build(q, query, args...)
+	if err != nil {
+		return listquery.Page[*exercise.Exercise]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*exercise.Exercise]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*exercise.Exercise](q)
+
+	for rows.Next() {
+		pe, err := scanExercise(rows)
+		if err != nil {
+			return listquery.Page[*exercise.Exercise]{}, err
+		}
+
+		e, err := PostgresExerciseToExercise(pe)
+		if err != nil {
+			return listquery.Page[*exercise.Exercise]{}, err
+		}
+
+		page.Add(e, pe.ID, pe.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// headlineStart and headlineStop surround the matched words in the headlines Postgres returns.
+// They are private use characters removed from the text before it is highlighted, so every one of them
+// comes from ts_headline and they can be told apart from the text once it is escaped.
+const (
+	headlineStart = "\uE000"
+	headlineStop  = "\uE001"
+)
+
+// highlightReplacer replaces the headline selectors with the markup of highlights
+var highlightReplacer = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+// highlight escapes a headline for HTML and wraps its matched words in <mark> and </mark>
+func highlight(headline string) string {
+	return highlightReplacer.Replace(html.EscapeString(headline))
+}
+
+// searchExercisesQuery ranks the exercises of a user against the search text.
+// The words of the text are matched with OR, so that an abbreviation the exercise is not known by
+// only lowers the rank, and names and aliases are also matched by trigram to tolerate typos.
+// Highlights are only computed for the page of results that is returned.
+const searchExercisesQuery = `
+	WITH q AS (
+		SELECT replace(plainto_tsquery('english', $2)::text, ' & ', ' | ')::tsquery AS tsq, lower($2) AS text
+	), hits AS (
+		SELECT e.id, ts_rank_cd(e.search_vector, q.tsq) + word_similarity(q.text, e.search_text) AS rank
+		FROM exercises e, q
+		WHERE e.user_id = $1
+			AND (e.search_vector @@ q.tsq OR q.text <% e.search_text)
+			AND (cardinality($3::uuid[]) = 0 OR EXISTS (
+				SELECT 1 FROM exercise_target_muscles WHERE exercise_id = e.id AND muscle_id = ANY($3)))
+			AND (cardinality($4::uuid[]) = 0 OR EXISTS (
+				SELECT 1 FROM exercise_equipment WHERE exercise_id = e.id AND equipment_id = ANY($4)))
+			AND (cardinality($5::uuid[]) = 0 OR EXISTS (
+				SELECT 1 FROM exercise_splits WHERE exercise_id = e.id AND split_id = ANY($5)))
+			AND (cardinality($6::text[]) = 0 OR lower(e.category) = ANY($6))
+		ORDER BY rank DESC, e.name, e.id
+		LIMIT $7
+	)
+	SELECT ` + exerciseColumns + `, h.rank,
+		ts_headline('english', translate(e.name, $10, ''), q.tsq, $8),
+		CASE WHEN to_tsvector('english', e.description) @@ q.tsq
+			THEN ts_headline('english', translate(e.description, $10, ''), q.tsq, $9) ELSE '' END,
+		ARRAY(
+			SELECT ts_headline('english', translate(a, $10, ''), q.tsq, $8)
+			FROM unnest(e.aliases) a
+			WHERE to_tsvector('english', a) @@ q.tsq
+		)
+	FROM hits h
+	JOIN exercises e ON e.id = h.id
+	CROSS JOIN q
+	ORDER BY h.rank DESC, e.name, e.id
+`
+
+// Options of ts_headline, names and aliases are short so they are highlighted whole
+const (
+	headlineWholeOptions    = `HighlightAll=true, StartSel="` + headlineStart + `", StopSel="` + headlineStop + `"`
+	headlineFragmentOptions = `MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=" … ", StartSel="` + headlineStart + `", StopSel="` + headlineStop + `"`
+)
+
+// Search returns the exercises of a user that match the query, the most relevant first
+func (r *ExerciseRepository) Search(ctx context.Context, userID uuid.UUID, q exercise.SearchQuery) ([]exercise.SearchResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, searchExercisesQuery,
+		userID,
+		q.Text,
+		uuidArray(q.MuscleIDs),
+		uuidArray(q.EquipmentIDs),
+		uuidArray(q.SplitIDs),
+		pq.StringArray(append([]string{}, q.Categories...)),
+		q.Limit,
+		headlineWholeOptions,
+		headlineFragmentOptions,
+		headlineStart+headlineStop,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	results := []exercise.SearchResult{}
+
+	for rows.Next() {
+		var result exercise.SearchResult
+		var aliases pq.StringArray
+
+		pe, err := scanExercise(rows, &result.Rank, &result.Highlights.Name, &result.Highlights.Description, &aliases)
+		if err != nil {
+			return nil, err
+		}
+		result.Highlights.Name = highlight(result.Highlights.Name)
+		result.Highlights.Description = highlight(result.Highlights.Description)
+
+		result.Exercise, err = PostgresExerciseToExercise(pe)
+		if err != nil {
+			return nil, err
+		}
+		result.Highlights.Aliases = make([]string, 0, len(aliases))
+		for _, a := range aliases {
+			result.Highlights.Aliases = append(result.Highlights.Aliases, highlight(a))
+		}
+
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
+
+// ListRelated retrieves the exercises of a user, other than the one in the filter, that are linked to any of
+// the muscles of the filter or follow its movement pattern
+func (r *ExerciseRepository) ListRelated(ctx context.Context, userID uuid.UUID, filter exercise.RelatedFilter) ([]*exercise.Exercise, error) {
+	query := selectExerciseQuery + `
+		WHERE e.user_id = $1 AND e.id <> $2
+			AND (
+				EXISTS (SELECT 1 FROM exercise_target_muscles WHERE exercise_id = e.id AND muscle_id = ANY($3))
+				OR ($4::text <> '' AND e.movement_pattern = $4)
+			)
+		ORDER BY e.name, e.id
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, filter.ExerciseID, uuidArray(filter.MuscleIDs), string(filter.MovementPattern))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	exercises := []*exercise.Exercise{}
+
+	for rows.Next() {
+		pe, err := scanExercise(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := PostgresExerciseToExercise(pe)
+		if err != nil {
+			return nil, err
+		}
+
+		exercises = append(exercises, e)
+	}
+
+	return exercises, rows.Err()
+}
+
+// Update overwrites an exercise and replaces all of its links for a specific user in a single transaction
+// Returns ErrNotFound if the exercise doesn't exist for that user
+func (r *ExerciseRepository) Update(ctx context.Context, userID uuid.UUID, e *exercise.Exercise) error {
+	query := `
+		UPDATE exercises
+		SET name = $3, description = $4, image_id = $5, category = $6, updated_at = $7, aliases = $8, movement_pattern = $9
+		WHERE user_id = $1 AND id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			query,
+			userID,
+			e.GetID(),
+			e.GetName(),
+			e.GetDescription(),
+			nullableUUID(e.GetImageID()),
+			e.GetCategory(),
+			e.GetUpdatedAt(),
+			pq.StringArray(e.GetAliases()),
+			string(e.GetMovementPattern()),
+		)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return ErrNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM exercise_target_muscles WHERE exercise_id = $1`, e.GetID()); err != nil {
+			return err
+		}
+		for _, link := range exerciseLinkTables {
+			if _, err := tx.ExecContext(ctx, `DELETE FROM `+link.table+` WHERE exercise_id = $1`, e.GetID()); err != nil {
+				return err
+			}
+		}
+
+		return insertExerciseLinks(ctx, tx, e)
+	})
+}
+
+// Delete removes an exercise and its links by ID for a specific user
+// Returns ErrNotFound if the exercise doesn't exist and ErrExerciseInUse if a workout refers to it
+func (r *ExerciseRepository) Delete(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	query := `
+		DELETE FROM exercises
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, exerciseID)
+	if err != nil {
+		if isForeignKeyViolation(err, "fk_workout_entries_exercise") {
+			return ErrExerciseInUse
+		}
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanExercise reads a row that starts with exerciseColumns, the columns that follow are scanned into extra
+func scanExercise(row rowScanner, extra ...any) (PostgresExercise, error) {
+	var pe PostgresExercise
+	var targetMuscles []byte
+	dest := []any{
+		&pe.ID,
+		&pe.UserID,
+		&pe.Name,
+		&pe.Description,
+		&pe.Aliases,
+		&pe.ImageID,
+		&pe.Category,
+		&pe.MovementPattern,
+		&pe.SplitIDs,
+		&targetMuscles,
+		&pe.EquipmentIDs,
+		&pe.CreatedAt,
+		&pe.UpdatedAt,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return pe, err
+	}
+
+	err = json.Unmarshal(targetMuscles, &pe.TargetMuscles)
+	return pe, err
+}
+
+// insertExerciseLinks writes the split, target muscle and equipment links of an exercise
+func insertExerciseLinks(ctx context.Context, tx *sql.Tx, e *exercise.Exercise) error {
+	for _, link := range exerciseLinkTables {
+		query := `INSERT INTO ` + link.table + ` (exercise_id, ` + link.column + `, position) VALUES($1, $2, $3)`
+
+		for i, id := range link.ids(e) {
+			if _, err := tx.ExecContext(ctx, query, e.GetID(), id, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	query := `
+		INSERT INTO exercise_target_muscles (exercise_id, muscle_id, position, role, activation)
+		VALUES($1, $2, $3, $4, $5)
+	`
+	for i, t := range e.GetTargetMuscles() {
+		if _, err := tx.ExecContext(ctx, query, e.GetID(), t.MuscleID, i, string(t.Role), t.Activation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PostgresExerciseToExercise converts a database model to a domain model
+func PostgresExerciseToExercise(pe PostgresExercise) (*exercise.Exercise, error) {
+	splitIDs, err := parseUUIDs(pe.SplitIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	targetMuscles := make([]exercise.TargetMuscle, 0, len(pe.TargetMuscles))
+	for _, t := range pe.TargetMuscles {
+		targetMuscles = append(targetMuscles, exercise.TargetMuscle{
+			MuscleID:   t.MuscleID,
+			Role:       exercise.MuscleRole(t.Role),
+			Activation: t.Activation,
+		})
+	}
+
+	equipmentIDs, err := parseUUIDs(pe.EquipmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return exercise.NewExercise(exercise.ExerciseParams{
+		ID:              pe.ID,
+		Name:            pe.Name,
+		Description:     pe.Description,
+		Aliases:         pe.Aliases,
+		ImageID:         pe.ImageID.UUID,
+		SplitIDs:        splitIDs,
+		TargetMuscles:   targetMuscles,
+		EquipmentIDs:    equipmentIDs,
+		Category:        pe.Category,
+		MovementPattern: exercise.MovementPattern(pe.MovementPattern),
+		CreatedAt:       pe.CreatedAt,
+		UpdatedAt:       pe.UpdatedAt,
+	})
+}
+
+// uuidArray converts UUIDs to a Postgres array, an empty one when there are none
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	values := make(pq.StringArray, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	return values
+}
+
+// parseUUIDs converts a postgres uuid array to a slice of UUIDs
+func parseUUIDs(values []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
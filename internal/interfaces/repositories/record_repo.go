@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/google/uuid"
+)
+
+// RecordRepository implements record.RecordRepository interface using PostgreSQL
+type RecordRepository struct {
+	db *sql.DB
+}
+
+// NewRecordRepository creates a new repository with the provided database connection
+func NewRecordRepository(db *sql.DB) *RecordRepository {
+	return &RecordRepository{db: db}
+}
+
+// PostgresRecord represents the database structure for storing personal records
+type PostgresRecord struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	ExerciseID uuid.UUID
+	SessionID  uuid.UUID
+	SetID      uuid.UUID
+	Kind       string
+	Value      float64
+	Weight     float64
+	AchievedAt time.Time
+}
+
+const selectRecordQuery = `
+	SELECT id, user_id, exercise_id, session_id, set_id, kind, value, weight, achieved_at
+	FROM personal_records
+`
+
+// Save inserts the records for a specific user in a single transaction,
+// replacing the stored records that have the same ID
+func (r *RecordRepository) Save(ctx context.Context, userID uuid.UUID, records []*record.Record) error {
+	query := `
+		INSERT INTO personal_records (id, user_id, exercise_id, session_id, set_id, kind, value, weight, achieved_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET set_id = EXCLUDED.set_id, value = EXCLUDED.value, achieved_at = EXCLUDED.achieved_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		for _, rec := range records {
+			if _, err := tx.ExecContext(ctx, query,
+				rec.GetID(), userID, rec.GetExerciseID(), rec.GetSessionID(), rec.GetSetID(),
+				string(rec.GetKind()), rec.GetValue(), rec.GetWeight(), rec.GetAchievedAt(),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListByExercise retrieves the records of an exercise for a specific user from the oldest to the newest
+func (r *RecordRepository) ListByExercise(ctx context.Context, userID, exerciseID uuid.UUID) ([]*record.Record, error) {
+	query := selectRecordQuery + `WHERE user_id = $1 AND exercise_id = $2 ORDER BY achieved_at, value`
+	return r.list(ctx, query, userID, exerciseID)
+}
+
+// ListBySession retrieves the records set during a workout session for a specific user
+func (r *RecordRepository) ListBySession(ctx context.Context, userID, sessionID uuid.UUID) ([]*record.Record, error) {
+	query := selectRecordQuery + `WHERE user_id = $1 AND session_id = $2 ORDER BY achieved_at, exercise_id, kind, weight`
+	return r.list(ctx, query, userID, sessionID)
+}
+
+// list runs a query selecting records and converts the rows to domain models
+func (r *RecordRepository) list(ctx context.Context, query string, args ...any) ([]*record.Record, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	records := []*record.Record{}
+
+	for rows.Next() {
+		var pr PostgresRecord
+		if err := rows.Scan(
+			&pr.ID, &pr.UserID, &pr.ExerciseID, &pr.SessionID, &pr.SetID,
+			&pr.Kind, &pr.Value, &pr.Weight, &pr.AchievedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		rec, err := PostgresRecordToRecord(pr)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+
+	return records, rows.Err()
+}
+
+// PostgresRecordToRecord converts a database model to a domain model
+func PostgresRecordToRecord(pr PostgresRecord) (*record.Record, error) {
+	return record.NewRecord(record.RecordParams{
+		ID:         pr.ID,
+		ExerciseID: pr.ExerciseID,
+		SessionID:  pr.SessionID,
+		SetID:      pr.SetID,
+		Kind:       record.Kind(pr.Kind),
+		Value:      pr.Value,
+		Weight:     pr.Weight,
+		AchievedAt: pr.AchievedAt,
+	})
+}
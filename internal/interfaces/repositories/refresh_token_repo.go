@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/google/uuid"
+)
+
+// RefreshTokenRepository implements session.SessionRepository interface using PostgreSQL
+type RefreshTokenRepository struct {
+	db *sql.DB
+}
+
+// NewRefreshTokenRepository creates a new repository with the provided database connection
+func NewRefreshTokenRepository(db *sql.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// PostgresRefreshToken represents the database structure for storing refresh tokens
+type PostgresRefreshToken struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	FamilyID   uuid.UUID
+	TokenHash  []byte
+	ExpiresAt  time.Time
+	ConsumedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  time.Time
+}
+
+// Add persists a new refresh token for a specific user
+func (r *RefreshTokenRepository) Add(ctx context.Context, userID uuid.UUID, token *session.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		token.ID(),
+		userID,
+		token.FamilyID(),
+		token.TokenHash(),
+		token.ExpiresAt(),
+		token.CreatedAt(),
+	)
+	return err
+}
+
+// GetByHash retrieves a refresh token by the hash of its plaintext value
+// Returns ErrNotFound if no token has that hash
+func (r *RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash []byte) (*session.RefreshToken, error) {
+	query := `
+		SELECT id, user_id, family_id, token_hash, expires_at, consumed_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var pt PostgresRefreshToken
+
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&pt.ID,
+		&pt.UserID,
+		&pt.FamilyID,
+		&pt.TokenHash,
+		&pt.ExpiresAt,
+		&pt.ConsumedAt,
+		&pt.RevokedAt,
+		&pt.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresRefreshTokenToRefreshToken(pt), nil
+}
+
+// Rotate marks an unconsumed and unrevoked refresh token as used and inserts its successor in one transaction
+// Returns session.ErrInvalidRefreshToken if the family was revoked and session.ErrRefreshTokenReused
+// if the token was consumed in the meantime
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, userID, tokenID uuid.UUID, next *session.RefreshToken) error {
+	consumeQuery := `
+		UPDATE refresh_tokens SET consumed_at = NOW()
+		WHERE user_id = $1 AND id = $2 AND consumed_at IS NULL AND revoked_at IS NULL
+	`
+	revokedQuery := `SELECT revoked_at IS NOT NULL FROM refresh_tokens WHERE user_id = $1 AND id = $2`
+	insertQuery := `
+		INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, consumeQuery, userID, tokenID)
+		if err != nil {
+			return err
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			var revoked bool
+			if err := tx.QueryRowContext(ctx, revokedQuery, userID, tokenID).Scan(&revoked); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return ErrNotFound
+				}
+				return err
+			}
+			if revoked {
+				return session.ErrInvalidRefreshToken
+			}
+			return session.ErrRefreshTokenReused
+		}
+
+		_, err = tx.ExecContext(ctx,
+			insertQuery,
+			next.ID(),
+			userID,
+			next.FamilyID(),
+			next.TokenHash(),
+			next.ExpiresAt(),
+			next.CreatedAt(),
+		)
+		return err
+	})
+}
+
+// RevokeFamily revokes every not yet revoked refresh token in a family for a specific user
+func (r *RefreshTokenRepository) RevokeFamily(ctx context.Context, userID, familyID uuid.UUID) error {
+	query := `
+		UPDATE refresh_tokens SET revoked_at = NOW()
+		WHERE user_id = $1 AND family_id = $2 AND revoked_at IS NULL
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, userID, familyID)
+	return err
+}
+
+// PostgresRefreshTokenToRefreshToken converts a database model to a domain model
+func PostgresRefreshTokenToRefreshToken(pt PostgresRefreshToken) *session.RefreshToken {
+	params := session.RefreshTokenParams{
+		ID:        pt.ID,
+		UserID:    pt.UserID,
+		FamilyID:  pt.FamilyID,
+		TokenHash: pt.TokenHash,
+		ExpiresAt: pt.ExpiresAt,
+		CreatedAt: pt.CreatedAt,
+	}
+	if pt.ConsumedAt.Valid {
+		params.ConsumedAt = &pt.ConsumedAt.Time
+	}
+	if pt.RevokedAt.Valid {
+		params.RevokedAt = &pt.RevokedAt.Time
+	}
+	return session.NewRefreshToken(params)
+}
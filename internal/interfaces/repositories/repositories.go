@@ -6,8 +6,17 @@ import (
 	"errors"
 	"time"
 
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
-	_ "github.com/lib/pq"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/lib/pq"
 )
 
 // Errors used by all repository implementations
@@ -20,6 +29,14 @@ var (
 	ErrConflict = errors.New("resource already exists")
 )
 
+// Postgres error codes translated to repository errors
+const (
+	// uniqueViolation is raised when a unique constraint or index is violated
+	uniqueViolation = pq.ErrorCode("23505")
+	// foreignKeyViolation is raised when a row is still referenced by another table
+	foreignKeyViolation = pq.ErrorCode("23503")
+)
+
 var (
 	// QueryTimeoutDuration defines the standard timeout for database operations
 	QueryTimeoutDuration = time.Second * 5
@@ -28,19 +45,85 @@ var (
 // Repositories provides access to all repository implementations
 // in a central location for dependecy injection
 type Repositories struct {
-	Muscles muscle.MuscleRepository
+	Muscles   muscle.MuscleRepository
+	Users     user.UserRepository
+	Sessions  session.SessionRepository
+	Exercises exercise.ExerciseRepository
+	Equipment equipment.EquipmentRepository
+	Splits    split.SplitRepository
+	Workouts  workout.WorkoutRepository
+	Routines  routine.RoutineRepository
+	Records   record.RecordRepository
+	Analytics analytics.AnalyticsRepository
+
+	EquipmentProfiles equipment.ProfileRepository
+
+	// Transactor runs units of work that span several of the repositories
+	Transactor *Transactor
 }
 
 // NewRepositories creates and initializes all repository implementations
 func NewRepositories(db *sql.DB) *Repositories {
 	return &Repositories{
-		Muscles: NewTargetMuscleRepository(db),
+		Muscles:   NewTargetMuscleRepository(db),
+		Users:     NewUserRepository(db),
+		Sessions:  NewRefreshTokenRepository(db),
+		Exercises: NewExerciseRepository(db),
+		Equipment: NewEquipmentRepository(db),
+		Splits:    NewSplitRepository(db),
+		Workouts:  NewWorkoutRepository(db),
+		Routines:  NewRoutineRepository(db),
+		Records:   NewRecordRepository(db),
+		Analytics: NewAnalyticsRepository(db),
+
+		EquipmentProfiles: NewEquipmentProfileRepository(db),
+
+		Transactor: NewTransactor(db),
+	}
+}
+
+// txKey is the context key of the transaction a Transactor runs a unit of work in
+type txKey struct{}
+
+// Transactor runs units of work that span several repositories in a single transaction
+type Transactor struct {
+	db *sql.DB
+}
+
+// NewTransactor creates a new transactor with the provided database connection
+func NewTransactor(db *sql.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+// WithinTx runs fn in a transaction that repositories called with the context passed to fn take part in.
+// The transaction is committed when fn succeeds and rolled back otherwise.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withTx(t.db, ctx, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// querier runs queries on the database or on a transaction
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// conn returns the transaction of the Transactor ctx runs in, or db outside of one
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
 	}
+	return db
 }
 
 // withTx executes the provided function within a database transaction
-// and handles commit/rollback automatically based on function result
+// and handles commit/rollback automatically based on function result.
+// Within a unit of work of a Transactor, fn joins its transaction instead.
 func withTx(db *sql.DB, ctx context.Context, fn func(*sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -53,3 +136,15 @@ func withTx(db *sql.DB, ctx context.Context, fn func(*sql.Tx) error) error {
 
 	return tx.Commit()
 }
+
+// isUniqueViolation reports whether err is a unique violation of the named constraint or index
+func isUniqueViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == constraint
+}
+
+// isForeignKeyViolation reports whether err is a foreign key violation of the named constraint
+func isForeignKeyViolation(err error, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation && pqErr.Constraint == constraint
+}
@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsUniqueViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Matching constraint",
+			err:      &pq.Error{Code: "23505", Constraint: "target_muscles_user_id_muscle_name_key"},
+			expected: true,
+		},
+		{
+			name:     "Wrapped matching constraint",
+			err:      fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Constraint: "target_muscles_user_id_muscle_name_key"}),
+			expected: true,
+		},
+		{
+			name:     "Other constraint",
+			err:      &pq.Error{Code: "23505", Constraint: "users_email_key"},
+			expected: false,
+		},
+		{
+			name:     "Foreign key violation",
+			err:      &pq.Error{Code: "23503", Constraint: "target_muscles_user_id_muscle_name_key"},
+			expected: false,
+		},
+		{
+			name:     "Not a postgres error",
+			err:      errors.New(`pq: duplicate key value violates unique constraint "target_muscles_user_id_muscle_name_key"`),
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isUniqueViolation(tc.err, "target_muscles_user_id_muscle_name_key"))
+		})
+	}
+}
+
+func TestIsForeignKeyViolation(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{
+			name:     "Matching constraint",
+			err:      &pq.Error{Code: "23503", Constraint: "fk_workout_entries_exercise"},
+			expected: true,
+		},
+		{
+			name:     "Other constraint",
+			err:      &pq.Error{Code: "23503", Constraint: "fk_workout_entries_session"},
+			expected: false,
+		},
+		{
+			name:     "Unique violation",
+			err:      &pq.Error{Code: "23505", Constraint: "fk_workout_entries_exercise"},
+			expected: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isForeignKeyViolation(tc.err, "fk_workout_entries_exercise"))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name     string
+		headline string
+		expected string
+	}{
+		{
+			name:     "Matched words are marked",
+			headline: headlineStart + "Bench" + headlineStop + " Press",
+			expected: "<mark>Bench</mark> Press",
+		},
+		{
+			name:     "Markup in the text is escaped",
+			headline: `<img src=x onerror="alert(1)"> ` + headlineStart + "Squat" + headlineStop,
+			expected: "&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>Squat</mark>",
+		},
+		{
+			name:     "Entities are escaped",
+			headline: "Curl &amp; " + headlineStart + "Press" + headlineStop,
+			expected: "Curl &amp;amp; <mark>Press</mark>",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, highlight(tc.headline))
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDuplicateRoutineName is returned when attempting to store a routine with a name the user already has
+	ErrDuplicateRoutineName = errors.New("a routine with that name already exists")
+)
+
+// RoutineRepository implements routine.RoutineRepository interface using PostgreSQL
+type RoutineRepository struct {
+	db *sql.DB
+}
+
+// NewRoutineRepository creates a new repository with the provided database connection
+func NewRoutineRepository(db *sql.DB) *RoutineRepository {
+	return &RoutineRepository{db: db}
+}
+
+// PostgresRoutine represents the database structure for storing routines
+type PostgresRoutine struct {
+	ID        uuid.UUID
+	Name      string
+	UserID    uuid.UUID
+	Exercises []PostgresRoutineExercise
+	CreatedAt time.Time
+}
+
+// PostgresRoutineExercise represents the database structure for storing the exercises of a routine
+type PostgresRoutineExercise struct {
+	ID         uuid.UUID `json:"id"`
+	ExerciseID uuid.UUID `json:"exercise_id"`
+	TargetSets int       `json:"target_sets"`
+	MinReps    int       `json:"min_reps"`
+	MaxReps    int       `json:"max_reps"`
+}
+
+// selectRoutineQuery loads routines together with their ordered exercises as JSON
+const selectRoutineQuery = `
+	SELECT r.id, r.routine_name, r.user_id, r.created_at,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', x.id,
+				'exercise_id', x.exercise_id,
+				'target_sets', x.target_sets,
+				'min_reps', x.min_reps,
+				'max_reps', x.max_reps
+			) ORDER BY x.position)
+			FROM routine_exercises x WHERE x.routine_id = r.id
+		), '[]')
+	FROM routines r
+`
+
+// Add persists a new routine with its exercises for a specific user in a single transaction
+// Returns ErrDuplicateRoutineName if the user already has a routine with the same name
+func (r *RoutineRepository) Add(ctx context.Context, userID uuid.UUID, rt *routine.Routine) error {
+	query := `
+		INSERT INTO routines (id, routine_name, user_id, created_at)
+		VALUES($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, rt.GetID(), rt.GetName(), userID, time.Now()); err != nil {
+			return routineError(err)
+		}
+		return insertRoutineExercises(ctx, tx, rt)
+	})
+}
+
+// GetByID retrieves a routine with its exercises by ID for a specific user
+// Returns ErrNotFound if the routine doesn't exist for that user
+func (r *RoutineRepository) GetByID(ctx context.Context, userID, routineID uuid.UUID) (*routine.Routine, error) {
+	query := selectRoutineQuery + `WHERE r.user_id = $1 AND r.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	pr, err := scanRoutine(r.db.QueryRowContext(ctx, query, userID, routineID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresRoutineToRoutine(pr)
+}
+
+// routineListSpec describes how routines are paginated, sorted and filtered
+var routineListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "r.routine_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "r.created_at", Type: "timestamptz"},
+	},
+	ID:   "r.id",
+	Name: "r.routine_name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (pr PostgresRoutine) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pr.CreatedAt
+	}
+	return pr.Name
+}
+
+// List retrieves a page of the routines with their exercises belonging to a specific user
+func (r *RoutineRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*routine.Routine], error) {
+	query := selectRoutineQuery + `WHERE r.user_id = $1`
+
+	query, args, err := routineListSpec.Build(q, query, userID)
+	if err != nil {
+		return listquery.Page[*routine.Routine]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*routine.Routine]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*routine.Routine](q)
+
+	for rows.Next() {
+		pr, err := scanRoutine(rows)
+		if err != nil {
+			return listquery.Page[*routine.Routine]{}, err
+		}
+
+		rt, err := PostgresRoutineToRoutine(pr)
+		if err != nil {
+			return listquery.Page[*routine.Routine]{}, err
+		}
+
+		page.Add(rt, pr.ID, pr.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Modify loads a routine for a specific user, applies fn to it and saves the result in a single transaction.
+// The routine row stays locked until the transaction ends, so overlapping modifications of a routine run one
+// after the other and none of them overwrites the exercises another one changed.
+// Returns ErrNotFound if the routine doesn't exist for that user and ErrDuplicateRoutineName if the name is taken
+func (r *RoutineRepository) Modify(ctx context.Context, userID, routineID uuid.UUID, fn func(*routine.Routine) error) (*routine.Routine, error) {
+	lockQuery := `SELECT id FROM routines WHERE user_id = $1 AND id = $2 FOR UPDATE`
+	query := selectRoutineQuery + `WHERE r.user_id = $1 AND r.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var rt *routine.Routine
+	err := withTx(r.db, ctx, func(tx *sql.Tx) error {
+		var id uuid.UUID
+		if err := tx.QueryRowContext(ctx, lockQuery, userID, routineID).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		pr, err := scanRoutine(tx.QueryRowContext(ctx, query, userID, routineID))
+		if err != nil {
+			return err
+		}
+
+		rt, err = PostgresRoutineToRoutine(pr)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(rt); err != nil {
+			return err
+		}
+		return updateRoutine(ctx, tx, userID, rt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// updateRoutine overwrites a locked routine and replaces its exercises
+func updateRoutine(ctx context.Context, tx *sql.Tx, userID uuid.UUID, rt *routine.Routine) error {
+	query := `
+		UPDATE routines SET routine_name = $3
+		WHERE user_id = $1 AND id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, userID, rt.GetID(), rt.GetName()); err != nil {
+		return routineError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM routine_exercises WHERE routine_id = $1`, rt.GetID()); err != nil {
+		return err
+	}
+
+	return insertRoutineExercises(ctx, tx, rt)
+}
+
+// Delete removes a routine and its exercises by ID for a specific user
+// Returns ErrNotFound if the routine doesn't exist
+func (r *RoutineRepository) Delete(ctx context.Context, userID, routineID uuid.UUID) error {
+	query := `
+		DELETE FROM routines
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, routineID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// insertRoutineExercises writes the ordered exercises of a routine with their targets
+func insertRoutineExercises(ctx context.Context, tx *sql.Tx, rt *routine.Routine) error {
+	query := `
+		INSERT INTO routine_exercises (id, routine_id, exercise_id, position, target_sets, min_reps, max_reps)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	for i, e := range rt.GetExercises() {
+		t := e.GetTarget()
+		if _, err := tx.ExecContext(ctx, query, e.GetID(), rt.GetID(), e.GetExerciseID(), i, t.Sets, t.MinReps, t.MaxReps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanRoutine reads a row produced by selectRoutineQuery
+func scanRoutine(row rowScanner) (PostgresRoutine, error) {
+	var pr PostgresRoutine
+	var exercises []byte
+
+	if err := row.Scan(&pr.ID, &pr.Name, &pr.UserID, &pr.CreatedAt, &exercises); err != nil {
+		return pr, err
+	}
+
+	err := json.Unmarshal(exercises, &pr.Exercises)
+	return pr, err
+}
+
+// routineError translates unique violations on the per-user name index to ErrDuplicateRoutineName
+func routineError(err error) error {
+	if isUniqueViolation(err, "routines_user_id_routine_name_key") {
+		return ErrDuplicateRoutineName
+	}
+	return err
+}
+
+// PostgresRoutineToRoutine converts a database model to a domain model
+func PostgresRoutineToRoutine(pr PostgresRoutine) (*routine.Routine, error) {
+	exercises := make([]routine.ExerciseParams, 0, len(pr.Exercises))
+	for _, e := range pr.Exercises {
+		exercises = append(exercises, routine.ExerciseParams{
+			ID:         e.ID,
+			ExerciseID: e.ExerciseID,
+			Target:     routine.Target{Sets: e.TargetSets, MinReps: e.MinReps, MaxReps: e.MaxReps},
+		})
+	}
+
+	return routine.NewRoutine(routine.RoutineParams{
+		ID:        pr.ID,
+		Name:      pr.Name,
+		Exercises: exercises,
+	})
+}
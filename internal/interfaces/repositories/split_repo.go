@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDuplicateSplitName is returned when attempting to store a split with a name the user already has
+	ErrDuplicateSplitName = errors.New("a split with that name already exists")
+)
+
+// SplitRepository implements split.SplitRepository interface using PostgreSQL
+type SplitRepository struct {
+	db *sql.DB
+}
+
+// NewSplitRepository creates a new repository with the provided database connection
+func NewSplitRepository(db *sql.DB) *SplitRepository {
+	return &SplitRepository{db: db}
+}
+
+// PostgresSplit represents the database structure for storing splits
+type PostgresSplit struct {
+	ID        uuid.UUID
+	Name      string
+	UserID    uuid.UUID
+	Days      []PostgresSplitDay
+	CreatedAt time.Time
+}
+
+// PostgresSplitDay represents the database structure for storing the training days of a split
+type PostgresSplitDay struct {
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	ExerciseIDs []uuid.UUID `json:"exercise_ids"`
+}
+
+// selectSplitQuery loads splits together with their ordered days and exercises as JSON.
+// A single statement sees one snapshot, so an aggregate is never read half updated.
+const selectSplitQuery = `
+	SELECT s.id, s.split_name, s.user_id, s.created_at,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', d.id,
+				'name', d.day_name,
+				'exercise_ids', ARRAY(
+					SELECT x.exercise_id FROM split_day_exercises x
+					WHERE x.split_day_id = d.id ORDER BY x.position
+				)
+			) ORDER BY d.position)
+			FROM split_days d WHERE d.split_id = s.id
+		), '[]')
+	FROM splits s
+`
+
+// Add persists a new split with its days for a specific user in a single transaction
+// Returns ErrDuplicateSplitName if the user already has a split with the same name
+func (r *SplitRepository) Add(ctx context.Context, userID uuid.UUID, s *split.Split) error {
+	query := `
+		INSERT INTO splits (id, split_name, user_id, created_at)
+		VALUES($1, $2, $3, $4)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query, s.GetId(), s.GetName(), userID, time.Now()); err != nil {
+			return splitError(err)
+		}
+		return insertSplitDays(ctx, tx, s)
+	})
+}
+
+// GetByID retrieves a split with its days by ID for a specific user
+// Returns ErrNotFound if the split doesn't exist for that user
+func (r *SplitRepository) GetByID(ctx context.Context, userID, splitID uuid.UUID) (*split.Split, error) {
+	query := selectSplitQuery + `WHERE s.user_id = $1 AND s.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	ps, err := scanSplit(r.db.QueryRowContext(ctx, query, userID, splitID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresSplitToSplit(ps)
+}
+
+// splitListSpec describes how splits are paginated, sorted and filtered
+var splitListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "s.split_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "s.created_at", Type: "timestamptz"},
+	},
+	ID:   "s.id",
+	Name: "s.split_name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (ps PostgresSplit) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return ps.CreatedAt
+	}
+	return ps.Name
+}
+
+// List retrieves a page of the splits with their days belonging to a specific user
+func (r *SplitRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*split.Split], error) {
+	query := selectSplitQuery + `WHERE s.user_id = $1`
+
+	query, args, err := splitListSpec.Build(q, query, userID)
+	if err != nil {
+		return listquery.Page[*split.Split]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*split.Split]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*split.Split](q)
+
+	for rows.Next() {
+		ps, err := scanSplit(rows)
+		if err != nil {
+			return listquery.Page[*split.Split]{}, err
+		}
+
+		s, err := PostgresSplitToSplit(ps)
+		if err != nil {
+			return listquery.Page[*split.Split]{}, err
+		}
+
+		page.Add(s, ps.ID, ps.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Modify loads a split for a specific user, applies fn to it and saves the result in a single transaction.
+// The split row stays locked until the transaction ends, so overlapping modifications of a split run one
+// after the other and none of them overwrites the days or exercises another one changed.
+// Returns ErrNotFound if the split doesn't exist for that user and ErrDuplicateSplitName if the name is taken
+func (r *SplitRepository) Modify(ctx context.Context, userID, splitID uuid.UUID, fn func(*split.Split) error) (*split.Split, error) {
+	lockQuery := `SELECT id FROM splits WHERE user_id = $1 AND id = $2 FOR UPDATE`
+	query := selectSplitQuery + `WHERE s.user_id = $1 AND s.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var s *split.Split
+	err := withTx(r.db, ctx, func(tx *sql.Tx) error {
+		var id uuid.UUID
+		if err := tx.QueryRowContext(ctx, lockQuery, userID, splitID).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		ps, err := scanSplit(tx.QueryRowContext(ctx, query, userID, splitID))
+		if err != nil {
+			return err
+		}
+
+		s, err = PostgresSplitToSplit(ps)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(s); err != nil {
+			return err
+		}
+		return updateSplit(ctx, tx, userID, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// updateSplit overwrites a locked split and replaces its days
+func updateSplit(ctx context.Context, tx *sql.Tx, userID uuid.UUID, s *split.Split) error {
+	query := `
+		UPDATE splits SET split_name = $3
+		WHERE user_id = $1 AND id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, userID, s.GetId(), s.GetName()); err != nil {
+		return splitError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM split_days WHERE split_id = $1`, s.GetId()); err != nil {
+		return err
+	}
+
+	return insertSplitDays(ctx, tx, s)
+}
+
+// Delete removes a split and its days by ID for a specific user
+// Returns ErrNotFound if the split doesn't exist
+func (r *SplitRepository) Delete(ctx context.Context, userID, splitID uuid.UUID) error {
+	query := `
+		DELETE FROM splits
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, splitID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// insertSplitDays writes the ordered days of a split and the exercises assigned to them
+func insertSplitDays(ctx context.Context, tx *sql.Tx, s *split.Split) error {
+	dayQuery := `INSERT INTO split_days (id, split_id, day_name, position) VALUES($1, $2, $3, $4)`
+	exerciseQuery := `INSERT INTO split_day_exercises (split_day_id, exercise_id, position) VALUES($1, $2, $3)`
+
+	for i, day := range s.GetDays() {
+		if _, err := tx.ExecContext(ctx, dayQuery, day.GetId(), s.GetId(), day.GetName(), i); err != nil {
+			return err
+		}
+
+		for j, exerciseID := range day.GetExerciseIDs() {
+			if _, err := tx.ExecContext(ctx, exerciseQuery, day.GetId(), exerciseID, j); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scanSplit reads a row produced by selectSplitQuery
+func scanSplit(row rowScanner) (PostgresSplit, error) {
+	var ps PostgresSplit
+	var days []byte
+
+	if err := row.Scan(&ps.ID, &ps.Name, &ps.UserID, &ps.CreatedAt, &days); err != nil {
+		return ps, err
+	}
+
+	err := json.Unmarshal(days, &ps.Days)
+	return ps, err
+}
+
+// splitError translates unique violations on the per-user name index to ErrDuplicateSplitName
+func splitError(err error) error {
+	if isUniqueViolation(err, "splits_user_id_split_name_key") {
+		return ErrDuplicateSplitName
+	}
+	return err
+}
+
+// PostgresSplitToSplit converts a database model to a domain model
+func PostgresSplitToSplit(ps PostgresSplit) (*split.Split, error) {
+	days := make([]split.DayParams, 0, len(ps.Days))
+	for _, d := range ps.Days {
+		days = append(days, split.DayParams{
+			ID:          d.ID,
+			Name:        d.Name,
+			ExerciseIDs: d.ExerciseIDs,
+		})
+	}
+
+	return split.NewSplit(split.SplitParams{
+		ID:   ps.ID,
+		Name: ps.Name,
+		Days: days,
+	})
+}
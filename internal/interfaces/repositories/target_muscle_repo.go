@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/google/uuid"
 )
 
@@ -15,7 +16,8 @@ var (
 	ErrDuplicateMuscleName = errors.New("a muscle with that name already exists")
 )
 
-// TargetMuscleRepository implements muscle.MuscleRepository interface using PostgreSQL
+// TargetMuscleRepository implements muscle.MuscleRepository interface using PostgreSQL.
+// Catalog muscles are stored with a NULL user_id.
 type TargetMuscleRepository struct {
 	db *sql.DB
 }
@@ -29,16 +31,22 @@ func NewTargetMuscleRepository(db *sql.DB) *TargetMuscleRepository {
 type PostgresMuscle struct {
 	ID        uuid.UUID
 	Name      string
-	UserID    uuid.UUID
+	UserID    uuid.NullUUID
+	Kind      string
+	ParentID  uuid.NullUUID
+	Version   int
 	CreatedAt time.Time
 }
 
+// muscleColumns lists the columns scanned by scanMuscle, prefixed with the alias m
+const muscleColumns = `m.id, m.muscle_name, m.user_id, m.kind, m.parent_id, m.version, m.created_at`
+
 // Add persists a new muscle to the database for a specific user
-// Returns ErrDuplicateMuscleName if a muscle with the same name already exists for that user
+// Returns ErrDuplicateMuscleName if a muscle with the same name, ignoring case, already exists for that user
 func (r *TargetMuscleRepository) Add(ctx context.Context, userID uuid.UUID, muscle *muscle.Muscle) error {
 	query := `
-		INSERT INTO target_muscles (id, muscle_name, user_id, created_at)
-		VALUES($1, $2, $3, $4)
+		INSERT INTO target_muscles (id, muscle_name, user_id, kind, parent_id, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
@@ -49,11 +57,13 @@ func (r *TargetMuscleRepository) Add(ctx context.Context, userID uuid.UUID, musc
 		muscle.ID(),
 		muscle.Name(),
 		userID,
+		muscle.Kind(),
+		nullableUUID(muscle.ParentID()),
 		time.Now(),
 	)
 	if err != nil {
 		switch {
-		case err.Error() == `pq: duplicate key value violates unique constraint "target_muscles_muscle_name_key"`:
+		case isUniqueViolation(err, "target_muscles_user_id_muscle_name_key"):
 			return ErrDuplicateMuscleName
 		default:
 			return err
@@ -62,30 +72,44 @@ func (r *TargetMuscleRepository) Add(ctx context.Context, userID uuid.UUID, musc
 	return nil
 }
 
-// GetByID retrieves a muscle by its ID for a specific user
-// Returns ErrNotFound if the muscle doesn't exist for that user
-func (r *TargetMuscleRepository) GetByID(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Muscle, error) {
+// AddToCatalog persists a muscle in the shared catalog and returns the ID it is stored under
+// If the catalog already has a muscle with the same name, ignoring case, its place in the hierarchy is updated instead
+func (r *TargetMuscleRepository) AddToCatalog(ctx context.Context, muscle *muscle.Muscle) (uuid.UUID, error) {
 	query := `
-		SELECT id, muscle_name, user_id, created_at FROM target_muscles
-		WHERE user_id = $1 AND id = $2	
+		INSERT INTO target_muscles (id, muscle_name, user_id, kind, parent_id, created_at)
+		VALUES($1, $2, NULL, $3, $4, $5)
+		ON CONFLICT (lower(muscle_name)) WHERE user_id IS NULL
+		DO UPDATE SET kind = EXCLUDED.kind, parent_id = EXCLUDED.parent_id
+		RETURNING id
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	var pm PostgresMuscle
-
+	var id uuid.UUID
 	err := r.db.QueryRowContext(ctx,
 		query,
-		userID,
-		muscleID,
-	).Scan(
-		&pm.ID,
-		&pm.Name,
-		&pm.UserID,
-		&pm.CreatedAt,
-	)
+		muscle.ID(),
+		muscle.Name(),
+		muscle.Kind(),
+		nullableUUID(muscle.ParentID()),
+		time.Now(),
+	).Scan(&id)
+	return id, err
+}
 
+// GetByID retrieves a muscle by its ID if it belongs to the user or to the catalog
+// Returns ErrNotFound if the muscle doesn't exist for that user
+func (r *TargetMuscleRepository) GetByID(ctx context.Context, userID, muscleID uuid.UUID) (*muscle.Muscle, error) {
+	query := `
+		SELECT ` + muscleColumns + ` FROM target_muscles m
+		WHERE (m.user_id = $1 OR m.user_id IS NULL) AND m.id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	pm, err := scanMuscle(r.db.QueryRowContext(ctx, query, userID, muscleID))
 	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
@@ -98,17 +122,110 @@ func (r *TargetMuscleRepository) GetByID(ctx context.Context, userID, muscleID u
 	return PostgresMuscleToMuscle(pm)
 }
 
-// List retrieves all muscles belonging to a specific user
-func (r *TargetMuscleRepository) List(ctx context.Context, userID uuid.UUID) ([]*muscle.Muscle, error) {
+// muscleListSpec describes how muscles are paginated, sorted and filtered
+var muscleListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "m.muscle_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "m.created_at", Type: "timestamptz"},
+	},
+	ID:   "m.id",
+	Name: "m.muscle_name",
+}
+
+// List retrieves a page of the user's private muscles merged with the catalog muscles they have not hidden
+func (r *TargetMuscleRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
+	query := `
+		SELECT ` + muscleColumns + ` FROM target_muscles m
+		WHERE (m.user_id = $1
+			OR (m.user_id IS NULL AND NOT EXISTS (
+				SELECT 1 FROM hidden_catalog_muscles h
+				WHERE h.user_id = $1 AND h.muscle_id = m.id
+			)))
+	`
+
+	return r.page(ctx, q, query, userID)
+}
+
+// ListHidden retrieves a page of the catalog muscles the user has hidden
+func (r *TargetMuscleRepository) ListHidden(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*muscle.Muscle], error) {
 	query := `
-		SELECT id, muscle_name, user_id, created_at FROM target_muscles
-		WHERE user_id = $1
+		SELECT ` + muscleColumns + ` FROM target_muscles m
+		JOIN hidden_catalog_muscles h ON h.muscle_id = m.id
+		WHERE h.user_id = $1 AND m.user_id IS NULL
 	`
 
+	return r.page(ctx, q, query, userID)
+}
+
+func (r *TargetMuscleRepository) page(ctx context.Context, q listquery.Query, query string, args ...any) (listquery.Page[*muscle.Muscle], error) {
+	query, args, err := muscleListSpec.Build(q, query, args...)
+	if err != nil {
+		return listquery.Page[*muscle.Muscle]{}, err
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
 	defer cancel()
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*muscle.Muscle]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*muscle.Muscle](q)
+
+	for rows.Next() {
+		pm, err := scanMuscle(rows)
+		if err != nil {
+			return listquery.Page[*muscle.Muscle]{}, err
+		}
+
+		m, err := PostgresMuscleToMuscle(pm)
+		if err != nil {
+			return listquery.Page[*muscle.Muscle]{}, err
+		}
+
+		page.Add(m, pm.ID, pm.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Subtree retrieves a muscle visible to the user together with every muscle below it, parents before children.
+// Hidden catalog muscles are included so that the tree stays complete.
+// Returns ErrNotFound if the root muscle doesn't exist for that user
+func (r *TargetMuscleRepository) Subtree(ctx context.Context, userID, muscleID uuid.UUID) ([]*muscle.Muscle, error) {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT t.*, 0 AS depth FROM target_muscles t
+			WHERE (t.user_id = $1 OR t.user_id IS NULL) AND t.id = $2
+			UNION ALL
+			SELECT t.*, s.depth + 1 FROM target_muscles t
+			JOIN subtree s ON t.parent_id = s.id
+			WHERE t.user_id = $1 OR t.user_id IS NULL
+		)
+		SELECT ` + muscleColumns + ` FROM subtree m
+		ORDER BY m.depth, lower(m.muscle_name)
+	`
+
+	muscles, err := r.list(ctx, query, userID, muscleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(muscles) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return muscles, nil
+}
+
+func (r *TargetMuscleRepository) list(ctx context.Context, query string, args ...any) ([]*muscle.Muscle, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -118,8 +235,7 @@ func (r *TargetMuscleRepository) List(ctx context.Context, userID uuid.UUID) ([]
 	muscles := []*muscle.Muscle{}
 
 	for rows.Next() {
-		var pm PostgresMuscle
-		err := rows.Scan(&pm.ID, &pm.Name, &pm.UserID, &pm.CreatedAt)
+		pm, err := scanMuscle(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -132,11 +248,103 @@ func (r *TargetMuscleRepository) List(ctx context.Context, userID uuid.UUID) ([]
 		muscles = append(muscles, m)
 	}
 
-	return muscles, nil
+	return muscles, rows.Err()
+}
+
+// Hide removes a catalog muscle from the user's merged view. Hiding it again is a no-op.
+func (r *TargetMuscleRepository) Hide(ctx context.Context, userID, muscleID uuid.UUID) error {
+	query := `
+		INSERT INTO hidden_catalog_muscles (user_id, muscle_id)
+		VALUES($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, userID, muscleID)
+	return err
+}
+
+// Unhide restores a hidden catalog muscle to the user's merged view. Unhiding a visible muscle is a no-op.
+func (r *TargetMuscleRepository) Unhide(ctx context.Context, userID, muscleID uuid.UUID) error {
+	query := `
+		DELETE FROM hidden_catalog_muscles
+		WHERE user_id = $1 AND muscle_id = $2
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx, query, userID, muscleID)
+	return err
+}
+
+// Update changes the name and place in the hierarchy of one of the user's muscles and increments its version.
+// The update only applies if the stored version equals muscle.Version().
+// Returns muscle.ErrVersionConflict if it does not, muscle.ErrCatalogMuscleReadOnly for catalog muscles,
+// ErrDuplicateMuscleName if the new name is taken and ErrNotFound if the muscle doesn't exist
+func (r *TargetMuscleRepository) Update(ctx context.Context, userID uuid.UUID, m *muscle.Muscle) error {
+	query := `
+		UPDATE target_muscles
+		SET muscle_name = $1, kind = $2, parent_id = $3, version = version + 1
+		WHERE user_id = $4 AND id = $5 AND version = $6
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx,
+		query,
+		m.Name(),
+		m.Kind(),
+		nullableUUID(m.ParentID()),
+		userID,
+		m.ID(),
+		m.Version(),
+	)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err, "target_muscles_user_id_muscle_name_key"):
+			return ErrDuplicateMuscleName
+		default:
+			return err
+		}
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return r.failedUpdateError(ctx, userID, m.ID())
+	}
+
+	return nil
+}
+
+// failedUpdateError explains why a versioned update matched no muscle owned by the user
+func (r *TargetMuscleRepository) failedUpdateError(ctx context.Context, userID, muscleID uuid.UUID) error {
+	if err := r.missingMuscleError(ctx, muscleID); err != ErrNotFound {
+		return err
+	}
+
+	query := `SELECT EXISTS (SELECT 1 FROM target_muscles WHERE id = $1 AND user_id = $2)`
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, query, muscleID, userID).Scan(&exists); err != nil {
+		return err
+	}
+
+	if exists {
+		return muscle.ErrVersionConflict
+	}
+	return ErrNotFound
 }
 
 // Delete removes a muscle by its ID for a specific user
-// Returns ErrNotFound if the muscle doesn't exist
+// Returns muscle.ErrCatalogMuscleReadOnly for catalog muscles and ErrNotFound if the muscle doesn't exist
 func (r *TargetMuscleRepository) Delete(ctx context.Context, userID, muscleID uuid.UUID) error {
 	query := `
 		DELETE FROM target_muscles
@@ -156,17 +364,60 @@ func (r *TargetMuscleRepository) Delete(ctx context.Context, userID, muscleID uu
 	}
 
 	if rows == 0 {
-		return ErrNotFound
+		return r.missingMuscleError(ctx, muscleID)
 	}
 
 	return nil
 }
 
+// missingMuscleError explains why a write matched no muscle owned by the user
+func (r *TargetMuscleRepository) missingMuscleError(ctx context.Context, muscleID uuid.UUID) error {
+	query := `SELECT EXISTS (SELECT 1 FROM target_muscles WHERE id = $1 AND user_id IS NULL)`
+
+	var isCatalog bool
+	if err := r.db.QueryRowContext(ctx, query, muscleID).Scan(&isCatalog); err != nil {
+		return err
+	}
+
+	if isCatalog {
+		return muscle.ErrCatalogMuscleReadOnly
+	}
+	return ErrNotFound
+}
+
+// sortValue returns the value of the column the muscle is sorted by
+func (pm PostgresMuscle) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pm.CreatedAt
+	}
+	return pm.Name
+}
+
+func scanMuscle(row rowScanner) (PostgresMuscle, error) {
+	var pm PostgresMuscle
+	err := row.Scan(&pm.ID, &pm.Name, &pm.UserID, &pm.Kind, &pm.ParentID, &pm.Version, &pm.CreatedAt)
+	return pm, err
+}
+
+// nullableUUID stores uuid.Nil as NULL
+func nullableUUID(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
 // PostgresMuscleToMuscle converts a database model to a domain model
 func PostgresMuscleToMuscle(pm PostgresMuscle) (*muscle.Muscle, error) {
+	origin := muscle.OriginUser
+	if !pm.UserID.Valid {
+		origin = muscle.OriginCatalog
+	}
+
 	return muscle.NewMuscle(muscle.MuscleParams{
-		ID:   pm.ID,
-		Name: pm.Name,
+		ID:       pm.ID,
+		Name:     pm.Name,
+		Origin:   origin,
+		Kind:     muscle.Kind(pm.Kind),
+		ParentID: pm.ParentID.UUID,
+		Version:  pm.Version,
 	})
 
 }
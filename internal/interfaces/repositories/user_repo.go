@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrDuplicateEmail is returned when attempting to create a user with an email that is already registered
+	ErrDuplicateEmail = errors.New("a user with that email already exists")
+
+	// ErrDuplicateUsername is returned when attempting to create a user with a username that is already taken
+	ErrDuplicateUsername = errors.New("a user with that username already exists")
+)
+
+// UserRepository implements user.UserRepository interface using PostgreSQL
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new repository with the provided database connection
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// PostgresUser represents the database structure for storing users
+type PostgresUser struct {
+	ID        uuid.UUID
+	Email     string
+	Username  string
+	Password  []byte
+	Formula   string
+	CreatedAt time.Time
+}
+
+// Add persists a new user to the database
+// Returns ErrDuplicateEmail or ErrDuplicateUsername if either is already taken
+func (r *UserRepository) Add(ctx context.Context, user *user.User) error {
+	query := `
+		INSERT INTO users (id, email, username, password, one_rep_max_formula, created_at)
+		VALUES($1, $2, $3, $4, $5, $6)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	_, err := r.db.ExecContext(ctx,
+		query,
+		user.ID(),
+		user.Email(),
+		user.Username(),
+		user.PasswordHash(),
+		string(user.Settings().OneRepMaxFormula),
+		user.CreatedAt(),
+	)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err, "users_email_key"):
+			return ErrDuplicateEmail
+		case isUniqueViolation(err, "users_username_key"):
+			return ErrDuplicateUsername
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// GetByID retrieves a user by ID
+// Returns ErrNotFound if the user doesn't exist
+func (r *UserRepository) GetByID(ctx context.Context, userID uuid.UUID) (*user.User, error) {
+	query := `
+		SELECT id, email, username, password, one_rep_max_formula, created_at FROM users
+		WHERE id = $1
+	`
+
+	return r.getOne(ctx, query, userID)
+}
+
+// GetByEmail retrieves a user by email, compared case-insensitively
+// Returns ErrNotFound if no user is registered with that email
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	query := `
+		SELECT id, email, username, password, one_rep_max_formula, created_at FROM users
+		WHERE email = $1
+	`
+
+	return r.getOne(ctx, query, email)
+}
+
+// UpdateSettings stores the settings of a user
+// Returns ErrNotFound if the user doesn't exist
+func (r *UserRepository) UpdateSettings(ctx context.Context, user *user.User) error {
+	query := `
+		UPDATE users SET one_rep_max_formula = $2
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, user.ID(), string(user.Settings().OneRepMaxFormula))
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *UserRepository) getOne(ctx context.Context, query string, arg any) (*user.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var pu PostgresUser
+
+	err := r.db.QueryRowContext(ctx, query, arg).Scan(
+		&pu.ID,
+		&pu.Email,
+		&pu.Username,
+		&pu.Password,
+		&pu.Formula,
+		&pu.CreatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresUserToUser(pu)
+}
+
+// PostgresUserToUser converts a database model to a domain model
+func PostgresUserToUser(pu PostgresUser) (*user.User, error) {
+	return user.NewUser(user.UserParams{
+		ID:           pu.ID,
+		Email:        pu.Email,
+		Username:     pu.Username,
+		PasswordHash: pu.Password,
+		Settings:     user.Settings{OneRepMaxFormula: strength.Formula(pu.Formula)},
+		CreatedAt:    pu.CreatedAt,
+	})
+}
@@ -0,0 +1,522 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// WorkoutRepository implements workout.WorkoutRepository interface using PostgreSQL
+type WorkoutRepository struct {
+	db *sql.DB
+}
+
+// NewWorkoutRepository creates a new repository with the provided database connection
+func NewWorkoutRepository(db *sql.DB) *WorkoutRepository {
+	return &WorkoutRepository{db: db}
+}
+
+// PostgresWorkout represents the database structure for storing workout sessions
+type PostgresWorkout struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	Notes      string
+	StartedAt  time.Time
+	FinishedAt sql.NullTime
+	Entries    []PostgresWorkoutEntry
+	CreatedAt  time.Time
+}
+
+// PostgresWorkoutEntry represents the database structure for storing the exercises of a workout session
+type PostgresWorkoutEntry struct {
+	ID         uuid.UUID              `json:"id"`
+	ExerciseID uuid.UUID              `json:"exercise_id"`
+	Target     *PostgresWorkoutTarget `json:"target"`
+	Sets       []PostgresWorkoutSet   `json:"sets"`
+}
+
+// PostgresWorkoutTarget represents the database structure for storing the target of a planned entry
+type PostgresWorkoutTarget struct {
+	Sets    int     `json:"sets"`
+	MinReps int     `json:"min_reps"`
+	MaxReps int     `json:"max_reps"`
+	Weight  float64 `json:"weight"`
+	Unit    string  `json:"unit"`
+}
+
+// PostgresWorkoutSet represents the database structure for storing the sets of an exercise entry
+type PostgresWorkoutSet struct {
+	ID     uuid.UUID `json:"id"`
+	Reps   int       `json:"reps"`
+	Weight float64   `json:"weight"`
+	Unit   string    `json:"unit"`
+	RPE    *float64  `json:"rpe"`
+	RIR    *int      `json:"rir"`
+	Warmup bool      `json:"warmup"`
+}
+
+// workoutSetsJSON is the ordered sets of the workout entry e as a JSON array
+const workoutSetsJSON = `
+	COALESCE((
+		SELECT json_agg(json_build_object(
+			'id', st.id,
+			'reps', st.reps,
+			'weight', st.weight,
+			'unit', st.unit,
+			'rpe', st.rpe,
+			'rir', st.rir,
+			'warmup', st.warmup
+		) ORDER BY st.position)
+		FROM workout_sets st WHERE st.entry_id = e.id
+	), '[]')
+`
+
+// selectWorkoutQuery loads workout sessions together with their ordered entries and sets as JSON.
+// A single statement sees one snapshot, so an aggregate is never read half updated.
+const selectWorkoutQuery = `
+	SELECT w.id, w.user_id, w.session_name, w.notes, w.started_at, w.finished_at, w.created_at,
+		COALESCE((
+			SELECT json_agg(json_build_object(
+				'id', e.id,
+				'exercise_id', e.exercise_id,
+				'target', CASE WHEN e.target_sets IS NULL THEN NULL ELSE json_build_object(
+					'sets', e.target_sets,
+					'min_reps', e.target_min_reps,
+					'max_reps', e.target_max_reps,
+					'weight', e.target_weight,
+					'unit', e.target_unit
+				) END,
+				'sets', ` + workoutSetsJSON + `
+			) ORDER BY e.position)
+			FROM workout_entries e WHERE e.session_id = w.id
+		), '[]')
+	FROM workout_sessions w
+`
+
+// Add persists a new workout session with its entries and sets for a specific user in a single transaction
+func (r *WorkoutRepository) Add(ctx context.Context, userID uuid.UUID, s *workout.Session) error {
+	query := `
+		INSERT INTO workout_sessions (id, user_id, session_name, notes, started_at, finished_at, created_at)
+		VALUES($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	return withTx(r.db, ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, query,
+			s.GetID(), userID, s.GetName(), s.GetNotes(), s.GetStartedAt(), nullableTime(s.GetFinishedAt()), time.Now(),
+		); err != nil {
+			return err
+		}
+		return saveWorkoutEntries(ctx, tx, s)
+	})
+}
+
+// GetByID retrieves a workout session with its entries and sets by ID for a specific user
+// Returns ErrNotFound if the session doesn't exist for that user
+func (r *WorkoutRepository) GetByID(ctx context.Context, userID, sessionID uuid.UUID) (*workout.Session, error) {
+	query := selectWorkoutQuery + `WHERE w.user_id = $1 AND w.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	pw, err := scanWorkout(r.db.QueryRowContext(ctx, query, userID, sessionID))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return PostgresWorkoutToSession(pw)
+}
+
+// workoutListSpec describes how workout sessions are paginated, sorted and filtered.
+// A session is created when it starts, so sorting by creation orders sessions by start time.
+var workoutListSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "w.session_name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "w.started_at", Type: "timestamptz"},
+	},
+	ID:   "w.id",
+	Name: "w.session_name",
+}
+
+// sortValue returns the value of the column the row is sorted by
+func (pw PostgresWorkout) sortValue(sort string) any {
+	if sort == listquery.SortCreatedAt {
+		return pw.StartedAt
+	}
+	return pw.Name
+}
+
+// List retrieves a page of the workout sessions with their entries and sets belonging to a specific user
+func (r *WorkoutRepository) List(ctx context.Context, userID uuid.UUID, q listquery.Query) (listquery.Page[*workout.Session], error) {
+	query := selectWorkoutQuery + `WHERE w.user_id = $1`
+
+	query, args, err := workoutListSpec.Build(q, query, userID)
+	if err != nil {
+		return listquery.Page[*workout.Session]{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return listquery.Page[*workout.Session]{}, err
+	}
+
+	defer rows.Close()
+
+	page := listquery.NewCollector[*workout.Session](q)
+
+	for rows.Next() {
+		pw, err := scanWorkout(rows)
+		if err != nil {
+			return listquery.Page[*workout.Session]{}, err
+		}
+
+		s, err := PostgresWorkoutToSession(pw)
+		if err != nil {
+			return listquery.Page[*workout.Session]{}, err
+		}
+
+		page.Add(s, pw.ID, pw.sortValue(q.Sort))
+	}
+
+	return page.Page(), rows.Err()
+}
+
+// Modify loads a workout session for a specific user, applies fn to it and saves the result in a single transaction.
+// The session row stays locked until the transaction ends, so overlapping modifications of a session run one
+// after the other and none of them overwrites the entries or sets another one added.
+// Returns ErrNotFound if the session doesn't exist for that user
+func (r *WorkoutRepository) Modify(ctx context.Context, userID, sessionID uuid.UUID, fn func(*workout.Session) error) (*workout.Session, error) {
+	lockQuery := `SELECT id FROM workout_sessions WHERE user_id = $1 AND id = $2 FOR UPDATE`
+	query := selectWorkoutQuery + `WHERE w.user_id = $1 AND w.id = $2`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	var s *workout.Session
+	err := withTx(r.db, ctx, func(tx *sql.Tx) error {
+		var id uuid.UUID
+		if err := tx.QueryRowContext(ctx, lockQuery, userID, sessionID).Scan(&id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		pw, err := scanWorkout(tx.QueryRowContext(ctx, query, userID, sessionID))
+		if err != nil {
+			return err
+		}
+
+		s, err = PostgresWorkoutToSession(pw)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(s); err != nil {
+			return err
+		}
+		return updateWorkout(ctx, tx, userID, s)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// updateWorkout overwrites a locked workout session and its entries and sets.
+// Entries and sets are updated in place so their IDs stay valid for rows that refer to them.
+func updateWorkout(ctx context.Context, tx *sql.Tx, userID uuid.UUID, s *workout.Session) error {
+	query := `
+		UPDATE workout_sessions SET session_name = $3, notes = $4, finished_at = $5
+		WHERE user_id = $1 AND id = $2
+	`
+
+	if _, err := tx.ExecContext(ctx, query, userID, s.GetID(), s.GetName(), s.GetNotes(), nullableTime(s.GetFinishedAt())); err != nil {
+		return err
+	}
+
+	entryIDs, setIDs := []string{}, []string{}
+	for _, e := range s.GetEntries() {
+		entryIDs = append(entryIDs, e.GetID().String())
+		for _, set := range e.GetSets() {
+			setIDs = append(setIDs, set.GetID().String())
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`DELETE FROM workout_entries WHERE session_id = $1 AND NOT (id = ANY($2::uuid[]))`,
+		s.GetID(), pq.StringArray(entryIDs),
+	); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM workout_sets st USING workout_entries e
+		WHERE st.entry_id = e.id AND e.session_id = $1 AND NOT (st.id = ANY($2::uuid[]))`,
+		s.GetID(), pq.StringArray(setIDs),
+	); err != nil {
+		return err
+	}
+
+	return saveWorkoutEntries(ctx, tx, s)
+}
+
+// Delete removes a workout session with its entries and sets by ID for a specific user
+// Returns ErrNotFound if the session doesn't exist
+func (r *WorkoutRepository) Delete(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `
+		DELETE FROM workout_sessions
+		WHERE user_id = $1 AND id = $2
+	`
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	res, err := r.db.ExecContext(ctx, query, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// LatestEntries returns, for each of the exercises, its entry with working sets from the user's most recent session
+func (r *WorkoutRepository) LatestEntries(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID) (map[uuid.UUID]workout.ExerciseEntry, error) {
+	query := `
+		SELECT DISTINCT ON (e.exercise_id) e.id, e.exercise_id, ` + workoutSetsJSON + `
+		FROM workout_entries e
+		JOIN workout_sessions w ON w.id = e.session_id
+		WHERE w.user_id = $1 AND e.exercise_id = ANY($2::uuid[])
+			AND EXISTS (SELECT 1 FROM workout_sets st WHERE st.entry_id = e.id AND NOT st.warmup)
+		ORDER BY e.exercise_id, w.started_at DESC, e.position DESC
+	`
+
+	ids := make([]string, 0, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		ids = append(ids, id.String())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, pq.StringArray(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make(map[uuid.UUID]workout.ExerciseEntry, len(exerciseIDs))
+
+	for rows.Next() {
+		var pe PostgresWorkoutEntry
+		var sets []byte
+
+		if err := rows.Scan(&pe.ID, &pe.ExerciseID, &sets); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(sets, &pe.Sets); err != nil {
+			return nil, err
+		}
+
+		entry, err := workout.NewExerciseEntry(postgresWorkoutEntryToParams(pe))
+		if err != nil {
+			return nil, err
+		}
+		entries[pe.ExerciseID] = entry
+	}
+
+	return entries, rows.Err()
+}
+
+// ExerciseHistory returns every entry of the exercise with its session, from the oldest session to the newest
+func (r *WorkoutRepository) ExerciseHistory(ctx context.Context, userID, exerciseID uuid.UUID) ([]workout.SessionEntry, error) {
+	query := `
+		SELECT w.id, w.started_at, e.id, e.exercise_id, ` + workoutSetsJSON + `
+		FROM workout_entries e
+		JOIN workout_sessions w ON w.id = e.session_id
+		WHERE w.user_id = $1 AND e.exercise_id = $2
+		ORDER BY w.started_at, e.position
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeoutDuration)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	history := []workout.SessionEntry{}
+
+	for rows.Next() {
+		var h workout.SessionEntry
+		var pe PostgresWorkoutEntry
+		var sets []byte
+
+		if err := rows.Scan(&h.SessionID, &h.StartedAt, &pe.ID, &pe.ExerciseID, &sets); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(sets, &pe.Sets); err != nil {
+			return nil, err
+		}
+
+		h.Entry, err = workout.NewExerciseEntry(postgresWorkoutEntryToParams(pe))
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, h)
+	}
+
+	return history, rows.Err()
+}
+
+// saveWorkoutEntries inserts or updates the ordered entries of a session and their sets.
+// The target of an entry is set when it is planned and never changes afterwards.
+func saveWorkoutEntries(ctx context.Context, tx *sql.Tx, s *workout.Session) error {
+	entryQuery := `
+		INSERT INTO workout_entries (
+			id, session_id, exercise_id, position,
+			target_sets, target_min_reps, target_max_reps, target_weight, target_unit
+		)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET position = EXCLUDED.position
+	`
+	setQuery := `
+		INSERT INTO workout_sets (id, entry_id, position, reps, weight, unit, rpe, rir, warmup)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET position = EXCLUDED.position, reps = EXCLUDED.reps, weight = EXCLUDED.weight,
+			unit = EXCLUDED.unit, rpe = EXCLUDED.rpe, rir = EXCLUDED.rir, warmup = EXCLUDED.warmup
+	`
+
+	for i, e := range s.GetEntries() {
+		var sets, minReps, maxReps sql.NullInt32
+		var weight sql.NullFloat64
+		var unit sql.NullString
+		if t := e.GetTarget(); t != nil {
+			sets = sql.NullInt32{Int32: int32(t.Sets), Valid: true}
+			minReps = sql.NullInt32{Int32: int32(t.MinReps), Valid: true}
+			maxReps = sql.NullInt32{Int32: int32(t.MaxReps), Valid: true}
+			weight = sql.NullFloat64{Float64: t.Weight, Valid: true}
+			unit = sql.NullString{String: string(t.Unit), Valid: true}
+		}
+
+		if _, err := tx.ExecContext(ctx, entryQuery,
+			e.GetID(), s.GetID(), e.GetExerciseID(), i, sets, minReps, maxReps, weight, unit,
+		); err != nil {
+			return err
+		}
+
+		for j, set := range e.GetSets() {
+			if _, err := tx.ExecContext(ctx, setQuery,
+				set.GetID(), e.GetID(), j, set.GetReps(), set.GetWeight(), string(set.GetUnit()),
+				set.GetRPE(), set.GetRIR(), set.IsWarmup(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scanWorkout reads a row produced by selectWorkoutQuery
+func scanWorkout(row rowScanner) (PostgresWorkout, error) {
+	var pw PostgresWorkout
+	var entries []byte
+
+	if err := row.Scan(&pw.ID, &pw.UserID, &pw.Name, &pw.Notes, &pw.StartedAt, &pw.FinishedAt, &pw.CreatedAt, &entries); err != nil {
+		return pw, err
+	}
+
+	err := json.Unmarshal(entries, &pw.Entries)
+	return pw, err
+}
+
+// nullableTime stores the zero time as NULL
+func nullableTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// postgresWorkoutEntryToParams converts a database entry to the parameters of a domain entry
+func postgresWorkoutEntryToParams(pe PostgresWorkoutEntry) workout.ExerciseEntryParams {
+	sets := make([]workout.SetParams, 0, len(pe.Sets))
+	for _, s := range pe.Sets {
+		sets = append(sets, workout.SetParams{
+			ID:     s.ID,
+			Reps:   s.Reps,
+			Weight: s.Weight,
+			Unit:   workout.Unit(s.Unit),
+			RPE:    s.RPE,
+			RIR:    s.RIR,
+			Warmup: s.Warmup,
+		})
+	}
+
+	var target *workout.Target
+	if pe.Target != nil {
+		target = &workout.Target{
+			Sets:    pe.Target.Sets,
+			MinReps: pe.Target.MinReps,
+			MaxReps: pe.Target.MaxReps,
+			Weight:  pe.Target.Weight,
+			Unit:    workout.Unit(pe.Target.Unit),
+		}
+	}
+
+	return workout.ExerciseEntryParams{
+		ID:         pe.ID,
+		ExerciseID: pe.ExerciseID,
+		Target:     target,
+		Sets:       sets,
+	}
+}
+
+// PostgresWorkoutToSession converts a database model to a domain model
+func PostgresWorkoutToSession(pw PostgresWorkout) (*workout.Session, error) {
+	entries := make([]workout.ExerciseEntryParams, 0, len(pw.Entries))
+	for _, e := range pw.Entries {
+		entries = append(entries, postgresWorkoutEntryToParams(e))
+	}
+
+	var finishedAt time.Time
+	if pw.FinishedAt.Valid {
+		finishedAt = pw.FinishedAt.Time
+	}
+
+	return workout.NewSession(workout.SessionParams{
+		ID:         pw.ID,
+		Name:       pw.Name,
+		Notes:      pw.Notes,
+		StartedAt:  pw.StartedAt,
+		FinishedAt: finishedAt,
+		Entries:    entries,
+	})
+}
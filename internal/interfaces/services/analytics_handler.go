@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// dateLayout is the format of dates in query parameters and responses
+const dateLayout = time.DateOnly
+
+// AnalyticsHandler handles HTTP requests for analytics computed from logged workouts.
+type AnalyticsHandler struct {
+	analyticsUseCase application.AnalyticsUseCase
+	logger           *zap.SugaredLogger
+	responseHelper   *ResponseHelper
+}
+
+// NewAnalyticsHandler creates a new analytics handler with the specified dependencies.
+func NewAnalyticsHandler(analyticsUseCase application.AnalyticsUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUseCase: analyticsUseCase,
+		logger:           logger,
+		responseHelper:   responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all analytics routes on the provided router.
+func (h *AnalyticsHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/analytics", func(r chi.Router) {
+		r.Get("/muscle-volume", h.GetMuscleVolume)
+	})
+}
+
+// MuscleVolumeResponse defines the response structure for the training volume of a muscle during a week.
+type MuscleVolumeResponse struct {
+	MuscleID string  `json:"muscle_id"`
+	Name     string  `json:"name"`
+	Sets     float64 `json:"sets"`
+	Tonnage  float64 `json:"tonnage"`
+}
+
+// WeekVolumeResponse defines the response structure for the training volume of an ISO week.
+type WeekVolumeResponse struct {
+	Week    string                 `json:"week"`
+	Start   string                 `json:"start"`
+	Muscles []MuscleVolumeResponse `json:"muscles"`
+}
+
+// WeeklyMuscleVolumeResponse defines the response structure for the weekly training volume per muscle.
+type WeeklyMuscleVolumeResponse struct {
+	From  string               `json:"from"`
+	To    string               `json:"to"`
+	Unit  string               `json:"unit"`
+	Weeks []WeekVolumeResponse `json:"weeks"`
+}
+
+// GetMuscleVolume handles GET requests for the working sets and tonnage per target muscle and ISO week.
+// The from and to query parameters are inclusive dates, the last four weeks are returned when both are missing.
+func (h *AnalyticsHandler) GetMuscleVolume(w http.ResponseWriter, r *http.Request) {
+	rg, err := parseRange(r)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	weeks, err := h.analyticsUseCase.WeeklyMuscleVolume(r.Context(), getUserIDFromContext(r.Context()), rg)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := WeeklyMuscleVolumeResponse{
+		From:  rg.From.Format(dateLayout),
+		To:    rg.To.Format(dateLayout),
+		Unit:  string(workout.UnitKg),
+		Weeks: make([]WeekVolumeResponse, 0, len(weeks)),
+	}
+	for _, wk := range weeks {
+		muscles := make([]MuscleVolumeResponse, 0, len(wk.Muscles))
+		for _, m := range wk.Muscles {
+			muscles = append(muscles, MuscleVolumeResponse{
+				MuscleID: m.Muscle.ID.String(),
+				Name:     m.Muscle.Name,
+				Sets:     m.Sets,
+				Tonnage:  m.Tonnage,
+			})
+		}
+		responseBody.Weeks = append(responseBody.Weeks, WeekVolumeResponse{
+			Week:    wk.Week.String(),
+			Start:   wk.Week.Start().Format(dateLayout),
+			Muscles: muscles,
+		})
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// parseRange reads the from and to query parameters. A missing to is today and a missing from
+// is the start of the default range ending on to. A malformed date is returned as a requestError.
+func parseRange(r *http.Request) (analytics.Range, error) {
+	def := analytics.DefaultRange(time.Now())
+
+	to, err := parseDateParam(r, "to", def.To)
+	if err != nil {
+		return analytics.Range{}, err
+	}
+	from, err := parseDateParam(r, "from", analytics.DefaultRange(to).From)
+	if err != nil {
+		return analytics.Range{}, err
+	}
+
+	return analytics.NewRange(from, to)
+}
+
+// parseDateParam parses the date in the named query parameter, returning def when it is missing.
+func parseDateParam(r *http.Request, param string, def time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return def, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, &requestError{fmt.Sprintf("%s must be a date formatted as YYYY-MM-DD", param)}
+	}
+	return t, nil
+}
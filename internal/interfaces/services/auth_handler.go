@@ -0,0 +1,184 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// AuthHandler handles HTTP requests related to signing up and logging in.
+type AuthHandler struct {
+	authUseCase    application.AuthUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewAuthHandler creates a new auth handler with the specified dependencies.
+func NewAuthHandler(authUseCase application.AuthUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *AuthHandler {
+	return &AuthHandler{
+		authUseCase:    authUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all auth-related routes on the provided router.
+func (h *AuthHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/auth", func(r chi.Router) {
+		r.Post("/signup", h.Signup)
+		r.Post("/login", h.Login)
+		r.Post("/refresh", h.Refresh)
+		r.Post("/logout", h.Logout)
+	})
+}
+
+// SignupRequest defines the expected structure for signup requests.
+type SignupRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Username string `json:"username" validate:"required,min=3,max=100"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// UserResponse defines the standard response structure for user data.
+type UserResponse struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// LoginRequest defines the expected structure for login requests.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+// RefreshTokenRequest defines the expected structure for refresh and logout requests.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// TokenResponse defines the response structure after a successful login or refresh.
+type TokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresIn             int       `json:"expires_in"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+// Signup handles POST requests to register a new user.
+func (h *AuthHandler) Signup(w http.ResponseWriter, r *http.Request) {
+	var payload SignupRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	u, err := h.authUseCase.Signup(r.Context(), payload.Email, payload.Username, payload.Password)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := UserResponse{
+		ID:       u.ID().String(),
+		Email:    u.Email(),
+		Username: u.Username(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// Login handles POST requests to exchange credentials for an access token.
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var payload LoginRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	tokens, err := h.authUseCase.Login(r.Context(), payload.Email, payload.Password)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newTokenResponse(tokens)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// Refresh handles POST requests to exchange a refresh token for a new token pair.
+// The presented refresh token is consumed and cannot be used again.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var payload RefreshTokenRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	tokens, err := h.authUseCase.Refresh(r.Context(), payload.RefreshToken)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newTokenResponse(tokens)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// Logout handles POST requests to revoke the session the refresh token belongs to.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var payload RefreshTokenRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	if err := h.authUseCase.Logout(r.Context(), payload.RefreshToken); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newTokenResponse converts issued tokens to their response representation.
+func newTokenResponse(tokens *application.AuthTokens) TokenResponse {
+	return TokenResponse{
+		AccessToken:           tokens.AccessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(tokens.ExpiresIn.Seconds()),
+		RefreshToken:          tokens.RefreshToken,
+		RefreshTokenExpiresAt: tokens.RefreshTokenExpiresAt,
+	}
+}
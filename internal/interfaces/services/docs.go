@@ -0,0 +1,374 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
+	"github.com/CP-Payne/exercise/internal/interfaces/services/openapi"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+)
+
+// swaggerUI is the page that renders the OpenAPI document with Swagger UI
+//
+//go:embed swagger.html
+var swaggerUI []byte
+
+// DocsHandler serves the OpenAPI document and the Swagger UI.
+type DocsHandler struct {
+	spec []byte
+}
+
+// NewDocsHandler creates a docs handler, the OpenAPI document is built once up front.
+func NewDocsHandler() *DocsHandler {
+	spec, err := json.Marshal(NewOpenAPIDocument())
+	if err != nil {
+		// The document is built from static types, failing to encode it is a programming error
+		panic(err)
+	}
+
+	return &DocsHandler{spec: spec}
+}
+
+// RegisterRoutes sets up the documentation routes on the provided router.
+func (h *DocsHandler) RegisterRoutes(router chi.Router) {
+	router.Get("/openapi.json", h.GetOpenAPI)
+	router.Get("/docs", h.GetSwaggerUI)
+}
+
+// GetOpenAPI handles GET requests for the OpenAPI document.
+func (h *DocsHandler) GetOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.spec)
+}
+
+// GetSwaggerUI handles GET requests for the Swagger UI page.
+func (h *DocsHandler) GetSwaggerUI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(swaggerUI)
+}
+
+// apiOperation describes one route for the OpenAPI document.
+// A nil response means the route answers 204 No Content.
+// An upload route takes an image as a multipart form, an image route answers with the image itself.
+type apiOperation struct {
+	method       string
+	path         string
+	id           string
+	summary      string
+	tag          string
+	request      any
+	optionalBody bool
+	response     any
+	status       int
+	params       []openapi.Parameter
+	list         bool
+	public       bool
+	ifMatch      bool
+	etag         bool
+	upload       bool
+	image        bool
+}
+
+// apiOperations lists every route the API serves.
+// TestOpenAPI_CoversAllRoutes fails when a registered route is missing here.
+var apiOperations = []apiOperation{
+	// Documentation
+	{method: http.MethodGet, path: "/openapi.json", id: "getOpenAPI", summary: "Get the OpenAPI document", tag: "docs", public: true},
+	{method: http.MethodGet, path: "/docs", id: "getDocs", summary: "Browse the API documentation", tag: "docs", public: true},
+
+	// Auth
+	{method: http.MethodPost, path: "/auth/signup", id: "signup", summary: "Register a new user", tag: "auth", request: SignupRequest{}, response: UserResponse{}, status: http.StatusCreated, public: true},
+	{method: http.MethodPost, path: "/auth/login", id: "login", summary: "Log in with email and password", tag: "auth", request: LoginRequest{}, response: TokenResponse{}, public: true},
+	{method: http.MethodPost, path: "/auth/refresh", id: "refresh", summary: "Exchange a refresh token for new tokens", tag: "auth", request: RefreshTokenRequest{}, response: TokenResponse{}, public: true},
+	{method: http.MethodPost, path: "/auth/logout", id: "logout", summary: "Revoke a refresh token", tag: "auth", request: RefreshTokenRequest{}, public: true},
+
+	// Images
+	{method: http.MethodGet, path: "/images/{imageID}", id: "getImage", summary: "Get an uploaded image from its signed URL", tag: "images", params: imageParameters, image: true, public: true},
+	{method: http.MethodGet, path: "/images/{imageID}/thumbnail", id: "getImageThumbnail", summary: "Get the thumbnail of an uploaded image from its signed URL", tag: "images", params: imageParameters, image: true, public: true},
+
+	// Muscles
+	{method: http.MethodGet, path: "/muscles", id: "listMuscles", summary: "List visible muscles", tag: "muscles", response: MuscleListResponse{}, list: true},
+	{method: http.MethodPost, path: "/muscles", id: "createMuscle", summary: "Create a private muscle", tag: "muscles", request: CreateMuscleRequest{}, response: CreateMuscleResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/muscles/hidden", id: "listHiddenMuscles", summary: "List hidden catalog muscles", tag: "muscles", response: MuscleListResponse{}, list: true},
+	{method: http.MethodGet, path: "/muscles/{muscleID}", id: "getMuscle", summary: "Get a muscle", tag: "muscles", response: MuscleResponse{}, etag: true},
+	{method: http.MethodPut, path: "/muscles/{muscleID}", id: "replaceMuscle", summary: "Replace a private muscle", tag: "muscles", request: CreateMuscleRequest{}, response: MuscleResponse{}, ifMatch: true, etag: true},
+	{method: http.MethodPatch, path: "/muscles/{muscleID}", id: "patchMuscle", summary: "Update fields of a private muscle", tag: "muscles", request: UpdateMuscleRequest{}, response: MuscleResponse{}, ifMatch: true, etag: true},
+	{method: http.MethodDelete, path: "/muscles/{muscleID}", id: "deleteMuscle", summary: "Delete a private muscle", tag: "muscles"},
+	{method: http.MethodGet, path: "/muscles/{muscleID}/tree", id: "getMuscleTree", summary: "Get a muscle and the muscles below it", tag: "muscles", response: MuscleTreeResponse{}},
+	{method: http.MethodPut, path: "/muscles/{muscleID}/hidden", id: "hideMuscle", summary: "Hide a catalog muscle", tag: "muscles"},
+	{method: http.MethodDelete, path: "/muscles/{muscleID}/hidden", id: "unhideMuscle", summary: "Show a hidden catalog muscle again", tag: "muscles"},
+
+	// Exercises
+	{method: http.MethodGet, path: "/exercises", id: "listExercises", summary: "List exercises", tag: "exercises", params: availabilityParameters, response: ExerciseListResponse{}, list: true},
+	{method: http.MethodPost, path: "/exercises", id: "createExercise", summary: "Create an exercise", tag: "exercises", request: ExerciseRequest{}, response: CreateExerciseResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/exercises/search", id: "searchExercises", summary: "Search exercises by name, alias and description", tag: "exercises", params: searchParameters, response: ExerciseSearchResponse{}},
+	{method: http.MethodGet, path: "/exercises/{exerciseID}", id: "getExercise", summary: "Get an exercise", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodPut, path: "/exercises/{exerciseID}", id: "replaceExercise", summary: "Replace an exercise", tag: "exercises", request: ExerciseRequest{}, response: ExerciseResponse{}},
+	{method: http.MethodDelete, path: "/exercises/{exerciseID}", id: "deleteExercise", summary: "Delete an exercise", tag: "exercises"},
+	{method: http.MethodGet, path: "/exercises/{exerciseID}/one-rep-max", id: "getOneRepMaxHistory", summary: "Get the estimated one-rep max of an exercise in each session", tag: "exercises", params: []openapi.Parameter{formulaParameter}, response: OneRepMaxHistoryResponse{}},
+	{method: http.MethodGet, path: "/exercises/{exerciseID}/alternatives", id: "listExerciseAlternatives", summary: "Rank the exercises that can replace an exercise", tag: "exercises", params: alternativesParameters, response: ExerciseAlternativesResponse{}},
+	{method: http.MethodPut, path: "/exercises/{exerciseID}/image", id: "uploadExerciseImage", summary: "Upload the display image of an exercise", tag: "exercises", response: ExerciseResponse{}, upload: true},
+	{method: http.MethodDelete, path: "/exercises/{exerciseID}/image", id: "deleteExerciseImage", summary: "Remove the display image of an exercise", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodGet, path: "/exercises/{exerciseID}/records", id: "listExerciseRecords", summary: "List the current and past personal records of an exercise", tag: "exercises", response: ExerciseRecordsResponse{}},
+	{method: http.MethodPut, path: "/exercises/{exerciseID}/muscles/{muscleID}", id: "attachTargetMuscle", summary: "Link a target muscle or change its role", tag: "exercises", request: TargetMuscleLinkRequest{}, optionalBody: true, response: ExerciseResponse{}},
+	{method: http.MethodDelete, path: "/exercises/{exerciseID}/muscles/{muscleID}", id: "detachTargetMuscle", summary: "Unlink a target muscle", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodPut, path: "/exercises/{exerciseID}/equipment/{equipmentID}", id: "attachEquipment", summary: "Link equipment", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodDelete, path: "/exercises/{exerciseID}/equipment/{equipmentID}", id: "detachEquipment", summary: "Unlink equipment", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodPut, path: "/exercises/{exerciseID}/splits/{splitID}", id: "attachSplit", summary: "Link a split", tag: "exercises", response: ExerciseResponse{}},
+	{method: http.MethodDelete, path: "/exercises/{exerciseID}/splits/{splitID}", id: "detachSplit", summary: "Unlink a split", tag: "exercises", response: ExerciseResponse{}},
+
+	// Equipment
+	{method: http.MethodGet, path: "/equipment", id: "listEquipment", summary: "List equipment", tag: "equipment", response: EquipmentListResponse{}, list: true},
+	{method: http.MethodPost, path: "/equipment", id: "createEquipment", summary: "Create equipment", tag: "equipment", request: EquipmentRequest{}, response: CreateEquipmentResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/equipment/profiles", id: "listEquipmentProfiles", summary: "List equipment profiles", tag: "equipment", response: EquipmentProfileListResponse{}, list: true},
+	{method: http.MethodPost, path: "/equipment/profiles", id: "createEquipmentProfile", summary: "Create an equipment profile", tag: "equipment", request: EquipmentProfileRequest{}, response: CreateEquipmentProfileResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/equipment/profiles/{profileID}", id: "getEquipmentProfile", summary: "Get an equipment profile", tag: "equipment", response: EquipmentProfileResponse{}},
+	{method: http.MethodPut, path: "/equipment/profiles/{profileID}", id: "replaceEquipmentProfile", summary: "Replace an equipment profile", tag: "equipment", request: EquipmentProfileRequest{}, response: EquipmentProfileResponse{}},
+	{method: http.MethodDelete, path: "/equipment/profiles/{profileID}", id: "deleteEquipmentProfile", summary: "Delete an equipment profile", tag: "equipment"},
+	{method: http.MethodPut, path: "/equipment/profiles/{profileID}/equipment/{equipmentID}", id: "addProfileEquipment", summary: "Add equipment to a profile", tag: "equipment", response: EquipmentProfileResponse{}},
+	{method: http.MethodDelete, path: "/equipment/profiles/{profileID}/equipment/{equipmentID}", id: "removeProfileEquipment", summary: "Remove equipment from a profile", tag: "equipment", response: EquipmentProfileResponse{}},
+	{method: http.MethodGet, path: "/equipment/{equipmentID}", id: "getEquipment", summary: "Get equipment", tag: "equipment", response: EquipmentResponse{}},
+	{method: http.MethodPut, path: "/equipment/{equipmentID}", id: "renameEquipment", summary: "Rename equipment", tag: "equipment", request: EquipmentRequest{}, response: EquipmentResponse{}},
+	{method: http.MethodDelete, path: "/equipment/{equipmentID}", id: "deleteEquipment", summary: "Delete equipment", tag: "equipment"},
+
+	// Splits
+	{method: http.MethodGet, path: "/splits", id: "listSplits", summary: "List splits", tag: "splits", response: SplitListResponse{}, list: true},
+	{method: http.MethodPost, path: "/splits", id: "createSplit", summary: "Create a split", tag: "splits", request: CreateSplitRequest{}, response: CreateSplitResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/splits/{splitID}", id: "getSplit", summary: "Get a split", tag: "splits", response: SplitResponse{}},
+	{method: http.MethodPut, path: "/splits/{splitID}", id: "renameSplit", summary: "Rename a split", tag: "splits", request: NameRequest{}, response: SplitResponse{}},
+	{method: http.MethodDelete, path: "/splits/{splitID}", id: "deleteSplit", summary: "Delete a split", tag: "splits"},
+	{method: http.MethodPost, path: "/splits/{splitID}/days", id: "addSplitDay", summary: "Add a training day", tag: "splits", request: NameRequest{}, response: SplitResponse{}},
+	{method: http.MethodPut, path: "/splits/{splitID}/days/order", id: "reorderSplitDays", summary: "Reorder the training days", tag: "splits", request: ReorderDaysRequest{}, response: SplitResponse{}},
+	{method: http.MethodPut, path: "/splits/{splitID}/days/{dayID}", id: "renameSplitDay", summary: "Rename a training day", tag: "splits", request: NameRequest{}, response: SplitResponse{}},
+	{method: http.MethodDelete, path: "/splits/{splitID}/days/{dayID}", id: "removeSplitDay", summary: "Remove a training day", tag: "splits", response: SplitResponse{}},
+	{method: http.MethodPut, path: "/splits/{splitID}/days/{dayID}/exercises/{exerciseID}", id: "assignSplitExercise", summary: "Assign an exercise to a training day", tag: "splits", response: SplitResponse{}},
+	{method: http.MethodDelete, path: "/splits/{splitID}/days/{dayID}/exercises/{exerciseID}", id: "unassignSplitExercise", summary: "Remove an exercise from a training day", tag: "splits", response: SplitResponse{}},
+
+	// Workouts
+	{method: http.MethodGet, path: "/workouts", id: "listWorkouts", summary: "List workout sessions", tag: "workouts", response: WorkoutListResponse{}, list: true},
+	{method: http.MethodPost, path: "/workouts", id: "startWorkout", summary: "Start a workout session", tag: "workouts", request: StartWorkoutRequest{}, response: CreateWorkoutResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/workouts/{workoutID}", id: "getWorkout", summary: "Get a workout session", tag: "workouts", response: WorkoutResponse{}},
+	{method: http.MethodDelete, path: "/workouts/{workoutID}", id: "deleteWorkout", summary: "Delete a workout session", tag: "workouts"},
+	{method: http.MethodPost, path: "/workouts/{workoutID}/exercises", id: "addWorkoutExercise", summary: "Append an exercise to a workout session", tag: "workouts", request: AddWorkoutExerciseRequest{}, response: WorkoutResponse{}},
+	{method: http.MethodPost, path: "/workouts/{workoutID}/exercises/{entryID}/sets", id: "addWorkoutSet", summary: "Record a set of an exercise", tag: "workouts", request: AddSetRequest{}, response: WorkoutResponse{}},
+	{method: http.MethodPost, path: "/workouts/{workoutID}/finish", id: "finishWorkout", summary: "Finish a workout session", tag: "workouts", response: FinishWorkoutResponse{}},
+
+	// Routines
+	{method: http.MethodGet, path: "/routines", id: "listRoutines", summary: "List routines", tag: "routines", response: RoutineListResponse{}, list: true},
+	{method: http.MethodPost, path: "/routines", id: "createRoutine", summary: "Create a routine, optionally from a split day", tag: "routines", request: CreateRoutineRequest{}, response: CreateRoutineResponse{}, status: http.StatusCreated},
+	{method: http.MethodGet, path: "/routines/{routineID}", id: "getRoutine", summary: "Get a routine", tag: "routines", response: RoutineResponse{}},
+	{method: http.MethodPut, path: "/routines/{routineID}", id: "renameRoutine", summary: "Rename a routine", tag: "routines", request: NameRequest{}, response: RoutineResponse{}},
+	{method: http.MethodDelete, path: "/routines/{routineID}", id: "deleteRoutine", summary: "Delete a routine", tag: "routines"},
+	{method: http.MethodPost, path: "/routines/{routineID}/exercises", id: "addRoutineExercise", summary: "Add an exercise with its target", tag: "routines", request: RoutineExerciseRequest{}, response: RoutineResponse{}},
+	{method: http.MethodPut, path: "/routines/{routineID}/exercises/order", id: "reorderRoutineExercises", summary: "Reorder the exercises", tag: "routines", request: ReorderRoutineExercisesRequest{}, response: RoutineResponse{}},
+	{method: http.MethodPut, path: "/routines/{routineID}/exercises/{routineExerciseID}", id: "setRoutineExerciseTarget", summary: "Change the target of an exercise", tag: "routines", request: RoutineTargetRequest{}, response: RoutineResponse{}},
+	{method: http.MethodDelete, path: "/routines/{routineID}/exercises/{routineExerciseID}", id: "removeRoutineExercise", summary: "Remove an exercise", tag: "routines", response: RoutineResponse{}},
+	{method: http.MethodPost, path: "/routines/{routineID}/start", id: "startRoutine", summary: "Start a workout session planned from the routine and the previous loads", tag: "routines", response: WorkoutResponse{}, status: http.StatusCreated},
+
+	// Settings
+	{method: http.MethodGet, path: "/settings", id: "getSettings", summary: "Get the settings of the current user", tag: "settings", response: SettingsResponse{}},
+	{method: http.MethodPut, path: "/settings", id: "replaceSettings", summary: "Replace the settings of the current user", tag: "settings", request: SettingsRequest{}, response: SettingsResponse{}},
+
+	// Tools
+	{method: http.MethodPost, path: "/tools/1rm", id: "estimateOneRepMax", summary: "Estimate a one-rep max from a set", tag: "tools", request: OneRepMaxRequest{}, response: OneRepMaxResponse{}},
+
+	// Analytics
+	{method: http.MethodGet, path: "/analytics/muscle-volume", id: "getMuscleVolume", summary: "Get the working sets and tonnage per target muscle and ISO week", tag: "analytics", params: rangeParameters, response: WeeklyMuscleVolumeResponse{}},
+}
+
+// rangeParameters documents the inclusive date range read by parseRange
+var rangeParameters = []openapi.Parameter{
+	{Name: "from", In: "query", Description: "First day of the range, defaults to the Monday three weeks before to", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+	{Name: "to", In: "query", Description: "Last day of the range, defaults to today", Schema: &openapi.Schema{Type: "string", Format: "date"}},
+}
+
+// availabilityParameters documents the inventory filter read by parseAvailability
+var availabilityParameters = []openapi.Parameter{
+	{Name: "available_only", In: "query", Description: "Only exercises whose equipment is all in the user's equipment profiles", Schema: &openapi.Schema{Type: "boolean"}},
+	{Name: "profile", In: "query", Description: "Name of the equipment profile available_only checks against, all profiles by default", Schema: &openapi.Schema{Type: "string"}},
+}
+
+// alternativesParameters documents the query parameters of the exercise alternatives
+var alternativesParameters = []openapi.Parameter{
+	{Name: "profile", In: "query", Description: "Name of the equipment profile whose equipment counts as owned, all profiles by default", Schema: &openapi.Schema{Type: "string"}},
+	{Name: "limit", In: "query", Description: "Number of alternatives, 10 by default and at most 50", Schema: &openapi.Schema{Type: "integer"}},
+}
+
+// imageParameters documents the expiry and signature read by the image routes
+var imageParameters = []openapi.Parameter{
+	{Name: storage.ExpiresParam, In: "query", Required: true, Description: "When the image URL expires, in Unix seconds", Schema: &openapi.Schema{Type: "integer"}},
+	{Name: storage.SignatureParam, In: "query", Required: true, Description: "Signature of the image URL and its expiry, as returned in the display_image and thumbnail_image of an exercise", Schema: &openapi.Schema{Type: "string"}},
+}
+
+// searchParameters documents the search text, filters and limit read by parseSearchParams
+var searchParameters = []openapi.Parameter{
+	{Name: "q", In: "query", Required: true, Description: "Words to look for, matched with stemming and tolerance for typos", Schema: &openapi.Schema{Type: "string"}},
+	{Name: "muscle_id", In: "query", Description: "Only exercises targeting one of these muscles, can be repeated", Schema: &openapi.Schema{Type: "string", Format: "uuid"}},
+	{Name: "equipment_id", In: "query", Description: "Only exercises using one of these pieces of equipment, can be repeated", Schema: &openapi.Schema{Type: "string", Format: "uuid"}},
+	{Name: "split_id", In: "query", Description: "Only exercises in one of these splits, can be repeated", Schema: &openapi.Schema{Type: "string", Format: "uuid"}},
+	{Name: "category", In: "query", Description: "Only exercises in one of these categories, ignoring case, can be repeated", Schema: &openapi.Schema{Type: "string"}},
+	{Name: "limit", In: "query", Description: "Number of results, 20 by default and at most 100", Schema: &openapi.Schema{Type: "integer"}},
+}
+
+// formulaParameter documents the query parameter that overrides the user's preferred one-rep max formula
+var formulaParameter = openapi.Parameter{
+	Name: "formula", In: "query", Description: "One-rep max formula, defaults to the one in the user's settings",
+	Schema: &openapi.Schema{Type: "string", Enum: []string{"epley", "brzycki", "lombardi", "rpe"}},
+}
+
+// pathParamPattern matches the {param} segments shared by chi and OpenAPI paths
+var pathParamPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// NewOpenAPIDocument builds the OpenAPI document of the API from the handler request and response types.
+func NewOpenAPIDocument() *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "Exercise API",
+		Version:     "1.0.0",
+		Description: "Manage exercises, muscles, equipment and training splits, plan routines and log workouts. Errors are returned as RFC 7807 problem details.",
+	})
+
+	doc.Components.SecuritySchemes["bearerAuth"] = &openapi.SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT"}
+	doc.Security = []openapi.SecurityRequirement{{"bearerAuth": {}}}
+	problem := doc.SchemaFor(Problem{})
+
+	for _, o := range apiOperations {
+		op := &openapi.Operation{
+			OperationID: o.id,
+			Summary:     o.summary,
+			Tags:        []string{o.tag},
+			Responses: map[string]*openapi.Response{
+				"default": {
+					Description: "Problem details describing the error",
+					Content:     map[string]*openapi.MediaType{problemContentType: {Schema: problem}},
+				},
+			},
+		}
+
+		if o.public {
+			op.Security = &[]openapi.SecurityRequirement{}
+		}
+
+		for _, m := range pathParamPattern.FindAllStringSubmatch(o.path, -1) {
+			op.Parameters = append(op.Parameters, openapi.Parameter{
+				Name: m[1], In: "path", Required: true,
+				Schema: &openapi.Schema{Type: "string", Format: "uuid"},
+			})
+		}
+		op.Parameters = append(op.Parameters, o.params...)
+		if o.list {
+			op.Parameters = append(op.Parameters, listParameters()...)
+		}
+		if o.ifMatch {
+			op.Parameters = append(op.Parameters, openapi.Parameter{
+				Name: "If-Match", In: "header",
+				Description: "Strong ETags of the versions the update applies to, the update fails with 412 when none of them is current",
+				Schema:      &openapi.Schema{Type: "string"},
+			})
+		}
+
+		if o.request != nil {
+			op.RequestBody = &openapi.RequestBody{
+				Required: !o.optionalBody,
+				Content:  map[string]*openapi.MediaType{"application/json": {Schema: doc.SchemaFor(o.request)}},
+			}
+		}
+		if o.upload {
+			op.RequestBody = &openapi.RequestBody{
+				Required: true,
+				Content:  map[string]*openapi.MediaType{"multipart/form-data": {Schema: imageUploadSchema()}},
+			}
+		}
+
+		status, response := o.status, &openapi.Response{Description: "No content"}
+		switch {
+		case o.id == "getOpenAPI":
+			response = &openapi.Response{Description: "OpenAPI document", Content: map[string]*openapi.MediaType{"application/json": {Schema: &openapi.Schema{Type: "object"}}}}
+		case o.id == "getDocs":
+			response = &openapi.Response{Description: "Swagger UI page", Content: map[string]*openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}}}
+		case o.image:
+			response = &openapi.Response{Description: "The image", Content: map[string]*openapi.MediaType{"image/*": {Schema: &openapi.Schema{Type: "string", Format: "binary"}}}}
+		case o.list:
+			response = &openapi.Response{Description: "One page of results", Content: map[string]*openapi.MediaType{"application/json": {Schema: listEnvelope(doc.SchemaFor(o.response))}}}
+		case o.response != nil:
+			response = &openapi.Response{Description: "Success", Content: map[string]*openapi.MediaType{"application/json": {Schema: dataEnvelope(doc.SchemaFor(o.response))}}}
+		}
+		if status == 0 {
+			status = http.StatusOK
+			if response.Content == nil {
+				status = http.StatusNoContent
+			}
+		}
+		if o.etag {
+			response.Headers = map[string]*openapi.Header{
+				"ETag": {Description: "Version of the resource for conditional updates", Schema: &openapi.Schema{Type: "string"}},
+			}
+		}
+		op.Responses[strconv.Itoa(status)] = response
+
+		doc.AddOperation(o.method, o.path, op)
+	}
+
+	return doc
+}
+
+// dataEnvelope wraps a schema in the envelope written by jsonResponse
+func dataEnvelope(data *openapi.Schema) *openapi.Schema {
+	return &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"data": data},
+		Required:   []string{"data"},
+	}
+}
+
+// listEnvelope wraps a schema in the envelope written by listResponse
+func listEnvelope(data *openapi.Schema) *openapi.Schema {
+	return &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"data": data,
+			"meta": {
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"next_cursor": {Type: "string", Nullable: true, Description: "Cursor of the next page, null on the last page"},
+				},
+				Required: []string{"next_cursor"},
+			},
+		},
+		Required: []string{"data", "meta"},
+	}
+}
+
+// imageUploadSchema describes the multipart form read by imageUpload
+func imageUploadSchema() *openapi.Schema {
+	return &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"image": {
+				Type: "string", Format: "binary",
+				Description: fmt.Sprintf("JPEG, PNG or GIF image of at most %d MB, its metadata is removed", imaging.MaxUploadSize>>20),
+			},
+		},
+		Required: []string{"image"},
+	}
+}
+
+// listParameters documents the query parameters accepted by listquery.Parse
+func listParameters() []openapi.Parameter {
+	minLimit, maxLimit := 1.0, float64(listquery.MaxLimit)
+	return []openapi.Parameter{
+		{Name: "sort", In: "query", Description: "Field to sort by", Schema: &openapi.Schema{Type: "string", Enum: []string{listquery.SortName, listquery.SortCreatedAt}}},
+		{Name: "order", In: "query", Description: "Sort direction", Schema: &openapi.Schema{Type: "string", Enum: []string{string(listquery.Asc), string(listquery.Desc)}}},
+		{Name: "limit", In: "query", Description: "Maximum number of items, defaults to " + strconv.Itoa(listquery.DefaultLimit), Schema: &openapi.Schema{Type: "integer", Minimum: &minLimit, Maximum: &maxLimit}},
+		{Name: "cursor", In: "query", Description: "Cursor returned as meta.next_cursor by the previous page", Schema: &openapi.Schema{Type: "string"}},
+		{Name: "name", In: "query", Description: "Only return items whose name starts with this prefix", Schema: &openapi.Schema{Type: "string"}},
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// noUseCases satisfies application.UseCases for tests that only inspect routing.
+// Handlers take method values of their use cases, so the use cases must not be nil interfaces.
+type noUseCases struct{}
+
+func (noUseCases) MuscleUseCase() application.MuscleUseCase {
+	return struct{ application.MuscleUseCase }{}
+}
+
+func (noUseCases) AuthUseCase() application.AuthUseCase {
+	return struct{ application.AuthUseCase }{}
+}
+
+func (noUseCases) ExerciseUseCase() application.ExerciseUseCase {
+	return struct{ application.ExerciseUseCase }{}
+}
+
+func (noUseCases) EquipmentUseCase() application.EquipmentUseCase {
+	return struct{ application.EquipmentUseCase }{}
+}
+
+func (noUseCases) SplitUseCase() application.SplitUseCase {
+	return struct{ application.SplitUseCase }{}
+}
+
+func (noUseCases) WorkoutUseCase() application.WorkoutUseCase {
+	return struct{ application.WorkoutUseCase }{}
+}
+
+func (noUseCases) RoutineUseCase() application.RoutineUseCase {
+	return struct{ application.RoutineUseCase }{}
+}
+
+func (noUseCases) SettingsUseCase() application.SettingsUseCase {
+	return struct{ application.SettingsUseCase }{}
+}
+
+func (noUseCases) ToolsUseCase() application.ToolsUseCase {
+	return struct{ application.ToolsUseCase }{}
+}
+
+func (noUseCases) AnalyticsUseCase() application.AnalyticsUseCase {
+	return struct{ application.AnalyticsUseCase }{}
+}
+
+func (noUseCases) ImageUseCase() application.ImageUseCase {
+	return struct{ application.ImageUseCase }{}
+}
+
+func newTestRouter() chi.Router {
+	router := chi.NewRouter()
+	NewHandlers(noUseCases{}, nil, zap.NewNop().Sugar()).RegisterRoutes(router)
+	return router
+}
+
+func TestOpenAPI_CoversAllRoutes(t *testing.T) {
+	doc := NewOpenAPIDocument()
+
+	routes := 0
+	err := chi.Walk(newTestRouter(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// Sub-routers register their index routes with a trailing slash
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		assert.True(t, doc.HasOperation(method, route), "%s %s is not documented in the OpenAPI document", method, route)
+		routes++
+		return nil
+	})
+	require.NoError(t, err)
+
+	operations := 0
+	for _, item := range doc.Paths {
+		operations += len(*item)
+	}
+	assert.Equal(t, routes, operations, "the OpenAPI document describes routes that are not registered")
+}
+
+func TestOpenAPI_SchemasFromRequestTypes(t *testing.T) {
+	doc := NewOpenAPIDocument()
+
+	s := doc.Components.Schemas["CreateMuscleRequest"]
+	require.NotNil(t, s)
+	assert.Equal(t, []string{"name"}, s.Required)
+	assert.Equal(t, 30, *s.Properties["name"].MaxLength)
+	assert.Equal(t, []string{"region", "group", "muscle", "head"}, s.Properties["kind"].Enum)
+	assert.Equal(t, "uuid", s.Properties["parent_id"].Format)
+
+	tree := doc.Components.Schemas["MuscleTreeResponse"]
+	require.NotNil(t, tree)
+	assert.Contains(t, tree.Properties, "name", "embedded fields are flattened")
+	assert.Equal(t, "#/components/schemas/MuscleTreeResponse", tree.Properties["children"].Items.Ref)
+}
+
+func TestDocsRoutes(t *testing.T) {
+	router := newTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var doc map[string]any
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&doc))
+	assert.Equal(t, "3.0.3", doc["openapi"])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "SwaggerUIBundle")
+}
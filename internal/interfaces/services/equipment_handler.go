@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// EquipmentHandler handles HTTP requests related to equipment resources.
+type EquipmentHandler struct {
+	equipmentUseCase application.EquipmentUseCase
+	logger           *zap.SugaredLogger
+	responseHelper   *ResponseHelper
+}
+
+// NewEquipmentHandler creates a new equipment handler with the specified dependencies.
+func NewEquipmentHandler(equipmentUseCase application.EquipmentUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *EquipmentHandler {
+	return &EquipmentHandler{
+		equipmentUseCase: equipmentUseCase,
+		logger:           logger,
+		responseHelper:   responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all equipment-related routes on the provided router.
+func (h *EquipmentHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/equipment", func(r chi.Router) {
+		r.Get("/", h.GetEquipment)
+
+		r.Route("/profiles", func(r chi.Router) {
+			r.Get("/", h.GetProfiles)
+			r.Post("/", h.CreateProfile)
+			r.Get("/{profileID}", h.GetProfileByID)
+			r.Put("/{profileID}", h.UpdateProfile)
+			r.Delete("/{profileID}", h.DeleteProfile)
+			r.Put("/{profileID}/equipment/{equipmentID}", h.profileEquipmentHandler(h.equipmentUseCase.AddProfileEquipment))
+			r.Delete("/{profileID}/equipment/{equipmentID}", h.profileEquipmentHandler(h.equipmentUseCase.RemoveProfileEquipment))
+		})
+
+		r.Get("/{equipmentID}", h.GetEquipmentByID)
+		r.Post("/", h.CreateEquipment)
+		r.Put("/{equipmentID}", h.UpdateEquipment)
+		r.Delete("/{equipmentID}", h.DeleteEquipment)
+	})
+}
+
+// EquipmentListResponse represents a collection of equipment responses
+type EquipmentListResponse []EquipmentResponse
+
+// EquipmentRequest defines the expected structure for equipment creation and rename requests.
+type EquipmentRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// CreateEquipmentResponse defines the response structure after successful equipment creation.
+type CreateEquipmentResponse struct {
+	ID string `json:"id"`
+}
+
+// EquipmentResponse defines the standard response structure for equipment data.
+type EquipmentResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// EquipmentProfileListResponse represents a collection of equipment profile responses
+type EquipmentProfileListResponse []EquipmentProfileResponse
+
+// EquipmentProfileRequest defines the expected structure for equipment profile creation and replacement requests.
+type EquipmentProfileRequest struct {
+	Name         string   `json:"name" validate:"required,max=100"`
+	EquipmentIDs []string `json:"equipment_ids" validate:"unique,dive,uuid"`
+}
+
+// CreateEquipmentProfileResponse defines the response structure after successful equipment profile creation.
+type CreateEquipmentProfileResponse struct {
+	ID string `json:"id"`
+}
+
+// EquipmentProfileResponse defines the standard response structure for equipment profile data.
+type EquipmentProfileResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	EquipmentIDs []string  `json:"equipment_ids"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CreateEquipment handles POST requests to create new equipment.
+func (h *EquipmentHandler) CreateEquipment(w http.ResponseWriter, r *http.Request) {
+	var payload EquipmentRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	domainEquipment, err := equipment.NewEquipment(equipment.EquipmentParams{Name: payload.Name})
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.equipmentUseCase.CreateEquipment(r.Context(), getUserIDFromContext(r.Context()), domainEquipment); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := CreateEquipmentResponse{
+		ID: domainEquipment.GetId().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetEquipment handles GET requests to retrieve a page of the equipment for the current user.
+func (h *EquipmentHandler) GetEquipment(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.equipmentUseCase.ListEquipmentForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(EquipmentListResponse, 0, len(page.Items))
+
+	for _, e := range page.Items {
+		responseBody = append(responseBody, newEquipmentResponse(e))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetEquipmentByID handles GET requests to retrieve equipment by ID for the current user.
+func (h *EquipmentHandler) GetEquipmentByID(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "equipmentID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainEquipment, err := h.equipmentUseCase.GetEquipmentByID(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newEquipmentResponse(domainEquipment)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// UpdateEquipment handles PUT requests to rename equipment for the current user.
+func (h *EquipmentHandler) UpdateEquipment(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "equipmentID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	var payload EquipmentRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	domainEquipment, err := h.equipmentUseCase.RenameEquipment(r.Context(), getUserIDFromContext(r.Context()), id, payload.Name)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newEquipmentResponse(domainEquipment)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// DeleteEquipment handles DELETE requests to delete equipment for the current user by ID.
+func (h *EquipmentHandler) DeleteEquipment(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "equipmentID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.equipmentUseCase.DeleteEquipment(r.Context(), getUserIDFromContext(r.Context()), id); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newEquipmentResponse converts domain equipment to its response representation.
+func newEquipmentResponse(e *equipment.Equipment) EquipmentResponse {
+	return EquipmentResponse{
+		ID:   e.GetId().String(),
+		Name: e.GetName(),
+	}
+}
+
+// CreateProfile handles POST requests to create a new equipment profile.
+func (h *EquipmentHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	profile, ok := h.readProfile(w, r, nil)
+	if !ok {
+		return
+	}
+
+	if err := h.equipmentUseCase.CreateProfile(r.Context(), getUserIDFromContext(r.Context()), profile); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := CreateEquipmentProfileResponse{
+		ID: profile.GetID().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetProfiles handles GET requests to retrieve a page of the equipment profiles for the current user.
+func (h *EquipmentHandler) GetProfiles(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.equipmentUseCase.ListProfilesForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(EquipmentProfileListResponse, 0, len(page.Items))
+
+	for _, p := range page.Items {
+		responseBody = append(responseBody, newEquipmentProfileResponse(p))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetProfileByID handles GET requests to retrieve an equipment profile by ID for the current user.
+func (h *EquipmentHandler) GetProfileByID(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "profileID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	profile, err := h.equipmentUseCase.GetProfileByID(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newEquipmentProfileResponse(profile)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// UpdateProfile handles PUT requests to replace an equipment profile for the current user.
+func (h *EquipmentHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "profileID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	userID := getUserIDFromContext(r.Context())
+
+	existing, err := h.equipmentUseCase.GetProfileByID(r.Context(), userID, id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	profile, ok := h.readProfile(w, r, existing)
+	if !ok {
+		return
+	}
+
+	if err := h.equipmentUseCase.UpdateProfile(r.Context(), userID, profile); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newEquipmentProfileResponse(profile)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// DeleteProfile handles DELETE requests to delete an equipment profile for the current user by ID.
+// The equipment in the profile is kept.
+func (h *EquipmentHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "profileID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.equipmentUseCase.DeleteProfile(r.Context(), getUserIDFromContext(r.Context()), id); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// profileEquipmentHandler builds a handler that adds or removes the equipment in the URL
+// to or from a profile with fn and responds with the updated profile.
+func (h *EquipmentHandler) profileEquipmentHandler(fn func(ctx context.Context, userID, profileID, equipmentID uuid.UUID) (*equipment.Profile, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		profileID, err := parseUUIDParam(r, "profileID")
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		equipmentID, err := parseUUIDParam(r, "equipmentID")
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		profile, err := fn(r.Context(), getUserIDFromContext(r.Context()), profileID, equipmentID)
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		if err := h.responseHelper.jsonResponse(w, http.StatusOK, newEquipmentProfileResponse(profile)); err != nil {
+			h.responseHelper.internalServerError(w, r, err)
+			return
+		}
+	}
+}
+
+// readProfile decodes and validates an EquipmentProfileRequest into a domain profile.
+// When existing is set the result replaces it and keeps its identity and creation time.
+// It writes the error response itself and reports whether decoding succeeded.
+func (h *EquipmentHandler) readProfile(w http.ResponseWriter, r *http.Request, existing *equipment.Profile) (*equipment.Profile, bool) {
+	var payload EquipmentProfileRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return nil, false
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return nil, false
+	}
+
+	params := equipment.ProfileParams{
+		Name:         payload.Name,
+		EquipmentIDs: mustParseUUIDs(payload.EquipmentIDs),
+	}
+	if existing != nil {
+		params.ID = existing.GetID()
+		params.CreatedAt = existing.GetCreatedAt()
+	}
+
+	profile, err := equipment.NewProfile(params)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return nil, false
+	}
+
+	return profile, true
+}
+
+// newEquipmentProfileResponse converts a domain equipment profile to its response representation.
+func newEquipmentProfileResponse(p *equipment.Profile) EquipmentProfileResponse {
+	return EquipmentProfileResponse{
+		ID:           p.GetID().String(),
+		Name:         p.GetName(),
+		EquipmentIDs: uuidsToStrings(p.GetEquipmentIDs()),
+		CreatedAt:    p.GetCreatedAt(),
+		UpdatedAt:    p.GetUpdatedAt(),
+	}
+}
@@ -0,0 +1,805 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/record"
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ExerciseHandler handles HTTP requests related to exercise resources.
+type ExerciseHandler struct {
+	exerciseUseCase application.ExerciseUseCase
+	imageUseCase    application.ImageUseCase
+	logger          *zap.SugaredLogger
+	responseHelper  *ResponseHelper
+}
+
+// NewExerciseHandler creates a new exercise handler with the specified dependencies.
+// The image use case signs the image URLs of the exercises in responses.
+func NewExerciseHandler(exerciseUseCase application.ExerciseUseCase, imageUseCase application.ImageUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *ExerciseHandler {
+	return &ExerciseHandler{
+		exerciseUseCase: exerciseUseCase,
+		imageUseCase:    imageUseCase,
+		logger:          logger,
+		responseHelper:  responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all exercise-related routes on the provided router.
+func (h *ExerciseHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/exercises", func(r chi.Router) {
+		r.Get("/", h.GetExercises)
+		r.Post("/", h.CreateExercise)
+		r.Get("/search", h.SearchExercises)
+
+		r.Route("/{exerciseID}", func(r chi.Router) {
+			r.Get("/", h.GetExerciseByID)
+			r.Put("/", h.UpdateExercise)
+			r.Delete("/", h.DeleteExercise)
+			r.Get("/records", h.GetExerciseRecords)
+			r.Get("/one-rep-max", h.GetOneRepMaxHistory)
+			r.Get("/alternatives", h.GetExerciseAlternatives)
+			r.Put("/image", h.UploadExerciseImage)
+			r.Delete("/image", h.DeleteExerciseImage)
+
+			r.Put("/muscles/{muscleID}", h.AttachTargetMuscle)
+			r.Delete("/muscles/{muscleID}", h.linkHandler("muscleID", h.exerciseUseCase.DetachTargetMuscle))
+			r.Put("/equipment/{equipmentID}", h.linkHandler("equipmentID", h.exerciseUseCase.AttachEquipment))
+			r.Delete("/equipment/{equipmentID}", h.linkHandler("equipmentID", h.exerciseUseCase.DetachEquipment))
+			r.Put("/splits/{splitID}", h.linkHandler("splitID", h.exerciseUseCase.AttachSplit))
+			r.Delete("/splits/{splitID}", h.linkHandler("splitID", h.exerciseUseCase.DetachSplit))
+		})
+	})
+}
+
+// ExerciseListResponse represents a collection of exercise responses
+type ExerciseListResponse []ExerciseResponse
+
+// ExerciseRequest defines the expected structure for exercise creation and replacement requests.
+type ExerciseRequest struct {
+	Name            string   `json:"name" validate:"required,max=255"`
+	Description     string   `json:"description" validate:"max=2000"`
+	Aliases         []string `json:"aliases" validate:"max=20,dive,max=100"`
+	Category        string   `json:"category" validate:"max=100"`
+	MovementPattern string   `json:"movement_pattern" validate:"omitempty,oneof=squat hinge lunge horizontal_push vertical_push horizontal_pull vertical_pull carry"`
+	TargetMuscleIDs []string `json:"target_muscle_ids" validate:"unique,dive,uuid"`
+	EquipmentIDs    []string `json:"equipment_ids" validate:"unique,dive,uuid"`
+	SplitIDs        []string `json:"split_ids" validate:"unique,dive,uuid"`
+	// TargetMuscles links target muscles with their roles. It replaces TargetMuscleIDs, which links
+	// the first muscle as primary and the others as secondary.
+	TargetMuscles []TargetMuscleRequest `json:"target_muscles" validate:"excluded_with=TargetMuscleIDs,unique=MuscleID,dive"`
+}
+
+// TargetMuscleLinkRequest defines the expected structure for the role and activation of a target muscle link.
+// The role defaults to primary and a missing activation to the default of the role.
+type TargetMuscleLinkRequest struct {
+	Role       string   `json:"role" validate:"omitempty,oneof=primary secondary stabilizer"`
+	Activation *float64 `json:"activation" validate:"omitempty,gt=0,max=1"`
+}
+
+// TargetMuscleRequest defines the expected structure for a target muscle link in an exercise request.
+type TargetMuscleRequest struct {
+	MuscleID string `json:"muscle_id" validate:"required,uuid"`
+	TargetMuscleLinkRequest
+}
+
+// link converts the request to a domain target muscle link of the muscle
+func (req TargetMuscleLinkRequest) link(muscleID uuid.UUID) exercise.TargetMuscle {
+	role := exercise.MuscleRole(req.Role)
+	if role == "" {
+		role = exercise.RolePrimary
+	}
+	return exercise.TargetMuscle{MuscleID: muscleID, Role: role, Activation: req.Activation}
+}
+
+// CreateExerciseResponse defines the response structure after successful exercise creation.
+type CreateExerciseResponse struct {
+	ID string `json:"id"`
+}
+
+// ExerciseResponse defines the standard response structure for exercise data.
+type ExerciseResponse struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	Aliases         []string  `json:"aliases"`
+	DisplayImage    string    `json:"display_image"`
+	ThumbnailImage  string    `json:"thumbnail_image"`
+	Category        string    `json:"category"`
+	MovementPattern string    `json:"movement_pattern"`
+	TargetMuscleIDs []string  `json:"target_muscle_ids"`
+	EquipmentIDs    []string  `json:"equipment_ids"`
+	SplitIDs        []string  `json:"split_ids"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// TargetMuscles holds the same muscles as TargetMuscleIDs with their roles and activations
+	TargetMuscles []TargetMuscleResponse `json:"target_muscles"`
+}
+
+// TargetMuscleResponse defines the response structure for a target muscle link of an exercise.
+// Activation is null when the link uses the default of its role.
+type TargetMuscleResponse struct {
+	MuscleID   string   `json:"muscle_id"`
+	Role       string   `json:"role"`
+	Activation *float64 `json:"activation"`
+}
+
+// ExerciseHighlightsResponse defines the response structure for the parts of an exercise that matched a search.
+// The text is HTML-escaped and matched words are wrapped in <mark> and </mark>, so it can be rendered as HTML.
+type ExerciseHighlightsResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+}
+
+// ExerciseSearchResultResponse defines the response structure for an exercise found by a search.
+// Rank is only comparable between results of the same search.
+type ExerciseSearchResultResponse struct {
+	Exercise   ExerciseResponse           `json:"exercise"`
+	Rank       float64                    `json:"rank"`
+	Highlights ExerciseHighlightsResponse `json:"highlights"`
+}
+
+// ExerciseSearchResponse defines the response structure for an exercise search, the most relevant result first.
+type ExerciseSearchResponse struct {
+	Query   string                         `json:"query"`
+	Results []ExerciseSearchResultResponse `json:"results"`
+}
+
+// ExerciseAlternativeResponse defines the response structure for an exercise that can replace another one.
+// Score grows from 0 to 1 with how well it replaces it, muscle_overlap is the share of primary target muscles
+// both exercises have in common and available reports whether the user owns all of its equipment.
+type ExerciseAlternativeResponse struct {
+	Exercise      ExerciseResponse `json:"exercise"`
+	Score         float64          `json:"score"`
+	MuscleOverlap float64          `json:"muscle_overlap"`
+	SamePattern   bool             `json:"same_pattern"`
+	Available     bool             `json:"available"`
+}
+
+// ExerciseAlternativesResponse defines the response structure for the alternatives to an exercise, the best first.
+type ExerciseAlternativesResponse struct {
+	ExerciseID   string                        `json:"exercise_id"`
+	Alternatives []ExerciseAlternativeResponse `json:"alternatives"`
+}
+
+// RecordResponse defines the response structure for a personal record.
+// Weights and volumes are in kg, Weight is only set for reps_at_weight records.
+type RecordResponse struct {
+	ID         string    `json:"id"`
+	ExerciseID string    `json:"exercise_id"`
+	SessionID  string    `json:"session_id"`
+	SetID      string    `json:"set_id"`
+	Kind       string    `json:"kind"`
+	Value      float64   `json:"value"`
+	Weight     float64   `json:"weight"`
+	Unit       string    `json:"unit"`
+	AchievedAt time.Time `json:"achieved_at"`
+}
+
+// ExerciseRecordsResponse defines the response structure for the personal records of an exercise.
+// Current holds the standing records, History every record ever set from the oldest to the newest.
+type ExerciseRecordsResponse struct {
+	Current []RecordResponse `json:"current"`
+	History []RecordResponse `json:"history"`
+}
+
+// OneRepMaxPointResponse defines the response structure for the best estimated one-rep max of a session, in kg.
+type OneRepMaxPointResponse struct {
+	SessionID string    `json:"session_id"`
+	Date      time.Time `json:"date"`
+	SetID     string    `json:"set_id"`
+	Weight    float64   `json:"weight"`
+	Reps      int       `json:"reps"`
+	OneRepMax float64   `json:"one_rep_max"`
+}
+
+// OneRepMaxHistoryResponse defines the response structure for the estimated one-rep max of an exercise over time.
+type OneRepMaxHistoryResponse struct {
+	Formula string                   `json:"formula"`
+	Unit    string                   `json:"unit"`
+	Points  []OneRepMaxPointResponse `json:"points"`
+}
+
+// CreateExercise handles POST requests to create a new exercise.
+func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
+	domainExercise, ok := h.readExercise(w, r, nil)
+	if !ok {
+		return
+	}
+
+	if err := h.exerciseUseCase.CreateExercise(r.Context(), getUserIDFromContext(r.Context()), domainExercise); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := CreateExerciseResponse{
+		ID: domainExercise.GetID().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetExercises handles GET requests to retrieve a page of the exercises for the current user.
+// With available_only=true only the exercises that can be done with the user's equipment profiles are listed.
+func (h *ExerciseHandler) GetExercises(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	availableOnly, profile, err := parseAvailability(r)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	var page listquery.Page[*exercise.Exercise]
+	if availableOnly {
+		page, err = h.exerciseUseCase.ListAvailableExercises(r.Context(), getUserIDFromContext(r.Context()), q, profile)
+	} else {
+		page, err = h.exerciseUseCase.ListExercisesForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	}
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(ExerciseListResponse, 0, len(page.Items))
+
+	for _, e := range page.Items {
+		responseBody = append(responseBody, newExerciseResponse(e, h.imageUseCase))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// parseAvailability reads the available_only and profile query parameters of the exercise list.
+// A profile is only accepted together with available_only=true, malformed values are returned as a requestError.
+func parseAvailability(r *http.Request) (bool, string, error) {
+	values := r.URL.Query()
+
+	availableOnly := false
+	if v := values.Get("available_only"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, "", &requestError{"available_only must be true or false"}
+		}
+		availableOnly = b
+	}
+
+	profile := values.Get("profile")
+	if profile != "" && !availableOnly {
+		return false, "", &requestError{"profile can only be used with available_only=true"}
+	}
+
+	return availableOnly, profile, nil
+}
+
+// SearchExercises handles GET requests to search the exercises of the current user by name, alias and description.
+// The muscle_id, equipment_id, split_id and category query parameters can be repeated, results must match
+// one of the values of every filter that is given.
+func (h *ExerciseHandler) SearchExercises(w http.ResponseWriter, r *http.Request) {
+	params, err := parseSearchParams(r)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	q, err := exercise.NewSearchQuery(params)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	results, err := h.exerciseUseCase.SearchExercises(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := ExerciseSearchResponse{
+		Query:   q.Text,
+		Results: make([]ExerciseSearchResultResponse, 0, len(results)),
+	}
+	for _, res := range results {
+		responseBody.Results = append(responseBody.Results, ExerciseSearchResultResponse{
+			Exercise: newExerciseResponse(res.Exercise, h.imageUseCase),
+			Rank:     res.Rank,
+			Highlights: ExerciseHighlightsResponse{
+				Name:        res.Highlights.Name,
+				Description: res.Highlights.Description,
+				Aliases:     res.Highlights.Aliases,
+			},
+		})
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// parseSearchParams reads the search text, filters and limit from the query parameters.
+// Malformed UUIDs and limits are returned as a requestError.
+func parseSearchParams(r *http.Request) (exercise.SearchParams, error) {
+	values := r.URL.Query()
+	params := exercise.SearchParams{Text: values.Get("q"), Categories: values["category"]}
+
+	var err error
+	if params.MuscleIDs, err = parseUUIDQueryParam(r, "muscle_id"); err != nil {
+		return exercise.SearchParams{}, err
+	}
+	if params.EquipmentIDs, err = parseUUIDQueryParam(r, "equipment_id"); err != nil {
+		return exercise.SearchParams{}, err
+	}
+	if params.SplitIDs, err = parseUUIDQueryParam(r, "split_id"); err != nil {
+		return exercise.SearchParams{}, err
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		if params.Limit, err = strconv.Atoi(limit); err != nil {
+			return exercise.SearchParams{}, exercise.ErrInvalidSearchLimit
+		}
+	}
+
+	return params, nil
+}
+
+// GetExerciseByID handles GET requests to retrieve an exercise by ID for the current user.
+func (h *ExerciseHandler) GetExerciseByID(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainExercise, err := h.exerciseUseCase.GetExerciseByID(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetExerciseRecords handles GET requests to retrieve the current and past personal records of an exercise.
+func (h *ExerciseHandler) GetExerciseRecords(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	records, err := h.exerciseUseCase.ListRecords(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := ExerciseRecordsResponse{
+		Current: newRecordResponses(record.Current(records)),
+		History: newRecordResponses(records),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetOneRepMaxHistory handles GET requests to retrieve the estimated one-rep max of an exercise
+// in every session it was logged in. The formula query parameter overrides the user's preferred formula.
+func (h *ExerciseHandler) GetOneRepMaxHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	formula := strength.Formula(r.URL.Query().Get("formula"))
+
+	formula, points, err := h.exerciseUseCase.OneRepMaxHistory(r.Context(), getUserIDFromContext(r.Context()), id, formula)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := OneRepMaxHistoryResponse{
+		Formula: string(formula),
+		Unit:    string(workout.UnitKg),
+		Points:  make([]OneRepMaxPointResponse, 0, len(points)),
+	}
+	for _, p := range points {
+		responseBody.Points = append(responseBody.Points, OneRepMaxPointResponse{
+			SessionID: p.SessionID.String(),
+			Date:      p.Date,
+			SetID:     p.SetID.String(),
+			Weight:    p.Weight,
+			Reps:      p.Reps,
+			OneRepMax: p.OneRepMax,
+		})
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetExerciseAlternatives handles GET requests to rank the exercises that can replace an exercise.
+// The profile query parameter names the equipment profile whose equipment counts as owned,
+// all of the user's profiles count when it is missing.
+func (h *ExerciseHandler) GetExerciseAlternatives(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			h.responseHelper.errorResponse(w, r, exercise.ErrInvalidAlternativesLimit)
+			return
+		}
+	}
+
+	alternatives, err := h.exerciseUseCase.ListAlternatives(r.Context(), getUserIDFromContext(r.Context()), id, r.URL.Query().Get("profile"), limit)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := ExerciseAlternativesResponse{
+		ExerciseID:   id.String(),
+		Alternatives: make([]ExerciseAlternativeResponse, 0, len(alternatives)),
+	}
+	for _, a := range alternatives {
+		responseBody.Alternatives = append(responseBody.Alternatives, ExerciseAlternativeResponse{
+			Exercise:      newExerciseResponse(a.Exercise, h.imageUseCase),
+			Score:         a.Score,
+			MuscleOverlap: a.MuscleOverlap,
+			SamePattern:   a.SamePattern,
+			Available:     a.Available,
+		})
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// UpdateExercise handles PUT requests to replace an exercise for the current user.
+func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	userID := getUserIDFromContext(r.Context())
+
+	existing, err := h.exerciseUseCase.GetExerciseByID(r.Context(), userID, id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainExercise, ok := h.readExercise(w, r, existing)
+	if !ok {
+		return
+	}
+
+	if err := h.exerciseUseCase.UpdateExercise(r.Context(), userID, domainExercise); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// DeleteExercise handles DELETE requests to delete an exercise for the current user by ID.
+func (h *ExerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.exerciseUseCase.DeleteExercise(r.Context(), getUserIDFromContext(r.Context()), id); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UploadExerciseImage handles PUT requests to set the display image of an exercise from the "image" field
+// of a multipart form. The image replaces the current one and is served with a thumbnail from signed URLs.
+func (h *ExerciseHandler) UploadExerciseImage(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, imaging.MaxUploadSize+maxMultipartOverhead)
+	upload, err := imageUpload(r)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainExercise, err := h.exerciseUseCase.SetDisplayImage(r.Context(), getUserIDFromContext(r.Context()), id, upload)
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		err = imaging.ErrTooLarge
+	}
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// DeleteExerciseImage handles DELETE requests to remove the display image of an exercise.
+func (h *ExerciseHandler) DeleteExerciseImage(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainExercise, err := h.exerciseUseCase.RemoveDisplayImage(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// AttachTargetMuscle handles PUT requests to link a target muscle to an exercise, or to change the role
+// and activation of an existing link. The body is optional, without one the muscle is linked as primary.
+func (h *ExerciseHandler) AttachTargetMuscle(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := parseUUIDParam(r, "exerciseID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	muscleID, err := parseUUIDParam(r, "muscleID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	var payload TargetMuscleLinkRequest
+	if r.ContentLength != 0 {
+		if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+			h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+			return
+		}
+	}
+
+	domainExercise, err := h.exerciseUseCase.AttachTargetMuscle(r.Context(), getUserIDFromContext(r.Context()), exerciseID, payload.link(muscleID))
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// linkHandler builds a handler that attaches or detaches the resource named by param
+// to the exercise in the URL and responds with the updated exercise.
+func (h *ExerciseHandler) linkHandler(param string, fn func(ctx context.Context, userID, exerciseID, linkedID uuid.UUID) (*exercise.Exercise, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exerciseID, err := parseUUIDParam(r, "exerciseID")
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		linkedID, err := parseUUIDParam(r, param)
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		domainExercise, err := fn(r.Context(), getUserIDFromContext(r.Context()), exerciseID, linkedID)
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		if err := h.responseHelper.jsonResponse(w, http.StatusOK, newExerciseResponse(domainExercise, h.imageUseCase)); err != nil {
+			h.responseHelper.internalServerError(w, r, err)
+			return
+		}
+	}
+}
+
+// maxMultipartOverhead is the room left for the boundaries and headers of a multipart upload
+const maxMultipartOverhead = 1 << 16
+
+// imageUpload returns the "image" part of a multipart form without buffering it.
+// A body that is not a multipart form or has no image part is returned as a requestError.
+func imageUpload(r *http.Request) (io.Reader, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, &requestError{"body must be a multipart/form-data form"}
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, &requestError{"body must contain an image field"}
+		}
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, imaging.ErrTooLarge
+		}
+		if err != nil {
+			return nil, &requestError{"body contains a malformed multipart form"}
+		}
+		if part.FormName() == "image" {
+			return part, nil
+		}
+	}
+}
+
+// readExercise decodes and validates an ExerciseRequest into a domain exercise.
+// When existing is set the result replaces it and keeps its identity, creation time and image.
+// It writes the error response itself and reports whether decoding succeeded.
+func (h *ExerciseHandler) readExercise(w http.ResponseWriter, r *http.Request, existing *exercise.Exercise) (*exercise.Exercise, bool) {
+	var payload ExerciseRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return nil, false
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return nil, false
+	}
+
+	params := exercise.ExerciseParams{
+		Name:            payload.Name,
+		Description:     payload.Description,
+		Aliases:         payload.Aliases,
+		Category:        payload.Category,
+		MovementPattern: exercise.MovementPattern(payload.MovementPattern),
+		TargetMuscles:   targetMuscles(payload),
+		EquipmentIDs:    mustParseUUIDs(payload.EquipmentIDs),
+		SplitIDs:        mustParseUUIDs(payload.SplitIDs),
+	}
+	if existing != nil {
+		params.ID = existing.GetID()
+		params.ImageID = existing.GetImageID()
+		params.CreatedAt = existing.GetCreatedAt()
+	}
+
+	domainExercise, err := exercise.NewExercise(params)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return nil, false
+	}
+
+	return domainExercise, true
+}
+
+// targetMuscles converts the target muscles of an exercise request to domain links
+func targetMuscles(payload ExerciseRequest) []exercise.TargetMuscle {
+	links := make([]exercise.TargetMuscle, 0, len(payload.TargetMuscles)+len(payload.TargetMuscleIDs))
+	for _, t := range payload.TargetMuscles {
+		links = append(links, t.link(uuid.MustParse(t.MuscleID)))
+	}
+	for i, id := range mustParseUUIDs(payload.TargetMuscleIDs) {
+		role := exercise.RoleSecondary
+		if i == 0 {
+			role = exercise.RolePrimary
+		}
+		links = append(links, exercise.TargetMuscle{MuscleID: id, Role: role})
+	}
+	return links
+}
+
+// newExerciseResponse converts a domain exercise to its response representation.
+// The image URLs are signed anew for every response, so they expire instead of being stored.
+func newExerciseResponse(e *exercise.Exercise, images application.ImageUseCase) ExerciseResponse {
+	var displayImage, thumbnailImage string
+	if id := e.GetImageID(); id != uuid.Nil {
+		original, thumbnail := images.ImageURL(id, application.ImageOriginal), images.ImageURL(id, application.ImageThumbnail)
+		displayImage, thumbnailImage = original.String(), thumbnail.String()
+	}
+
+	targetMuscles := make([]TargetMuscleResponse, 0, len(e.GetTargetMuscles()))
+	for _, t := range e.GetTargetMuscles() {
+		targetMuscles = append(targetMuscles, TargetMuscleResponse{
+			MuscleID:   t.MuscleID.String(),
+			Role:       string(t.Role),
+			Activation: t.Activation,
+		})
+	}
+
+	return ExerciseResponse{
+		ID:              e.GetID().String(),
+		Name:            e.GetName(),
+		Description:     e.GetDescription(),
+		Aliases:         e.GetAliases(),
+		DisplayImage:    displayImage,
+		ThumbnailImage:  thumbnailImage,
+		Category:        e.GetCategory(),
+		MovementPattern: string(e.GetMovementPattern()),
+		TargetMuscleIDs: uuidsToStrings(e.GetTargetMuscleIDs()),
+		EquipmentIDs:    uuidsToStrings(e.GetEquipments()),
+		SplitIDs:        uuidsToStrings(e.GetSplits()),
+		CreatedAt:       e.GetCreatedAt(),
+		UpdatedAt:       e.GetUpdatedAt(),
+		TargetMuscles:   targetMuscles,
+	}
+}
+
+// newRecordResponses converts domain personal records to their response representation.
+func newRecordResponses(records []*record.Record) []RecordResponse {
+	responses := make([]RecordResponse, 0, len(records))
+	for _, rec := range records {
+		responses = append(responses, RecordResponse{
+			ID:         rec.GetID().String(),
+			ExerciseID: rec.GetExerciseID().String(),
+			SessionID:  rec.GetSessionID().String(),
+			SetID:      rec.GetSetID().String(),
+			Kind:       string(rec.GetKind()),
+			Value:      rec.GetValue(),
+			Weight:     rec.GetWeight(),
+			Unit:       string(workout.UnitKg),
+			AchievedAt: rec.GetAchievedAt(),
+		})
+	}
+	return responses
+}
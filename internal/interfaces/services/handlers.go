@@ -1,26 +1,81 @@
 package services
 
 import (
+	"net/http"
+
 	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
 // Handlers holds all HTTP handlers for the application
 type Handlers struct {
-	muscle *MuscleHandler
+	auth      *AuthHandler
+	muscle    *MuscleHandler
+	exercise  *ExerciseHandler
+	equipment *EquipmentHandler
+	split     *SplitHandler
+	workout   *WorkoutHandler
+	routine   *RoutineHandler
+	settings  *SettingsHandler
+	tools     *ToolsHandler
+	analytics *AnalyticsHandler
+	image     *ImageHandler
+	docs      *DocsHandler
 	// More handlers to be added
+
+	authMiddleware func(http.Handler) http.Handler
+	responseHelper *ResponseHelper
 }
 
 // NewHandlers creates and initializes all handlers with their required dependencies.
-func NewHandlers(useCases application.UseCases, logger *zap.SugaredLogger) *Handlers {
+func NewHandlers(useCases application.UseCases, authenticator auth.Authenticator, logger *zap.SugaredLogger) *Handlers {
 	responseHelper := NewResponseHelper(logger)
 	return &Handlers{
-		muscle: NewMuscleHandler(useCases.MuscleUseCase(), logger, responseHelper),
+		auth:           NewAuthHandler(useCases.AuthUseCase(), logger, responseHelper),
+		muscle:         NewMuscleHandler(useCases.MuscleUseCase(), logger, responseHelper),
+		exercise:       NewExerciseHandler(useCases.ExerciseUseCase(), useCases.ImageUseCase(), logger, responseHelper),
+		equipment:      NewEquipmentHandler(useCases.EquipmentUseCase(), logger, responseHelper),
+		split:          NewSplitHandler(useCases.SplitUseCase(), logger, responseHelper),
+		workout:        NewWorkoutHandler(useCases.WorkoutUseCase(), logger, responseHelper),
+		routine:        NewRoutineHandler(useCases.RoutineUseCase(), logger, responseHelper),
+		settings:       NewSettingsHandler(useCases.SettingsUseCase(), logger, responseHelper),
+		tools:          NewToolsHandler(useCases.ToolsUseCase(), logger, responseHelper),
+		analytics:      NewAnalyticsHandler(useCases.AnalyticsUseCase(), logger, responseHelper),
+		image:          NewImageHandler(useCases.ImageUseCase(), logger, responseHelper),
+		docs:           NewDocsHandler(),
+		authMiddleware: AuthTokenMiddleware(authenticator, responseHelper),
+		responseHelper: responseHelper,
 	}
 }
 
-// RegisterRoutes registers all handler routes with the provided router
+// RegisterRoutes registers all handler routes with the provided router.
+// Every route except the auth, documentation and image routes requires a valid access token,
+// images are protected by the signature of their URL instead.
+// Unknown routes and methods are answered with problem details like every other error.
 func (h *Handlers) RegisterRoutes(router chi.Router) {
-	h.muscle.RegisterRoutes(router)
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		h.responseHelper.errorResponse(w, r, errRouteNotFound)
+	})
+	router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		h.responseHelper.errorResponse(w, r, errMethodNotAllowed)
+	})
+
+	h.docs.RegisterRoutes(router)
+	h.auth.RegisterRoutes(router)
+	h.image.RegisterRoutes(router)
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.authMiddleware)
+		h.muscle.RegisterRoutes(r)
+		h.exercise.RegisterRoutes(r)
+		h.equipment.RegisterRoutes(r)
+		h.split.RegisterRoutes(r)
+		h.workout.RegisterRoutes(r)
+		h.routine.RegisterRoutes(r)
+		h.settings.RegisterRoutes(r)
+		h.tools.RegisterRoutes(r)
+		h.analytics.RegisterRoutes(r)
+	})
 }
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// ImageHandler serves uploaded images from their signed URLs.
+type ImageHandler struct {
+	imageUseCase   application.ImageUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewImageHandler creates a new image handler with the specified dependencies.
+func NewImageHandler(imageUseCase application.ImageUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *ImageHandler {
+	return &ImageHandler{
+		imageUseCase:   imageUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up the image routes on the provided router.
+// The routes are public, the signature in the URL is what grants access to an image.
+func (h *ImageHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/images/{imageID}", func(r chi.Router) {
+		r.Get("/", h.imageHandler(application.ImageOriginal))
+		r.Get("/thumbnail", h.imageHandler(application.ImageThumbnail))
+	})
+}
+
+// imageHandler builds a handler that serves a variant of the image in the URL.
+// An image never changes once uploaded, so clients may cache it until its URL expires.
+func (h *ImageHandler) imageHandler(variant application.ImageVariant) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUUIDParam(r, "imageID")
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		query := r.URL.Query()
+		body, info, err := h.imageUseCase.OpenImage(r.Context(), id, variant, query)
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+		defer body.Close()
+
+		w.Header().Set("Content-Type", info.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+		w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(secondsUntil(query.Get(storage.ExpiresParam)), 10)+", immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+
+		if _, err := io.Copy(w, body); err != nil {
+			h.logger.Warnw("image response interrupted", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+		}
+	}
+}
+
+// secondsUntil returns how many seconds are left until the Unix time of a verified URL expiry
+func secondsUntil(expires string) int64 {
+	unix, _ := strconv.ParseInt(expires, 10, 64)
+	return max(unix-time.Now().Unix(), 0)
+}
@@ -17,6 +17,7 @@ func init() {
 
 // readJSON decodes a JSON request body into the provided struct.
 // It enforces a max request size and disallows unknown fields.
+// Errors are returned as a requestError that is safe to send to the client.
 func (rh *ResponseHelper) readJSON(w http.ResponseWriter, r *http.Request, data any) error {
 	maxBytes := 1_048_578
 
@@ -25,16 +26,10 @@ func (rh *ResponseHelper) readJSON(w http.ResponseWriter, r *http.Request, data
 	decoder := json.NewDecoder(r.Body)
 	decoder.DisallowUnknownFields()
 
-	return decoder.Decode(data)
-}
-
-// writeJSONError writes a standardized JSON error response.
-// It wraps the error message in a consistent envelope structure.
-func (rh *ResponseHelper) writeJSONError(w http.ResponseWriter, status int, message string) error {
-	type envelope struct {
-		Error string `json:"error"`
+	if err := decoder.Decode(data); err != nil {
+		return newRequestError(err, int64(maxBytes))
 	}
-	return rh.writeJSON(w, status, &envelope{Error: message})
+	return nil
 }
 
 // writeJSON marshals data to JSON and writes it to the response.
@@ -61,3 +56,21 @@ func (rh *ResponseHelper) jsonResponse(w http.ResponseWriter, status int, data a
 	}
 	return rh.writeJSON(w, status, &envelope{Data: data})
 }
+
+// listResponse sends one page of a list in the common envelope.
+// The cursor for the next page is returned as metadata and is null on the last page.
+func (rh *ResponseHelper) listResponse(w http.ResponseWriter, status int, data any, nextCursor string) error {
+	type meta struct {
+		NextCursor *string `json:"next_cursor"`
+	}
+	type envelope struct {
+		Data any  `json:"data"`
+		Meta meta `json:"meta"`
+	}
+
+	var m meta
+	if nextCursor != "" {
+		m.NextCursor = &nextCursor
+	}
+	return rh.writeJSON(w, status, &envelope{Data: data, Meta: m})
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+// userIDContextKey is the request context key holding the authenticated user's ID
+const userIDContextKey contextKey = "userID"
+
+var (
+	// errMissingAuthHeader is returned when a protected route is called without an Authorization header
+	errMissingAuthHeader = errors.New("authorization header is missing")
+
+	// errMalformedAuthHeader is returned when the Authorization header is not a Bearer token
+	errMalformedAuthHeader = errors.New("authorization header is malformed")
+)
+
+// AuthTokenMiddleware verifies the Bearer access token on the request and
+// stores the authenticated user's ID in the request context.
+func AuthTokenMiddleware(authenticator auth.Authenticator, rh *ResponseHelper) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authHeader := r.Header.Get("Authorization")
+			if authHeader == "" {
+				rh.errorResponse(w, r, errMissingAuthHeader)
+				return
+			}
+
+			scheme, token, found := strings.Cut(authHeader, " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				rh.errorResponse(w, r, errMalformedAuthHeader)
+				return
+			}
+
+			userID, err := authenticator.ValidateToken(token)
+			if err != nil {
+				rh.errorResponse(w, r, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// getUserIDFromContext returns the authenticated user's ID set by AuthTokenMiddleware.
+// It returns uuid.Nil when the request did not pass through the middleware.
+func getUserIDFromContext(ctx context.Context) uuid.UUID {
+	userID, ok := ctx.Value(userIDContextKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+	return userID
+}
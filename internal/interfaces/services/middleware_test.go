@@ -0,0 +1,104 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAuthTokenMiddleware(t *testing.T) {
+	const secret = "a-test-secret-that-is-long-enough"
+	authenticator := auth.NewJWTAuthenticator(secret, "exercise-api", "exercise-api", time.Minute)
+	userID := uuid.New()
+
+	var seen uuid.UUID
+	handler := AuthTokenMiddleware(authenticator, NewResponseHelper(zap.NewNop().Sugar()))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			seen = getUserIDFromContext(r.Context())
+			w.WriteHeader(http.StatusNoContent)
+		}),
+	)
+
+	token := func(a *auth.JWTAuthenticator) string {
+		t.Helper()
+		token, err := a.GenerateToken(userID)
+		require.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+		expectedDetail string
+	}{
+		{
+			name:           "Valid token",
+			header:         "Bearer " + token(authenticator),
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Missing header",
+			header:         "",
+			expectedStatus: http.StatusUnauthorized,
+			expectedDetail: "authorization header is missing",
+		},
+		{
+			name:           "Malformed header",
+			header:         "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+			expectedDetail: "authorization header is malformed",
+		},
+		{
+			name:           "Bearer without token",
+			header:         "Bearer ",
+			expectedStatus: http.StatusUnauthorized,
+			expectedDetail: "authorization header is malformed",
+		},
+		{
+			name:           "Bad signature",
+			header:         "Bearer " + token(auth.NewJWTAuthenticator("another-secret-that-is-long-enough", "exercise-api", "exercise-api", time.Minute)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedDetail: "invalid token",
+		},
+		{
+			name:           "Wrong audience",
+			header:         "Bearer " + token(auth.NewJWTAuthenticator(secret, "exercise-api", "another-api", time.Minute)),
+			expectedStatus: http.StatusUnauthorized,
+			expectedDetail: "invalid token",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = uuid.Nil
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/exercises", nil)
+			if tc.header != "" {
+				r.Header.Set("Authorization", tc.header)
+			}
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			if tc.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, userID, seen)
+				return
+			}
+
+			var p Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+			assert.Equal(t, "unauthorized", p.Code)
+			assert.Equal(t, tc.expectedDetail, p.Detail)
+			assert.Equal(t, uuid.Nil, seen, "the next handler must not run")
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package services
 
 import (
-	"errors"
+	"context"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/CP-Payne/exercise/internal/application"
 	"github.com/CP-Payne/exercise/internal/domain/muscle"
-	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -32,9 +34,15 @@ func NewMuscleHandler(muscleUseCase application.MuscleUseCase, logger *zap.Sugar
 func (h *MuscleHandler) RegisterRoutes(router chi.Router) {
 	router.Route("/muscles", func(r chi.Router) {
 		r.Get("/", h.GetMuscles)
+		r.Get("/hidden", h.GetHiddenMuscles)
 		r.Get("/{muscleID}", h.GetMuscleByID)
+		r.Get("/{muscleID}/tree", h.GetMuscleTree)
 		r.Post("/", h.CreateMuscle)
+		r.Put("/{muscleID}", h.ReplaceMuscle)
+		r.Patch("/{muscleID}", h.PatchMuscle)
 		r.Delete("/{muscleID}", h.DeleteMuscle)
+		r.Put("/{muscleID}/hidden", h.HideMuscle)
+		r.Delete("/{muscleID}/hidden", h.UnhideMuscle)
 	})
 }
 
@@ -43,8 +51,17 @@ type MuscleListResponse []MuscleResponse
 
 // CreateMuscleResponse defines the expected structure for muscle creation requests.
 type CreateMuscleRequest struct {
-	Name   string `json:"name" validate:"required,max=30"`
-	UserID string `json:"userID"`
+	Name     string `json:"name" validate:"required,max=30"`
+	Kind     string `json:"kind" validate:"omitempty,oneof=region group muscle head"`
+	ParentID string `json:"parent_id" validate:"omitempty,uuid"`
+}
+
+// UpdateMuscleRequest defines the expected structure for partial muscle updates.
+// Omitted fields are left unchanged, an empty parent_id moves the muscle to the top of the hierarchy.
+type UpdateMuscleRequest struct {
+	Name     *string `json:"name" validate:"omitempty,min=1,max=30"`
+	Kind     *string `json:"kind" validate:"omitempty,oneof=region group muscle head"`
+	ParentID *string `json:"parent_id" validate:"omitempty,len=0|uuid"`
 }
 
 // CreateMuscleResponse defines teh response structure after successfull muscle creation.
@@ -54,45 +71,44 @@ type CreateMuscleResponse struct {
 
 // MuscleResponse defines the standard response structure for muscle data.
 type MuscleResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Origin   string `json:"origin"`
+	Kind     string `json:"kind"`
+	ParentID string `json:"parent_id,omitempty"`
 }
 
-var (
-	// tempUserID is a placeholder user ID for testing
-	// TODO: Replace with JWT authentication to extract user ID from context.
-	tempUserID = "762c3349-0230-4094-932b-5d0685fafd4e" // only used for testing, will retrieve it from jwt in context later on
-)
+// MuscleTreeResponse defines the response structure for a muscle and the muscles below it.
+type MuscleTreeResponse struct {
+	MuscleResponse
+	Children []MuscleTreeResponse `json:"children"`
+}
 
 // CreateMuscle handles POST requests to create a new muscle.
 func (h *MuscleHandler) CreateMuscle(w http.ResponseWriter, r *http.Request) {
 	var payload CreateMuscleRequest
 	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
-		h.responseHelper.badRequestResponse(w, r, err)
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
 	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
-		h.responseHelper.WriteValidationErrorResponse(w, validationErrors)
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
 		return
 	}
 
-	domainMuscle, err := muscle.NewMuscle(muscle.MuscleParams{Name: payload.Name})
+	domainMuscle, err := muscle.NewMuscle(muscle.MuscleParams{
+		Name:     payload.Name,
+		Kind:     muscle.Kind(payload.Kind),
+		ParentID: parseOptionalUUID(payload.ParentID),
+	})
 	if err != nil {
-		if errors.Is(err, muscle.ErrInvalidMuscle) {
-			h.responseHelper.badRequestResponse(w, r, err)
-			return
-		}
-		h.responseHelper.internalServerError(w, r, err)
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
-	if err := h.muscleUseCase.CreateMuscle(r.Context(), uuid.MustParse(tempUserID), domainMuscle); err != nil {
-		if errors.Is(repositories.ErrDuplicateMuscleName, err) {
-			h.responseHelper.badRequestResponse(w, r, err)
-			return
-		}
-		h.responseHelper.internalServerError(w, r, err)
+	if err := h.muscleUseCase.CreateMuscle(r.Context(), getUserIDFromContext(r.Context()), domainMuscle); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 	// Only return ID
@@ -106,24 +122,27 @@ func (h *MuscleHandler) CreateMuscle(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// GetMuscles handles GET requests to retrieve all muscles for the current user.
+// GetMuscles handles GET requests to retrieve a page of the muscles for the current user.
 func (h *MuscleHandler) GetMuscles(w http.ResponseWriter, r *http.Request) {
-	domainMuscles, err := h.muscleUseCase.ListMusclesForUser(r.Context(), uuid.MustParse(tempUserID))
+	q, err := listquery.Parse(r.URL.Query())
 	if err != nil {
-		h.responseHelper.internalServerError(w, r, err)
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
-	responseBody := make(MuscleListResponse, 0, len(domainMuscles))
+	page, err := h.muscleUseCase.ListMusclesForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(MuscleListResponse, 0, len(page.Items))
 
-	for _, m := range domainMuscles {
-		responseBody = append(responseBody, MuscleResponse{
-			ID:   m.ID().String(),
-			Name: m.Name(),
-		})
+	for _, m := range page.Items {
+		responseBody = append(responseBody, newMuscleResponse(m))
 	}
 
-	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
 		h.responseHelper.internalServerError(w, r, err)
 		return
 	}
@@ -131,56 +150,245 @@ func (h *MuscleHandler) GetMuscles(w http.ResponseWriter, r *http.Request) {
 
 // GetMuscleByID handles GET requests to retrieve a muscle by ID for the current user.
 func (h *MuscleHandler) GetMuscleByID(w http.ResponseWriter, r *http.Request) {
-	idParam := chi.URLParam(r, "muscleID")
-	id, err := uuid.Parse(idParam)
+	id, err := parseUUIDParam(r, "muscleID")
 	if err != nil {
-		h.responseHelper.badRequestResponse(w, r, err)
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
-	domainMuscle, err := h.muscleUseCase.GetMuscleByID(r.Context(), uuid.MustParse(tempUserID), id)
+	domainMuscle, err := h.muscleUseCase.GetMuscleByID(r.Context(), getUserIDFromContext(r.Context()), id)
 	if err != nil {
-		switch err {
-		case repositories.ErrNotFound:
-			h.responseHelper.notFoundResponse(w, r, err)
-			return
-		default:
-			h.responseHelper.internalServerError(w, r, err)
-			return
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", muscleETag(domainMuscle))
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newMuscleResponse(domainMuscle)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+
+}
+
+// ReplaceMuscle handles PUT requests to replace the name and place in the hierarchy of one of the user's muscles.
+// Omitting kind makes it an individual muscle and omitting parent_id moves it to the top of the hierarchy.
+func (h *MuscleHandler) ReplaceMuscle(w http.ResponseWriter, r *http.Request) {
+	var payload CreateMuscleRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	h.updateMuscle(w, r, func(m *muscle.Muscle) error {
+		if err := m.Rename(payload.Name); err != nil {
+			return err
+		}
+		kind := muscle.KindMuscle
+		if payload.Kind != "" {
+			kind = muscle.Kind(payload.Kind)
+		}
+		if err := m.SetKind(kind); err != nil {
+			return err
 		}
+		m.SetParent(parseOptionalUUID(payload.ParentID))
+		return nil
+	})
+}
+
+// PatchMuscle handles PATCH requests to change some of the fields of one of the user's muscles.
+func (h *MuscleHandler) PatchMuscle(w http.ResponseWriter, r *http.Request) {
+	var payload UpdateMuscleRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
 	}
 
-	response := MuscleResponse{
-		ID:   domainMuscle.ID().String(),
-		Name: domainMuscle.Name(),
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
 	}
 
-	if err := h.responseHelper.jsonResponse(w, http.StatusOK, response); err != nil {
+	h.updateMuscle(w, r, func(m *muscle.Muscle) error {
+		if payload.Name != nil {
+			if err := m.Rename(*payload.Name); err != nil {
+				return err
+			}
+		}
+		if payload.Kind != nil {
+			if err := m.SetKind(muscle.Kind(*payload.Kind)); err != nil {
+				return err
+			}
+		}
+		if payload.ParentID != nil {
+			m.SetParent(parseOptionalUUID(*payload.ParentID))
+		}
+		return nil
+	})
+}
+
+// updateMuscle applies the changes to the muscle in the URL, guarded by the version in the If-Match header if present.
+func (h *MuscleHandler) updateMuscle(w http.ResponseWriter, r *http.Request, apply func(*muscle.Muscle) error) {
+	id, err := parseUUIDParam(r, "muscleID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	domainMuscle, err := h.muscleUseCase.UpdateMuscle(r.Context(), getUserIDFromContext(r.Context()), id, parseIfMatch(r), apply)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", muscleETag(domainMuscle))
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newMuscleResponse(domainMuscle)); err != nil {
 		h.responseHelper.internalServerError(w, r, err)
 		return
 	}
+}
 
+// GetMuscleTree handles GET requests to retrieve a muscle and every muscle below it in the hierarchy.
+func (h *MuscleHandler) GetMuscleTree(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "muscleID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	tree, err := h.muscleUseCase.GetMuscleTree(r.Context(), getUserIDFromContext(r.Context()), id)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newMuscleTreeResponse(tree)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
 }
 
 // DeleteMuscle handles DELETE requests to delete a muscle for the current user by ID.
 func (h *MuscleHandler) DeleteMuscle(w http.ResponseWriter, r *http.Request) {
-	idParam := chi.URLParam(r, "muscleID")
-	id, err := uuid.Parse(idParam)
+	id, err := parseUUIDParam(r, "muscleID")
 	if err != nil {
-		h.responseHelper.badRequestResponse(w, r, err)
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
-	if err := h.muscleUseCase.DeleteMuscle(r.Context(), uuid.MustParse(tempUserID), id); err != nil {
-		switch {
-		case errors.Is(err, repositories.ErrNotFound):
-			h.responseHelper.notFoundResponse(w, r, err)
-		default:
-			h.responseHelper.internalServerError(w, r, err)
-		}
+	if err := h.muscleUseCase.DeleteMuscle(r.Context(), getUserIDFromContext(r.Context()), id); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+}
+
+// GetHiddenMuscles handles GET requests to retrieve a page of the catalog muscles the current user has hidden.
+func (h *MuscleHandler) GetHiddenMuscles(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.muscleUseCase.ListHiddenMusclesForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(MuscleListResponse, 0, len(page.Items))
+
+	for _, m := range page.Items {
+		responseBody = append(responseBody, newMuscleResponse(m))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// HideMuscle handles PUT requests to hide a catalog muscle from the current user's muscle list.
+func (h *MuscleHandler) HideMuscle(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, h.muscleUseCase.HideMuscle)
+}
+
+// UnhideMuscle handles DELETE requests to show a hidden catalog muscle in the current user's muscle list again.
+func (h *MuscleHandler) UnhideMuscle(w http.ResponseWriter, r *http.Request) {
+	h.setHidden(w, r, h.muscleUseCase.UnhideMuscle)
+}
+
+func (h *MuscleHandler) setHidden(w http.ResponseWriter, r *http.Request, fn func(ctx context.Context, userID, muscleID uuid.UUID) error) {
+	id, err := parseUUIDParam(r, "muscleID")
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := fn(r.Context(), getUserIDFromContext(r.Context()), id); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
+}
 
+// newMuscleResponse converts a domain muscle to its response representation.
+func newMuscleResponse(m *muscle.Muscle) MuscleResponse {
+	response := MuscleResponse{
+		ID:     m.ID().String(),
+		Name:   m.Name(),
+		Origin: string(m.Origin()),
+		Kind:   string(m.Kind()),
+	}
+	if m.HasParent() {
+		response.ParentID = m.ParentID().String()
+	}
+	return response
+}
+
+// newMuscleTreeResponse converts a muscle tree to its nested response representation.
+func newMuscleTreeResponse(n *muscle.Node) MuscleTreeResponse {
+	children := make([]MuscleTreeResponse, 0, len(n.Children))
+	for _, c := range n.Children {
+		children = append(children, newMuscleTreeResponse(c))
+	}
+	return MuscleTreeResponse{
+		MuscleResponse: newMuscleResponse(n.Muscle),
+		Children:       children,
+	}
+}
+
+// muscleETag returns the strong entity tag for the current version of a muscle.
+func muscleETag(m *muscle.Muscle) string {
+	return strconv.Quote(strconv.Itoa(m.Version()))
+}
+
+// parseIfMatch returns the muscle versions listed in the If-Match header, nil when any version is acceptable.
+// If-Match uses the strong comparison, so weak tags and tags that are not muscle versions never match.
+// A header without a single matching tag returns an empty list that no version is in.
+func parseIfMatch(r *http.Request) []int {
+	header := strings.TrimSpace(strings.Join(r.Header.Values("If-Match"), ","))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil && version > 0 {
+			versions = append(versions, version)
+		}
+	}
+	return versions
 }
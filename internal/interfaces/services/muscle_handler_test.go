@@ -0,0 +1,37 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []string
+		expected []int
+	}{
+		{name: "Missing header", expected: nil},
+		{name: "Any version", headers: []string{"*"}, expected: nil},
+		{name: "Single tag", headers: []string{`"3"`}, expected: []int{3}},
+		{name: "Several tags", headers: []string{`"2", "3"`}, expected: []int{2, 3}},
+		{name: "Several header lines", headers: []string{`"2"`, `"3"`}, expected: []int{2, 3}},
+		{name: "Weak tag never matches", headers: []string{`W/"3"`}, expected: []int{}},
+		{name: "Weak tags are skipped", headers: []string{`W/"2", "3"`}, expected: []int{3}},
+		{name: "Unquoted tag", headers: []string{"3"}, expected: []int{}},
+		{name: "Not a version", headers: []string{`"abc", "0"`}, expected: []int{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "/muscles", nil)
+			for _, h := range tc.headers {
+				r.Header.Add("If-Match", h)
+			}
+			assert.Equal(t, tc.expected, parseIfMatch(r))
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/analytics"
+	"github.com/CP-Payne/exercise/internal/domain/equipment"
+	"github.com/CP-Payne/exercise/internal/domain/exercise"
+	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/domain/session"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/infrastructure/auth"
+	"github.com/CP-Payne/exercise/internal/infrastructure/imaging"
+	"github.com/CP-Payne/exercise/internal/infrastructure/storage"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/CP-Payne/exercise/internal/listquery"
+)
+
+// problemTypeBase prefixes the code of a problem to form its type URI
+const problemTypeBase = "/problems/"
+
+// problemContentType is the media type of RFC 7807 problem details
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details response.
+// Code is a stable machine-readable identifier clients can switch on, the type URI is derived from it.
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Errors   []ValidationError `json:"errors,omitempty"`
+}
+
+// problemKind is a class of problem: its status, stable code and title
+type problemKind struct {
+	status int
+	code   string
+	title  string
+}
+
+// Problem kinds that are not tied to a single domain or repository error
+var (
+	problemInternal         = problemKind{http.StatusInternalServerError, "internal-error", "Internal server error"}
+	problemMalformedRequest = problemKind{http.StatusBadRequest, "malformed-request", "Malformed request"}
+	problemValidation       = problemKind{http.StatusBadRequest, "validation-failed", "Request validation failed"}
+	problemUnauthorized     = problemKind{http.StatusUnauthorized, "unauthorized", "Authentication required"}
+	problemRateLimited      = problemKind{http.StatusTooManyRequests, "rate-limited", "Rate limit exceeded"}
+	problemNotFound         = problemKind{http.StatusNotFound, "not-found", "Resource not found"}
+)
+
+var (
+	// errRouteNotFound is returned for requests to a path without a route
+	errRouteNotFound = errors.New("no route matches the request path")
+
+	// errMethodNotAllowed is returned for requests with a method the route does not support
+	errMethodNotAllowed = errors.New("the route does not support the request method")
+)
+
+// errorProblems maps domain and repository errors to problem kinds.
+// It is the only place where errors are translated to HTTP statuses, handlers pass every error to errorResponse.
+// Errors are matched with errors.Is in order, so more specific errors must come first.
+// Codes are part of the API contract and must not change once released.
+var errorProblems = []struct {
+	err  error
+	kind problemKind
+}{
+	// Authentication
+	{errMissingAuthHeader, problemUnauthorized},
+	{errMalformedAuthHeader, problemUnauthorized},
+	{auth.ErrInvalidToken, problemUnauthorized},
+	{user.ErrInvalidCredentials, problemKind{http.StatusUnauthorized, "invalid-credentials", "Invalid credentials"}},
+	{session.ErrInvalidRefreshToken, problemKind{http.StatusUnauthorized, "invalid-refresh-token", "Invalid refresh token"}},
+	{session.ErrRefreshTokenReused, problemKind{http.StatusUnauthorized, "refresh-token-reused", "Refresh token reused"}},
+
+	// Users
+	{user.ErrInvalidEmail, problemKind{http.StatusBadRequest, "invalid-email", "Invalid email"}},
+	{user.ErrInvalidUsername, problemKind{http.StatusBadRequest, "invalid-username", "Invalid username"}},
+	{user.ErrInvalidPassword, problemKind{http.StatusBadRequest, "invalid-password", "Invalid password"}},
+	{repositories.ErrDuplicateEmail, problemKind{http.StatusConflict, "duplicate-email", "Email already registered"}},
+	{repositories.ErrDuplicateUsername, problemKind{http.StatusConflict, "duplicate-username", "Username already taken"}},
+
+	// Muscles
+	{muscle.ErrInvalidMuscle, problemKind{http.StatusBadRequest, "invalid-muscle", "Invalid muscle"}},
+	{muscle.ErrInvalidKind, problemKind{http.StatusBadRequest, "invalid-muscle-kind", "Invalid muscle kind"}},
+	{muscle.ErrUnknownParent, problemKind{http.StatusBadRequest, "unknown-parent-muscle", "Unknown parent muscle"}},
+	{muscle.ErrInvalidParent, problemKind{http.StatusBadRequest, "invalid-parent-muscle", "Invalid parent muscle"}},
+	{muscle.ErrNotCatalogMuscle, problemKind{http.StatusBadRequest, "not-catalog-muscle", "Not a catalog muscle"}},
+	{muscle.ErrCatalogMuscleReadOnly, problemKind{http.StatusForbidden, "catalog-muscle-read-only", "Catalog muscle is read-only"}},
+	{muscle.ErrVersionConflict, problemKind{http.StatusPreconditionFailed, "version-conflict", "Resource was modified"}},
+	{repositories.ErrDuplicateMuscleName, problemKind{http.StatusConflict, "duplicate-muscle-name", "Muscle name already exists"}},
+
+	// Exercises
+	{exercise.ErrInvalidExerciseName, problemKind{http.StatusBadRequest, "invalid-exercise", "Invalid exercise"}},
+	{exercise.ErrUnknownTargetMuscle, problemKind{http.StatusBadRequest, "unknown-target-muscle", "Unknown target muscle"}},
+	{exercise.ErrUnknownEquipment, problemKind{http.StatusBadRequest, "unknown-equipment", "Unknown equipment"}},
+	{exercise.ErrUnknownSplit, problemKind{http.StatusBadRequest, "unknown-split", "Unknown split"}},
+	{exercise.ErrLinkNotFound, problemKind{http.StatusNotFound, "link-not-found", "Link not found"}},
+	{exercise.ErrDuplicateLink, problemKind{http.StatusConflict, "duplicate-link", "Duplicate link"}},
+	{exercise.ErrInvalidMuscleRole, problemKind{http.StatusBadRequest, "invalid-muscle-role", "Invalid muscle role"}},
+	{exercise.ErrInvalidActivation, problemKind{http.StatusBadRequest, "invalid-activation", "Invalid activation"}},
+	{exercise.ErrInvalidSearchText, problemKind{http.StatusBadRequest, "invalid-search-text", "Invalid search text"}},
+	{exercise.ErrInvalidSearchLimit, problemKind{http.StatusBadRequest, "invalid-search-limit", "Invalid search limit"}},
+	{exercise.ErrInvalidMovementPattern, problemKind{http.StatusBadRequest, "invalid-movement-pattern", "Invalid movement pattern"}},
+	{exercise.ErrInvalidAlternativesLimit, problemKind{http.StatusBadRequest, "invalid-alternatives-limit", "Invalid alternatives limit"}},
+	{repositories.ErrExerciseInUse, problemKind{http.StatusConflict, "exercise-in-use", "Exercise is in use"}},
+
+	// Equipment
+	{equipment.ErrInvalidEquipment, problemKind{http.StatusBadRequest, "invalid-equipment", "Invalid equipment"}},
+	{repositories.ErrDuplicateEquipmentName, problemKind{http.StatusConflict, "duplicate-equipment-name", "Equipment name already exists"}},
+	{equipment.ErrInvalidProfile, problemKind{http.StatusBadRequest, "invalid-equipment-profile", "Invalid equipment profile"}},
+	{equipment.ErrUnknownEquipment, problemKind{http.StatusBadRequest, "unknown-equipment", "Unknown equipment"}},
+	{equipment.ErrDuplicateEquipment, problemKind{http.StatusConflict, "duplicate-profile-equipment", "Equipment already in profile"}},
+	{equipment.ErrEquipmentNotInProfile, problemKind{http.StatusNotFound, "equipment-not-in-profile", "Equipment not in profile"}},
+	{repositories.ErrDuplicateProfileName, problemKind{http.StatusConflict, "duplicate-equipment-profile-name", "Equipment profile name already exists"}},
+
+	// Splits
+	{split.ErrInvalidSplit, problemKind{http.StatusBadRequest, "invalid-split", "Invalid split"}},
+	{split.ErrInvalidDay, problemKind{http.StatusBadRequest, "invalid-day", "Invalid training day"}},
+	{split.ErrInvalidDayOrder, problemKind{http.StatusBadRequest, "invalid-day-order", "Invalid training day order"}},
+	{split.ErrUnknownExercise, problemKind{http.StatusBadRequest, "unknown-exercise", "Unknown exercise"}},
+	{split.ErrDayNotFound, problemKind{http.StatusNotFound, "day-not-found", "Training day not found"}},
+	{split.ErrExerciseNotAssigned, problemKind{http.StatusNotFound, "exercise-not-assigned", "Exercise not assigned"}},
+	{repositories.ErrDuplicateSplitName, problemKind{http.StatusConflict, "duplicate-split-name", "Split name already exists"}},
+
+	// Workouts
+	{workout.ErrInvalidReps, problemKind{http.StatusBadRequest, "invalid-reps", "Invalid reps"}},
+	{workout.ErrInvalidWeight, problemKind{http.StatusBadRequest, "invalid-weight", "Invalid weight"}},
+	{workout.ErrInvalidUnit, problemKind{http.StatusBadRequest, "invalid-unit", "Invalid unit"}},
+	{workout.ErrInvalidRPE, problemKind{http.StatusBadRequest, "invalid-rpe", "Invalid RPE"}},
+	{workout.ErrInvalidRIR, problemKind{http.StatusBadRequest, "invalid-rir", "Invalid RIR"}},
+	{workout.ErrInvalidTarget, problemKind{http.StatusBadRequest, "invalid-target", "Invalid target"}},
+	{workout.ErrInvalidFinish, problemKind{http.StatusBadRequest, "invalid-finish", "Invalid finish time"}},
+	{workout.ErrUnknownExercise, problemKind{http.StatusBadRequest, "unknown-exercise", "Unknown exercise"}},
+	{workout.ErrSessionFinished, problemKind{http.StatusConflict, "workout-finished", "Workout already finished"}},
+	{workout.ErrEntryNotFound, problemKind{http.StatusNotFound, "entry-not-found", "Exercise entry not found"}},
+
+	// Routines
+	{routine.ErrInvalidRoutine, problemKind{http.StatusBadRequest, "invalid-routine", "Invalid routine"}},
+	{routine.ErrInvalidTargetSets, problemKind{http.StatusBadRequest, "invalid-target-sets", "Invalid target sets"}},
+	{routine.ErrInvalidRepRange, problemKind{http.StatusBadRequest, "invalid-rep-range", "Invalid rep range"}},
+	{routine.ErrInvalidExerciseOrder, problemKind{http.StatusBadRequest, "invalid-exercise-order", "Invalid exercise order"}},
+	{routine.ErrUnknownExercise, problemKind{http.StatusBadRequest, "unknown-exercise", "Unknown exercise"}},
+	{routine.ErrUnknownSplitDay, problemKind{http.StatusBadRequest, "unknown-split-day", "Unknown split day"}},
+	{routine.ErrExerciseNotFound, problemKind{http.StatusNotFound, "routine-exercise-not-found", "Routine exercise not found"}},
+	{repositories.ErrDuplicateRoutineName, problemKind{http.StatusConflict, "duplicate-routine-name", "Routine name already exists"}},
+
+	// One-rep max estimates
+	{strength.ErrInvalidFormula, problemKind{http.StatusBadRequest, "invalid-formula", "Invalid one-rep max formula"}},
+	{strength.ErrInvalidWeight, problemKind{http.StatusBadRequest, "invalid-weight", "Invalid weight"}},
+	{strength.ErrInvalidReps, problemKind{http.StatusBadRequest, "invalid-reps", "Invalid reps"}},
+	{strength.ErrRPERequired, problemKind{http.StatusBadRequest, "rpe-required", "RPE required"}},
+	{strength.ErrRPEOutOfRange, problemKind{http.StatusBadRequest, "rpe-out-of-range", "RPE out of range"}},
+
+	// Analytics
+	{analytics.ErrInvalidRange, problemKind{http.StatusBadRequest, "invalid-range", "Invalid date range"}},
+
+	// Images
+	{imaging.ErrUnsupportedType, problemKind{http.StatusUnsupportedMediaType, "unsupported-image-type", "Unsupported image type"}},
+	{imaging.ErrTooLarge, problemKind{http.StatusRequestEntityTooLarge, "image-too-large", "Image too large"}},
+	{imaging.ErrInvalidImage, problemKind{http.StatusBadRequest, "invalid-image", "Invalid image"}},
+	{application.ErrInvalidImageSignature, problemKind{http.StatusForbidden, "invalid-signature", "Invalid signature"}},
+	{storage.ErrNotFound, problemNotFound},
+
+	// Listing
+	{listquery.ErrInvalidSort, problemKind{http.StatusBadRequest, "invalid-sort", "Invalid sort"}},
+	{listquery.ErrInvalidOrder, problemKind{http.StatusBadRequest, "invalid-order", "Invalid order"}},
+	{listquery.ErrInvalidLimit, problemKind{http.StatusBadRequest, "invalid-limit", "Invalid limit"}},
+	{listquery.ErrInvalidCursor, problemKind{http.StatusBadRequest, "invalid-cursor", "Invalid cursor"}},
+
+	// Routing
+	{errRouteNotFound, problemNotFound},
+	{errMethodNotAllowed, problemKind{http.StatusMethodNotAllowed, "method-not-allowed", "Method not allowed"}},
+
+	// Generic repository errors
+	{repositories.ErrNotFound, problemNotFound},
+	{repositories.ErrConflict, problemKind{http.StatusConflict, "conflict", "Resource already exists"}},
+}
+
+// requestError is a problem with the request itself, such as a malformed body or path parameter.
+// Its message is safe to return to clients.
+type requestError struct {
+	detail string
+}
+
+func (e *requestError) Error() string { return e.detail }
+
+// problemFor returns the problem kind and client-facing detail for an error.
+// Errors that are not known are reported as internal errors without detail.
+func problemFor(err error) (problemKind, string) {
+	var reqErr *requestError
+	if errors.As(err, &reqErr) {
+		return problemMalformedRequest, reqErr.detail
+	}
+
+	for _, p := range errorProblems {
+		if errors.Is(err, p.err) {
+			return p.kind, p.err.Error()
+		}
+	}
+
+	return problemInternal, "the server encountered a problem"
+}
+
+// newProblem builds the problem details of a kind for the request.
+func newProblem(r *http.Request, kind problemKind, detail string) *Problem {
+	return &Problem{
+		Type:     problemTypeBase + kind.code,
+		Title:    kind.title,
+		Status:   kind.status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     kind.code,
+	}
+}
+
+// newRequestError translates a JSON decoding error into a requestError that does not leak decoder internals.
+func newRequestError(err error, maxBytes int64) error {
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	var maxBytesError *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &syntaxError):
+		return &requestError{fmt.Sprintf("body contains badly-formed JSON (at character %d)", syntaxError.Offset)}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return &requestError{"body contains badly-formed JSON"}
+	case errors.As(err, &unmarshalTypeError):
+		if unmarshalTypeError.Field != "" {
+			return &requestError{fmt.Sprintf("body contains incorrect JSON type for field %q", unmarshalTypeError.Field)}
+		}
+		return &requestError{fmt.Sprintf("body contains incorrect JSON type (at character %d)", unmarshalTypeError.Offset)}
+	case errors.Is(err, io.EOF):
+		return &requestError{"body must not be empty"}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return &requestError{"body contains unknown field " + strings.TrimPrefix(err.Error(), "json: unknown field ")}
+	case errors.As(err, &maxBytesError):
+		return &requestError{fmt.Sprintf("body must not be larger than %d bytes", maxBytes)}
+	default:
+		return &requestError{"body could not be decoded"}
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CP-Payne/exercise/internal/domain/muscle"
+	"github.com/CP-Payne/exercise/internal/interfaces/repositories"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestErrorResponse(t *testing.T) {
+	rh := NewResponseHelper(zap.NewNop().Sugar())
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+	}{
+		{
+			name:           "Repository error",
+			err:            repositories.ErrNotFound,
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   "not-found",
+			expectedDetail: "resource not found",
+		},
+		{
+			name:           "Wrapped domain error keeps only the sentinel message",
+			err:            fmt.Errorf("updating muscle 42: %w", muscle.ErrVersionConflict),
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   "version-conflict",
+			expectedDetail: "muscle was modified by another request",
+		},
+		{
+			name:           "Request error",
+			err:            &requestError{"muscleID must be a valid UUID"},
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "malformed-request",
+			expectedDetail: "muscleID must be a valid UUID",
+		},
+		{
+			name:           "Unknown errors are not leaked",
+			err:            errors.New(`pq: relation "target_muscles" does not exist`),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   "internal-error",
+			expectedDetail: "the server encountered a problem",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/muscles/42", nil)
+
+			rh.errorResponse(w, r, tc.err)
+
+			var p Problem
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(&p))
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.expectedStatus, p.Status)
+			assert.Equal(t, tc.expectedCode, p.Code)
+			assert.Equal(t, "/problems/"+tc.expectedCode, p.Type)
+			assert.Equal(t, tc.expectedDetail, p.Detail)
+			assert.Equal(t, "/v1/muscles/42", p.Instance)
+			assert.NotEmpty(t, p.Title)
+		})
+	}
+}
+
+func TestErrorProblems_CodesAreUnique(t *testing.T) {
+	seen := map[string]problemKind{}
+	for _, p := range errorProblems {
+		if existing, ok := seen[p.kind.code]; ok {
+			assert.Equal(t, existing, p.kind, "code %q is used for different problems", p.kind.code)
+		}
+		seen[p.kind.code] = p.kind
+	}
+}
+
+func TestReadJSON_HidesDecoderErrors(t *testing.T) {
+	rh := NewResponseHelper(zap.NewNop().Sugar())
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedDetail string
+	}{
+		{name: "Empty body", body: "", expectedDetail: "body must not be empty"},
+		{name: "Syntax error", body: `{"name":}`, expectedDetail: "body contains badly-formed JSON (at character 9)"},
+		{name: "Wrong type", body: `{"name": 1}`, expectedDetail: `body contains incorrect JSON type for field "name"`},
+		{name: "Unknown field", body: `{"colour": "red"}`, expectedDetail: `body contains unknown field "colour"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/v1/muscles", strings.NewReader(tc.body))
+
+			var payload struct {
+				Name string `json:"name"`
+			}
+			err := rh.readJSON(w, r, &payload)
+
+			var reqErr *requestError
+			assert.ErrorAs(t, err, &reqErr)
+			assert.Equal(t, tc.expectedDetail, reqErr.Error())
+		})
+	}
+}
+
+func TestImageUpload(t *testing.T) {
+	form := func(field, content string) (string, string) {
+		var body strings.Builder
+		mw := multipart.NewWriter(&body)
+		part, _ := mw.CreateFormFile(field, "squat.png")
+		part.Write([]byte(content))
+		mw.Close()
+		return body.String(), mw.FormDataContentType()
+	}
+
+	t.Run("Returns the image field", func(t *testing.T) {
+		body, contentType := form("image", "PNG data")
+		r := httptest.NewRequest(http.MethodPut, "/exercises/1/image", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+
+		upload, err := imageUpload(r)
+		assert.NoError(t, err)
+		data, _ := io.ReadAll(upload)
+		assert.Equal(t, "PNG data", string(data))
+	})
+
+	imageless, imagelessType := form("photo", "PNG data")
+	tests := []struct {
+		name           string
+		body           string
+		contentType    string
+		expectedDetail string
+	}{
+		{name: "Not multipart", body: `{"image": ""}`, contentType: "application/json", expectedDetail: "body must be a multipart/form-data form"},
+		{name: "No image field", body: imageless, contentType: imagelessType, expectedDetail: "body must contain an image field"},
+		{name: "Malformed", body: "--x\r\nno header separator\r\n\r\n", contentType: "multipart/form-data; boundary=x", expectedDetail: "body contains a malformed multipart form"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/exercises/1/image", strings.NewReader(tc.body))
+			r.Header.Set("Content-Type", tc.contentType)
+
+			_, err := imageUpload(r)
+
+			var reqErr *requestError
+			assert.ErrorAs(t, err, &reqErr)
+			assert.Equal(t, tc.expectedDetail, reqErr.Error())
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"encoding/json"
 	"net/http"
 
 	"go.uber.org/zap"
@@ -17,40 +18,24 @@ func NewResponseHelper(logger *zap.SugaredLogger) *ResponseHelper {
 	return &ResponseHelper{logger: logger}
 }
 
-// internalServerError logs and sends a 500 internal Server Error response.
-func (rh *ResponseHelper) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
-	rh.logger.Errorw("internal error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	rh.writeJSONError(w, http.StatusInternalServerError, "the server encountered a problem")
-}
+// errorResponse logs the error and sends the problem it maps to in errorProblems.
+// Unknown errors are logged as internal errors and sent as a 500 without detail.
+func (rh *ResponseHelper) errorResponse(w http.ResponseWriter, r *http.Request, err error) {
+	kind, detail := problemFor(err)
 
-// forbiddenResponse logs and sends a 403 Forbidden response.
-func (rh *ResponseHelper) forbiddenResponse(w http.ResponseWriter, r *http.Request) {
-	rh.logger.Warnw("forbidden", "method", r.Method, "path", r.URL.Path, "error")
-	rh.writeJSONError(w, http.StatusForbidden, "forbidden")
-}
-
-// badRequestResponse logs and sends a 400 Bad Request response with the specified error message.
-func (rh *ResponseHelper) badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
-	rh.logger.Warnw("bad request", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	rh.writeJSONError(w, http.StatusBadRequest, err.Error())
-}
+	if kind.status >= http.StatusInternalServerError {
+		rh.logger.Errorw("internal error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	} else {
+		rh.logger.Warnw(kind.title, "method", r.Method, "path", r.URL.Path, "code", kind.code, "error", err.Error())
+	}
 
-// notFoundResponse logs and sends a 404 Not Found response.
-func (rh *ResponseHelper) notFoundResponse(w http.ResponseWriter, r *http.Request, err error) {
-	rh.logger.Warnw("not found error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	rh.writeJSONError(w, http.StatusNotFound, "not found")
+	rh.writeProblem(w, newProblem(r, kind, detail))
 }
 
-// conflictResponse logs and sends a 409 Conflict response.
-func (rh *ResponseHelper) conflictResponse(w http.ResponseWriter, r *http.Request, err error) {
-	rh.logger.Errorw("conflict response", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	rh.writeJSONError(w, http.StatusConflict, "conflict")
-}
-
-// unauthorizedErrorResponse logs and sends a 401 Unauthorized response.
-func (rh *ResponseHelper) unauthorizedErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	rh.logger.Warnw("unauthorized error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-	rh.writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+// internalServerError logs and sends a 500 internal Server Error response.
+func (rh *ResponseHelper) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
+	rh.logger.Errorw("internal error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	rh.writeProblem(w, newProblem(r, problemInternal, "the server encountered a problem"))
 }
 
 // unauthorizedBasicErrorResponse logs and sends a 401 Unauthorized response with Basic authentication header.
@@ -58,7 +43,7 @@ func (rh *ResponseHelper) unauthorizedBasicErrorResponse(w http.ResponseWriter,
 	rh.logger.Warnw("unauthorized basic error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
 
 	w.Header().Set("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
-	rh.writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+	rh.writeProblem(w, newProblem(r, problemUnauthorized, ""))
 }
 
 // rateLimitExceededResponse logs and sends a 429 Too Many Requests response with retry information.
@@ -67,5 +52,13 @@ func (rh *ResponseHelper) rateLimitExceededResponse(w http.ResponseWriter, r *ht
 
 	w.Header().Set("Retry-After", retryAfter)
 
-	rh.writeJSONError(w, http.StatusTooManyRequests, "rate limit exceeded, retry after: "+retryAfter)
+	rh.writeProblem(w, newProblem(r, problemRateLimited, "retry after: "+retryAfter))
+}
+
+// writeProblem writes problem details with the application/problem+json media type.
+func (rh *ResponseHelper) writeProblem(w http.ResponseWriter, p *Problem) error {
+	w.Header().Set("Content-Type", problemContentType)
+
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
 }
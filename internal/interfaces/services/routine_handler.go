@@ -0,0 +1,364 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/routine"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// RoutineHandler handles HTTP requests related to routine resources.
+type RoutineHandler struct {
+	routineUseCase application.RoutineUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewRoutineHandler creates a new routine handler with the specified dependencies.
+func NewRoutineHandler(routineUseCase application.RoutineUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *RoutineHandler {
+	return &RoutineHandler{
+		routineUseCase: routineUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all routine-related routes on the provided router.
+func (h *RoutineHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/routines", func(r chi.Router) {
+		r.Get("/", h.GetRoutines)
+		r.Post("/", h.CreateRoutine)
+
+		r.Route("/{routineID}", func(r chi.Router) {
+			r.Get("/", h.GetRoutineByID)
+			r.Put("/", h.RenameRoutine)
+			r.Delete("/", h.DeleteRoutine)
+
+			r.Post("/exercises", h.AddExercise)
+			r.Put("/exercises/order", h.ReorderExercises)
+			r.Put("/exercises/{routineExerciseID}", h.SetExerciseTarget)
+			r.Delete("/exercises/{routineExerciseID}", h.RemoveExercise)
+
+			r.Post("/start", h.StartRoutine)
+		})
+	})
+}
+
+// RoutineListResponse represents a collection of routine responses
+type RoutineListResponse []RoutineResponse
+
+// RoutineTargetRequest defines the expected structure for the sets and rep range of a routine exercise.
+type RoutineTargetRequest struct {
+	TargetSets int `json:"target_sets" validate:"required,min=1,max=20"`
+	MinReps    int `json:"min_reps" validate:"required,min=1,max=100"`
+	MaxReps    int `json:"max_reps" validate:"required,gtefield=MinReps,max=100"`
+}
+
+// RoutineExerciseRequest defines the expected structure for adding an exercise to a routine.
+type RoutineExerciseRequest struct {
+	ExerciseID string `json:"exercise_id" validate:"required,uuid"`
+	RoutineTargetRequest
+}
+
+// CreateRoutineRequest defines the expected structure for routine creation requests.
+// A routine is created either with its exercises or from the exercises of a split day.
+type CreateRoutineRequest struct {
+	Name      string                   `json:"name" validate:"required,max=100"`
+	Exercises []RoutineExerciseRequest `json:"exercises" validate:"excluded_with=SplitID,dive"`
+	SplitID   string                   `json:"split_id" validate:"required_with=DayID,omitempty,uuid"`
+	DayID     string                   `json:"day_id" validate:"required_with=SplitID,omitempty,uuid"`
+}
+
+// ReorderRoutineExercisesRequest defines the expected structure for changing the order of the exercises in a routine.
+type ReorderRoutineExercisesRequest struct {
+	RoutineExerciseIDs []string `json:"routine_exercise_ids" validate:"required,unique,dive,uuid"`
+}
+
+// CreateRoutineResponse defines the response structure after successful routine creation.
+type CreateRoutineResponse struct {
+	ID string `json:"id"`
+}
+
+// RoutineExerciseResponse defines the response structure for an exercise within a routine.
+type RoutineExerciseResponse struct {
+	ID         string `json:"id"`
+	ExerciseID string `json:"exercise_id"`
+	Position   int    `json:"position"`
+	TargetSets int    `json:"target_sets"`
+	MinReps    int    `json:"min_reps"`
+	MaxReps    int    `json:"max_reps"`
+}
+
+// RoutineResponse defines the standard response structure for routine data.
+type RoutineResponse struct {
+	ID        string                    `json:"id"`
+	Name      string                    `json:"name"`
+	Exercises []RoutineExerciseResponse `json:"exercises"`
+}
+
+// CreateRoutine handles POST requests to create a new routine with its exercises or from a split day.
+func (h *RoutineHandler) CreateRoutine(w http.ResponseWriter, r *http.Request) {
+	var payload CreateRoutineRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	userID := getUserIDFromContext(r.Context())
+
+	var domainRoutine *routine.Routine
+	if payload.SplitID != "" {
+		var err error
+		domainRoutine, err = h.routineUseCase.CreateRoutineFromSplitDay(r.Context(), userID, payload.Name, uuid.MustParse(payload.SplitID), uuid.MustParse(payload.DayID))
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+	} else {
+		exercises := make([]routine.ExerciseParams, 0, len(payload.Exercises))
+		for _, e := range payload.Exercises {
+			exercises = append(exercises, routine.ExerciseParams{ExerciseID: uuid.MustParse(e.ExerciseID), Target: e.target()})
+		}
+
+		var err error
+		domainRoutine, err = routine.NewRoutine(routine.RoutineParams{Name: payload.Name, Exercises: exercises})
+		if err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+
+		if err := h.routineUseCase.CreateRoutine(r.Context(), userID, domainRoutine); err != nil {
+			h.responseHelper.errorResponse(w, r, err)
+			return
+		}
+	}
+
+	response := CreateRoutineResponse{
+		ID: domainRoutine.GetID().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetRoutines handles GET requests to retrieve a page of the routines for the current user.
+func (h *RoutineHandler) GetRoutines(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.routineUseCase.ListRoutinesForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(RoutineListResponse, 0, len(page.Items))
+
+	for _, rt := range page.Items {
+		responseBody = append(responseBody, newRoutineResponse(rt))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetRoutineByID handles GET requests to retrieve a routine by ID for the current user.
+func (h *RoutineHandler) GetRoutineByID(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.GetRoutineByID(r.Context(), getUserIDFromContext(r.Context()), routineID)
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// RenameRoutine handles PUT requests to rename a routine for the current user.
+func (h *RoutineHandler) RenameRoutine(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	var payload NameRequest
+	if !h.readRequest(w, r, &payload) {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.RenameRoutine(r.Context(), getUserIDFromContext(r.Context()), routineID, payload.Name)
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// DeleteRoutine handles DELETE requests to delete a routine for the current user by ID.
+func (h *RoutineHandler) DeleteRoutine(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	if err := h.routineUseCase.DeleteRoutine(r.Context(), getUserIDFromContext(r.Context()), routineID); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddExercise handles POST requests to append an exercise with its target to a routine.
+func (h *RoutineHandler) AddExercise(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	var payload RoutineExerciseRequest
+	if !h.readRequest(w, r, &payload) {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.AddExercise(r.Context(), getUserIDFromContext(r.Context()), routineID, uuid.MustParse(payload.ExerciseID), payload.target())
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// ReorderExercises handles PUT requests to change the order of the exercises in a routine.
+func (h *RoutineHandler) ReorderExercises(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	var payload ReorderRoutineExercisesRequest
+	if !h.readRequest(w, r, &payload) {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.ReorderExercises(r.Context(), getUserIDFromContext(r.Context()), routineID, mustParseUUIDs(payload.RoutineExerciseIDs))
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// SetExerciseTarget handles PUT requests to change the sets and rep range of an exercise in a routine.
+func (h *RoutineHandler) SetExerciseTarget(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	routineExerciseID, ok := requireUUIDParam(w, r, h.responseHelper, "routineExerciseID")
+	if !ok {
+		return
+	}
+
+	var payload RoutineTargetRequest
+	if !h.readRequest(w, r, &payload) {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.SetExerciseTarget(r.Context(), getUserIDFromContext(r.Context()), routineID, routineExerciseID, payload.target())
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// RemoveExercise handles DELETE requests to remove an exercise from a routine.
+func (h *RoutineHandler) RemoveExercise(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	routineExerciseID, ok := requireUUIDParam(w, r, h.responseHelper, "routineExerciseID")
+	if !ok {
+		return
+	}
+
+	domainRoutine, err := h.routineUseCase.RemoveExercise(r.Context(), getUserIDFromContext(r.Context()), routineID, routineExerciseID)
+	h.writeRoutine(w, r, domainRoutine, err)
+}
+
+// StartRoutine handles POST requests to start a workout session planned from a routine
+// and from the loads of the previous sessions.
+func (h *RoutineHandler) StartRoutine(w http.ResponseWriter, r *http.Request) {
+	routineID, ok := requireUUIDParam(w, r, h.responseHelper, "routineID")
+	if !ok {
+		return
+	}
+
+	session, err := h.routineUseCase.StartRoutine(r.Context(), getUserIDFromContext(r.Context()), routineID)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, newWorkoutResponse(session)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// readRequest decodes and validates a request body, writing the error response on failure.
+func (h *RoutineHandler) readRequest(w http.ResponseWriter, r *http.Request, payload any) bool {
+	if err := h.responseHelper.readJSON(w, r, payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return false
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return false
+	}
+	return true
+}
+
+// writeRoutine responds with the routine returned by a use case, or with its error.
+func (h *RoutineHandler) writeRoutine(w http.ResponseWriter, r *http.Request, rt *routine.Routine, err error) {
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newRoutineResponse(rt)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// target converts the request to a domain target.
+func (t RoutineTargetRequest) target() routine.Target {
+	return routine.Target{Sets: t.TargetSets, MinReps: t.MinReps, MaxReps: t.MaxReps}
+}
+
+// newRoutineResponse converts a domain routine to its response representation.
+func newRoutineResponse(rt *routine.Routine) RoutineResponse {
+	exercises := make([]RoutineExerciseResponse, 0, len(rt.GetExercises()))
+	for i, e := range rt.GetExercises() {
+		t := e.GetTarget()
+		exercises = append(exercises, RoutineExerciseResponse{
+			ID:         e.GetID().String(),
+			ExerciseID: e.GetExerciseID().String(),
+			Position:   i,
+			TargetSets: t.Sets,
+			MinReps:    t.MinReps,
+			MaxReps:    t.MaxReps,
+		})
+	}
+
+	return RoutineResponse{
+		ID:        rt.GetID().String(),
+		Name:      rt.GetName(),
+		Exercises: exercises,
+	}
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/user"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// SettingsHandler handles HTTP requests related to the settings of the current user.
+type SettingsHandler struct {
+	settingsUseCase application.SettingsUseCase
+	logger          *zap.SugaredLogger
+	responseHelper  *ResponseHelper
+}
+
+// NewSettingsHandler creates a new settings handler with the specified dependencies.
+func NewSettingsHandler(settingsUseCase application.SettingsUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *SettingsHandler {
+	return &SettingsHandler{
+		settingsUseCase: settingsUseCase,
+		logger:          logger,
+		responseHelper:  responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all settings-related routes on the provided router.
+func (h *SettingsHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/settings", func(r chi.Router) {
+		r.Get("/", h.GetSettings)
+		r.Put("/", h.UpdateSettings)
+	})
+}
+
+// SettingsRequest defines the expected structure for replacing the settings of the current user.
+type SettingsRequest struct {
+	OneRepMaxFormula string `json:"one_rep_max_formula" validate:"required,oneof=epley brzycki lombardi rpe"`
+}
+
+// SettingsResponse defines the response structure for the settings of the current user.
+type SettingsResponse struct {
+	OneRepMaxFormula string `json:"one_rep_max_formula"`
+}
+
+// GetSettings handles GET requests to retrieve the settings of the current user.
+func (h *SettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.settingsUseCase.GetSettings(r.Context(), getUserIDFromContext(r.Context()))
+	h.writeSettings(w, r, settings, err)
+}
+
+// UpdateSettings handles PUT requests to replace the settings of the current user.
+func (h *SettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var payload SettingsRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	settings := user.Settings{OneRepMaxFormula: strength.Formula(payload.OneRepMaxFormula)}
+
+	settings, err := h.settingsUseCase.UpdateSettings(r.Context(), getUserIDFromContext(r.Context()), settings)
+	h.writeSettings(w, r, settings, err)
+}
+
+// writeSettings responds with the settings returned by a use case, or with its error.
+func (h *SettingsHandler) writeSettings(w http.ResponseWriter, r *http.Request, s user.Settings, err error) {
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := SettingsResponse{OneRepMaxFormula: string(s.OneRepMaxFormula)}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
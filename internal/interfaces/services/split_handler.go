@@ -0,0 +1,351 @@
+package services
+
+import (
+	"net/http"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/split"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SplitHandler handles HTTP requests related to training split resources.
+type SplitHandler struct {
+	splitUseCase   application.SplitUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewSplitHandler creates a new split handler with the specified dependencies.
+func NewSplitHandler(splitUseCase application.SplitUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *SplitHandler {
+	return &SplitHandler{
+		splitUseCase:   splitUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all split-related routes on the provided router.
+func (h *SplitHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/splits", func(r chi.Router) {
+		r.Get("/", h.GetSplits)
+		r.Post("/", h.CreateSplit)
+
+		r.Route("/{splitID}", func(r chi.Router) {
+			r.Get("/", h.GetSplitByID)
+			r.Put("/", h.RenameSplit)
+			r.Delete("/", h.DeleteSplit)
+
+			r.Post("/days", h.AddDay)
+			r.Put("/days/order", h.ReorderDays)
+			r.Put("/days/{dayID}", h.RenameDay)
+			r.Delete("/days/{dayID}", h.RemoveDay)
+			r.Put("/days/{dayID}/exercises/{exerciseID}", h.AssignExercise)
+			r.Delete("/days/{dayID}/exercises/{exerciseID}", h.UnassignExercise)
+		})
+	})
+}
+
+// SplitListResponse represents a collection of split responses
+type SplitListResponse []SplitResponse
+
+// CreateSplitRequest defines the expected structure for split creation requests.
+type CreateSplitRequest struct {
+	Name string   `json:"name" validate:"required,max=100"`
+	Days []string `json:"days" validate:"dive,required,max=100"`
+}
+
+// NameRequest defines the expected structure for requests that only rename a resource.
+type NameRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// ReorderDaysRequest defines the expected structure for changing the order of training days.
+type ReorderDaysRequest struct {
+	DayIDs []string `json:"day_ids" validate:"required,unique,dive,uuid"`
+}
+
+// CreateSplitResponse defines the response structure after successful split creation.
+type CreateSplitResponse struct {
+	ID string `json:"id"`
+}
+
+// SplitDayResponse defines the response structure for a training day within a split.
+type SplitDayResponse struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Position    int      `json:"position"`
+	ExerciseIDs []string `json:"exercise_ids"`
+}
+
+// SplitResponse defines the standard response structure for split data.
+type SplitResponse struct {
+	ID   string             `json:"id"`
+	Name string             `json:"name"`
+	Days []SplitDayResponse `json:"days"`
+}
+
+// CreateSplit handles POST requests to create a new split, optionally with its training days.
+func (h *SplitHandler) CreateSplit(w http.ResponseWriter, r *http.Request) {
+	var payload CreateSplitRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	days := make([]split.DayParams, 0, len(payload.Days))
+	for _, name := range payload.Days {
+		days = append(days, split.DayParams{Name: name})
+	}
+
+	domainSplit, err := split.NewSplit(split.SplitParams{Name: payload.Name, Days: days})
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.splitUseCase.CreateSplit(r.Context(), getUserIDFromContext(r.Context()), domainSplit); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := CreateSplitResponse{
+		ID: domainSplit.GetId().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetSplits handles GET requests to retrieve a page of the splits for the current user.
+func (h *SplitHandler) GetSplits(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.splitUseCase.ListSplitsForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(SplitListResponse, 0, len(page.Items))
+
+	for _, s := range page.Items {
+		responseBody = append(responseBody, newSplitResponse(s))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetSplitByID handles GET requests to retrieve a split by ID for the current user.
+func (h *SplitHandler) GetSplitByID(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.GetSplitByID(r.Context(), getUserIDFromContext(r.Context()), splitID)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// RenameSplit handles PUT requests to rename a split for the current user.
+func (h *SplitHandler) RenameSplit(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	payload, ok := h.readName(w, r)
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.RenameSplit(r.Context(), getUserIDFromContext(r.Context()), splitID, payload.Name)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// DeleteSplit handles DELETE requests to delete a split for the current user by ID.
+func (h *SplitHandler) DeleteSplit(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	if err := h.splitUseCase.DeleteSplit(r.Context(), getUserIDFromContext(r.Context()), splitID); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddDay handles POST requests to append a training day to a split.
+func (h *SplitHandler) AddDay(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	payload, ok := h.readName(w, r)
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.AddDay(r.Context(), getUserIDFromContext(r.Context()), splitID, payload.Name)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// ReorderDays handles PUT requests to change the order of the training days in a split.
+func (h *SplitHandler) ReorderDays(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	var payload ReorderDaysRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.ReorderDays(r.Context(), getUserIDFromContext(r.Context()), splitID, mustParseUUIDs(payload.DayIDs))
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// RenameDay handles PUT requests to rename a training day in a split.
+func (h *SplitHandler) RenameDay(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	dayID, ok := requireUUIDParam(w, r, h.responseHelper, "dayID")
+	if !ok {
+		return
+	}
+
+	payload, ok := h.readName(w, r)
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.RenameDay(r.Context(), getUserIDFromContext(r.Context()), splitID, dayID, payload.Name)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// RemoveDay handles DELETE requests to remove a training day from a split.
+func (h *SplitHandler) RemoveDay(w http.ResponseWriter, r *http.Request) {
+	splitID, ok := requireUUIDParam(w, r, h.responseHelper, "splitID")
+	if !ok {
+		return
+	}
+
+	dayID, ok := requireUUIDParam(w, r, h.responseHelper, "dayID")
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.RemoveDay(r.Context(), getUserIDFromContext(r.Context()), splitID, dayID)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// AssignExercise handles PUT requests to assign an exercise to a training day.
+func (h *SplitHandler) AssignExercise(w http.ResponseWriter, r *http.Request) {
+	splitID, dayID, exerciseID, ok := h.parseDayExerciseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.AssignExerciseToDay(r.Context(), getUserIDFromContext(r.Context()), splitID, dayID, exerciseID)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// UnassignExercise handles DELETE requests to remove an exercise from a training day.
+func (h *SplitHandler) UnassignExercise(w http.ResponseWriter, r *http.Request) {
+	splitID, dayID, exerciseID, ok := h.parseDayExerciseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	domainSplit, err := h.splitUseCase.UnassignExerciseFromDay(r.Context(), getUserIDFromContext(r.Context()), splitID, dayID, exerciseID)
+	h.writeSplit(w, r, domainSplit, err)
+}
+
+// parseDayExerciseIDs parses the split, day and exercise IDs of a day assignment route.
+func (h *SplitHandler) parseDayExerciseIDs(w http.ResponseWriter, r *http.Request) (splitID, dayID, exerciseID uuid.UUID, ok bool) {
+	if splitID, ok = requireUUIDParam(w, r, h.responseHelper, "splitID"); !ok {
+		return
+	}
+	if dayID, ok = requireUUIDParam(w, r, h.responseHelper, "dayID"); !ok {
+		return
+	}
+	exerciseID, ok = requireUUIDParam(w, r, h.responseHelper, "exerciseID")
+	return
+}
+
+// readName decodes and validates a NameRequest, writing the error response on failure.
+func (h *SplitHandler) readName(w http.ResponseWriter, r *http.Request) (NameRequest, bool) {
+	var payload NameRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return payload, false
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return payload, false
+	}
+	return payload, true
+}
+
+// writeSplit responds with the split returned by a use case, or with its error.
+func (h *SplitHandler) writeSplit(w http.ResponseWriter, r *http.Request, s *split.Split, err error) {
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newSplitResponse(s)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// newSplitResponse converts a domain split to its response representation.
+func newSplitResponse(s *split.Split) SplitResponse {
+	days := make([]SplitDayResponse, 0, len(s.GetDays()))
+	for i, d := range s.GetDays() {
+		days = append(days, SplitDayResponse{
+			ID:          d.GetId().String(),
+			Name:        d.GetName(),
+			Position:    i,
+			ExerciseIDs: uuidsToStrings(d.GetExerciseIDs()),
+		})
+	}
+
+	return SplitResponse{
+		ID:   s.GetId().String(),
+		Name: s.GetName(),
+		Days: days,
+	}
+}
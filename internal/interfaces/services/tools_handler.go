@@ -0,0 +1,88 @@
+package services
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/strength"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+// ToolsHandler handles HTTP requests for calculators that do not store anything.
+type ToolsHandler struct {
+	toolsUseCase   application.ToolsUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewToolsHandler creates a new tools handler with the specified dependencies.
+func NewToolsHandler(toolsUseCase application.ToolsUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *ToolsHandler {
+	return &ToolsHandler{
+		toolsUseCase:   toolsUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all tool routes on the provided router.
+func (h *ToolsHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/tools", func(r chi.Router) {
+		r.Post("/1rm", h.EstimateOneRepMax)
+	})
+}
+
+// OneRepMaxRequest defines the expected structure for estimating a one-rep max.
+// The user's preferred formula is used when Formula is empty, RPE is required by the rpe formula.
+type OneRepMaxRequest struct {
+	Weight  float64  `json:"weight" validate:"required,gt=0,max=10000"`
+	Unit    string   `json:"unit" validate:"omitempty,oneof=kg lb"`
+	Reps    int      `json:"reps" validate:"required,min=1,max=30"`
+	RPE     *float64 `json:"rpe" validate:"omitempty,min=1,max=10"`
+	Formula string   `json:"formula" validate:"omitempty,oneof=epley brzycki lombardi rpe"`
+}
+
+// OneRepMaxResponse defines the response structure of a one-rep max estimate, in the unit of the request.
+type OneRepMaxResponse struct {
+	Formula   string  `json:"formula"`
+	OneRepMax float64 `json:"one_rep_max"`
+	Unit      string  `json:"unit"`
+}
+
+// EstimateOneRepMax handles POST requests to estimate a one-rep max from a set.
+func (h *ToolsHandler) EstimateOneRepMax(w http.ResponseWriter, r *http.Request) {
+	var payload OneRepMaxRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	if payload.Unit == "" {
+		payload.Unit = string(workout.UnitKg)
+	}
+
+	formula, e1rm, err := h.toolsUseCase.EstimateOneRepMax(r.Context(), getUserIDFromContext(r.Context()),
+		strength.Formula(payload.Formula), payload.Weight, payload.Reps, payload.RPE)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := OneRepMaxResponse{
+		Formula:   string(formula),
+		OneRepMax: math.Round(e1rm*100) / 100,
+		Unit:      payload.Unit,
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// parseUUIDParam parses the UUID in the named URL parameter.
+// A malformed value is returned as a requestError.
+func parseUUIDParam(r *http.Request, param string) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, param))
+	if err != nil {
+		return uuid.Nil, &requestError{fmt.Sprintf("%s must be a valid UUID", param)}
+	}
+	return id, nil
+}
+
+// requireUUIDParam parses the UUID in the named URL parameter, writing the error response on failure.
+func requireUUIDParam(w http.ResponseWriter, r *http.Request, rh *ResponseHelper, param string) (uuid.UUID, bool) {
+	id, err := parseUUIDParam(r, param)
+	if err != nil {
+		rh.errorResponse(w, r, err)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// parseUUIDQueryParam parses every value of the named query parameter as a UUID.
+// A malformed value is returned as a requestError.
+func parseUUIDQueryParam(r *http.Request, param string) ([]uuid.UUID, error) {
+	values := r.URL.Query()[param]
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			return nil, &requestError{fmt.Sprintf("%s must be a valid UUID", param)}
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// mustParseUUIDs converts strings that already passed `uuid` validation to UUIDs.
+func mustParseUUIDs(values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		ids = append(ids, uuid.MustParse(v))
+	}
+	return ids
+}
+
+// uuidsToStrings converts UUIDs to their string representation for responses.
+func uuidsToStrings(ids []uuid.UUID) []string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, id.String())
+	}
+	return values
+}
+
+// parseOptionalUUID parses a validated UUID, returning uuid.Nil for an empty string.
+func parseOptionalUUID(value string) uuid.UUID {
+	if value == "" {
+		return uuid.Nil
+	}
+	return uuid.MustParse(value)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -20,6 +21,13 @@ var validationMessages = map[string]string{
 	"max":      "Must be at most %s characters long.",
 	"email":    "Must be a valid email address.",
 	"uuid":     "Must be a valid UUID.",
+	"unique":   "Must not contain duplicate values.",
+}
+
+// numericValidationMessages replaces the length based messages for fields holding numbers.
+var numericValidationMessages = map[string]string{
+	"min": "Must be at least %s.",
+	"max": "Must be at most %s.",
 }
 
 // ValidateStruct validates a struct and returns a list of detailed validation error messages
@@ -34,6 +42,9 @@ func (rh *ResponseHelper) ValidateStruct(data interface{}) []ValidationError {
 	for _, err := range err.(validator.ValidationErrors) {
 		// Lookup validation message, default to generic message
 		msg, exists := validationMessages[err.Tag()]
+		if numeric, ok := numericValidationMessages[err.Tag()]; ok && isNumericKind(err.Kind()) {
+			msg = numeric
+		}
 		if exists {
 			if err.Param() != "" {
 				msg = fmt.Sprintf(msg, err.Param()) // Handle parameters like min=3, max=10
@@ -51,11 +62,21 @@ func (rh *ResponseHelper) ValidateStruct(data interface{}) []ValidationError {
 	return errors
 }
 
-// WriteValidationErrorResponse sends a structured validation error response
-func (rh *ResponseHelper) WriteValidationErrorResponse(w http.ResponseWriter, errors []ValidationError) {
-	response := map[string]interface{}{
-		"errors": errors,
-	}
+// WriteValidationErrorResponse sends a validation-failed problem listing every invalid field
+func (rh *ResponseHelper) WriteValidationErrorResponse(w http.ResponseWriter, r *http.Request, errors []ValidationError) {
+	problem := newProblem(r, problemValidation, "one or more fields are invalid")
+	problem.Errors = errors
 
-	rh.jsonResponse(w, http.StatusBadRequest, response)
+	rh.writeProblem(w, problem)
+}
+
+// isNumericKind reports whether a value of kind k is a number
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
 }
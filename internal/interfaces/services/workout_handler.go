@@ -0,0 +1,364 @@
+package services
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/application"
+	"github.com/CP-Payne/exercise/internal/domain/workout"
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// WorkoutHandler handles HTTP requests related to workout session resources.
+type WorkoutHandler struct {
+	workoutUseCase application.WorkoutUseCase
+	logger         *zap.SugaredLogger
+	responseHelper *ResponseHelper
+}
+
+// NewWorkoutHandler creates a new workout handler with the specified dependencies.
+func NewWorkoutHandler(workoutUseCase application.WorkoutUseCase, logger *zap.SugaredLogger, responseHelper *ResponseHelper) *WorkoutHandler {
+	return &WorkoutHandler{
+		workoutUseCase: workoutUseCase,
+		logger:         logger,
+		responseHelper: responseHelper,
+	}
+}
+
+// RegisterRoutes sets up all workout-related routes on the provided router.
+func (h *WorkoutHandler) RegisterRoutes(router chi.Router) {
+	router.Route("/workouts", func(r chi.Router) {
+		r.Get("/", h.GetWorkouts)
+		r.Post("/", h.StartWorkout)
+
+		r.Route("/{workoutID}", func(r chi.Router) {
+			r.Get("/", h.GetWorkoutByID)
+			r.Delete("/", h.DeleteWorkout)
+
+			r.Post("/exercises", h.AddExercise)
+			r.Post("/exercises/{entryID}/sets", h.AddSet)
+			r.Post("/finish", h.FinishWorkout)
+		})
+	})
+}
+
+// WorkoutListResponse represents a collection of workout responses
+type WorkoutListResponse []WorkoutResponse
+
+// StartWorkoutRequest defines the expected structure for starting a workout session.
+type StartWorkoutRequest struct {
+	Name        string   `json:"name" validate:"max=100"`
+	Notes       string   `json:"notes" validate:"max=2000"`
+	ExerciseIDs []string `json:"exercise_ids" validate:"dive,uuid"`
+}
+
+// AddWorkoutExerciseRequest defines the expected structure for appending an exercise to a workout session.
+type AddWorkoutExerciseRequest struct {
+	ExerciseID string `json:"exercise_id" validate:"required,uuid"`
+}
+
+// AddSetRequest defines the expected structure for recording a set.
+type AddSetRequest struct {
+	Reps   int      `json:"reps" validate:"required,min=1,max=1000"`
+	Weight float64  `json:"weight" validate:"min=0,max=10000"`
+	Unit   string   `json:"unit" validate:"omitempty,oneof=kg lb"`
+	RPE    *float64 `json:"rpe" validate:"omitempty,min=1,max=10"`
+	RIR    *int     `json:"rir" validate:"omitempty,min=0,max=10"`
+	Warmup bool     `json:"warmup"`
+}
+
+// CreateWorkoutResponse defines the response structure after successfully starting a workout session.
+type CreateWorkoutResponse struct {
+	ID string `json:"id"`
+}
+
+// WorkoutSetResponse defines the response structure for a set within an exercise entry.
+type WorkoutSetResponse struct {
+	ID     string   `json:"id"`
+	Reps   int      `json:"reps"`
+	Weight float64  `json:"weight"`
+	Unit   string   `json:"unit"`
+	RPE    *float64 `json:"rpe"`
+	RIR    *int     `json:"rir"`
+	Warmup bool     `json:"warmup"`
+}
+
+// WorkoutTargetResponse defines the response structure for the target of an entry planned from a routine.
+type WorkoutTargetResponse struct {
+	Sets    int     `json:"sets"`
+	MinReps int     `json:"min_reps"`
+	MaxReps int     `json:"max_reps"`
+	Weight  float64 `json:"weight"`
+	Unit    string  `json:"unit"`
+}
+
+// WorkoutEntryResponse defines the response structure for an exercise performed in a workout session.
+// Target is null for entries that were not planned from a routine.
+type WorkoutEntryResponse struct {
+	ID         string                 `json:"id"`
+	ExerciseID string                 `json:"exercise_id"`
+	Position   int                    `json:"position"`
+	Target     *WorkoutTargetResponse `json:"target"`
+	Sets       []WorkoutSetResponse   `json:"sets"`
+}
+
+// WorkoutResponse defines the standard response structure for workout session data.
+type WorkoutResponse struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Notes      string                 `json:"notes"`
+	StartedAt  time.Time              `json:"started_at"`
+	FinishedAt *time.Time             `json:"finished_at"`
+	Entries    []WorkoutEntryResponse `json:"entries"`
+}
+
+// FinishWorkoutResponse defines the response structure of a finished workout session
+// with the personal records set during it.
+type FinishWorkoutResponse struct {
+	WorkoutResponse
+	Records []RecordResponse `json:"records"`
+}
+
+// StartWorkout handles POST requests to start a new workout session, optionally with the exercises to perform.
+func (h *WorkoutHandler) StartWorkout(w http.ResponseWriter, r *http.Request) {
+	var payload StartWorkoutRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	entries := make([]workout.ExerciseEntryParams, 0, len(payload.ExerciseIDs))
+	for _, id := range mustParseUUIDs(payload.ExerciseIDs) {
+		entries = append(entries, workout.ExerciseEntryParams{ExerciseID: id})
+	}
+
+	session, err := workout.NewSession(workout.SessionParams{Name: payload.Name, Notes: payload.Notes, Entries: entries})
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.workoutUseCase.StartWorkout(r.Context(), getUserIDFromContext(r.Context()), session); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	response := CreateWorkoutResponse{
+		ID: session.GetID().String(),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusCreated, response); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetWorkouts handles GET requests to retrieve a page of the workout sessions for the current user.
+// Sorting by created_at orders the sessions by the time they started.
+func (h *WorkoutHandler) GetWorkouts(w http.ResponseWriter, r *http.Request) {
+	q, err := listquery.Parse(r.URL.Query())
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	page, err := h.workoutUseCase.ListWorkoutsForUser(r.Context(), getUserIDFromContext(r.Context()), q)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := make(WorkoutListResponse, 0, len(page.Items))
+
+	for _, s := range page.Items {
+		responseBody = append(responseBody, newWorkoutResponse(s))
+	}
+
+	if err := h.responseHelper.listResponse(w, http.StatusOK, responseBody, page.NextCursor); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// GetWorkoutByID handles GET requests to retrieve a workout session by ID for the current user.
+func (h *WorkoutHandler) GetWorkoutByID(w http.ResponseWriter, r *http.Request) {
+	workoutID, ok := requireUUIDParam(w, r, h.responseHelper, "workoutID")
+	if !ok {
+		return
+	}
+
+	session, err := h.workoutUseCase.GetWorkoutByID(r.Context(), getUserIDFromContext(r.Context()), workoutID)
+	h.writeWorkout(w, r, session, err)
+}
+
+// DeleteWorkout handles DELETE requests to delete a workout session for the current user by ID.
+func (h *WorkoutHandler) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
+	workoutID, ok := requireUUIDParam(w, r, h.responseHelper, "workoutID")
+	if !ok {
+		return
+	}
+
+	if err := h.workoutUseCase.DeleteWorkout(r.Context(), getUserIDFromContext(r.Context()), workoutID); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddExercise handles POST requests to append an exercise to a workout session.
+func (h *WorkoutHandler) AddExercise(w http.ResponseWriter, r *http.Request) {
+	workoutID, ok := requireUUIDParam(w, r, h.responseHelper, "workoutID")
+	if !ok {
+		return
+	}
+
+	var payload AddWorkoutExerciseRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	session, err := h.workoutUseCase.AddExercise(r.Context(), getUserIDFromContext(r.Context()), workoutID, uuid.MustParse(payload.ExerciseID))
+	h.writeWorkout(w, r, session, err)
+}
+
+// AddSet handles POST requests to record a set of an exercise in a workout session.
+func (h *WorkoutHandler) AddSet(w http.ResponseWriter, r *http.Request) {
+	workoutID, ok := requireUUIDParam(w, r, h.responseHelper, "workoutID")
+	if !ok {
+		return
+	}
+
+	entryID, ok := requireUUIDParam(w, r, h.responseHelper, "entryID")
+	if !ok {
+		return
+	}
+
+	var payload AddSetRequest
+	if err := h.responseHelper.readJSON(w, r, &payload); err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if validationErrors := h.responseHelper.ValidateStruct(payload); validationErrors != nil {
+		h.responseHelper.WriteValidationErrorResponse(w, r, validationErrors)
+		return
+	}
+
+	params := workout.SetParams{
+		Reps:   payload.Reps,
+		Weight: payload.Weight,
+		Unit:   workout.Unit(payload.Unit),
+		RPE:    payload.RPE,
+		RIR:    payload.RIR,
+		Warmup: payload.Warmup,
+	}
+
+	session, err := h.workoutUseCase.AddSet(r.Context(), getUserIDFromContext(r.Context()), workoutID, entryID, params)
+	h.writeWorkout(w, r, session, err)
+}
+
+// FinishWorkout handles POST requests to finish a workout session, after which it can no longer be changed.
+// The response lists the personal records set during the session.
+func (h *WorkoutHandler) FinishWorkout(w http.ResponseWriter, r *http.Request) {
+	workoutID, ok := requireUUIDParam(w, r, h.responseHelper, "workoutID")
+	if !ok {
+		return
+	}
+
+	session, records, err := h.workoutUseCase.FinishWorkout(r.Context(), getUserIDFromContext(r.Context()), workoutID)
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	responseBody := FinishWorkoutResponse{
+		WorkoutResponse: newWorkoutResponse(session),
+		Records:         newRecordResponses(records),
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, responseBody); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// writeWorkout responds with the workout session returned by a use case, or with its error.
+func (h *WorkoutHandler) writeWorkout(w http.ResponseWriter, r *http.Request, s *workout.Session, err error) {
+	if err != nil {
+		h.responseHelper.errorResponse(w, r, err)
+		return
+	}
+
+	if err := h.responseHelper.jsonResponse(w, http.StatusOK, newWorkoutResponse(s)); err != nil {
+		h.responseHelper.internalServerError(w, r, err)
+		return
+	}
+}
+
+// newWorkoutResponse converts a domain workout session to its response representation.
+func newWorkoutResponse(s *workout.Session) WorkoutResponse {
+	entries := make([]WorkoutEntryResponse, 0, len(s.GetEntries()))
+	for i, e := range s.GetEntries() {
+		sets := make([]WorkoutSetResponse, 0, len(e.GetSets()))
+		for _, set := range e.GetSets() {
+			sets = append(sets, WorkoutSetResponse{
+				ID:     set.GetID().String(),
+				Reps:   set.GetReps(),
+				Weight: set.GetWeight(),
+				Unit:   string(set.GetUnit()),
+				RPE:    set.GetRPE(),
+				RIR:    set.GetRIR(),
+				Warmup: set.IsWarmup(),
+			})
+		}
+
+		var target *WorkoutTargetResponse
+		if t := e.GetTarget(); t != nil {
+			target = &WorkoutTargetResponse{
+				Sets:    t.Sets,
+				MinReps: t.MinReps,
+				MaxReps: t.MaxReps,
+				Weight:  t.Weight,
+				Unit:    string(t.Unit),
+			}
+		}
+
+		entries = append(entries, WorkoutEntryResponse{
+			ID:         e.GetID().String(),
+			ExerciseID: e.GetExerciseID().String(),
+			Position:   i,
+			Target:     target,
+			Sets:       sets,
+		})
+	}
+
+	var finishedAt *time.Time
+	if s.IsFinished() {
+		t := s.GetFinishedAt()
+		finishedAt = &t
+	}
+
+	return WorkoutResponse{
+		ID:         s.GetID().String(),
+		Name:       s.GetName(),
+		Notes:      s.GetNotes(),
+		StartedAt:  s.GetStartedAt(),
+		FinishedAt: finishedAt,
+		Entries:    entries,
+	}
+}
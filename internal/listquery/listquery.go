@@ -0,0 +1,201 @@
+// Package listquery implements keyset pagination, sorting and name prefix filtering for list endpoints.
+//
+// A Query is parsed from the URL parameters sort, order, limit, cursor and name.
+// Repositories describe their sortable columns with a Spec, which turns the Query
+// into SQL, and collect the scanned rows into a Page that carries the cursor for the next page.
+package listquery
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Sort keys supported by every list endpoint
+const (
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+)
+
+const (
+	// DefaultLimit is the page size used when no limit is given
+	DefaultLimit = 50
+	// MaxLimit is the largest page size a client can ask for
+	MaxLimit = 200
+)
+
+var (
+	// ErrInvalidSort is returned when the sort key is not supported by the endpoint
+	ErrInvalidSort = errors.New("invalid sort field")
+	// ErrInvalidOrder is returned when the order is not asc or desc
+	ErrInvalidOrder = errors.New("order must be asc or desc")
+	// ErrInvalidLimit is returned when the limit is not a number between 1 and MaxLimit
+	ErrInvalidLimit = fmt.Errorf("limit must be between 1 and %d", MaxLimit)
+	// ErrInvalidCursor is returned when the cursor is malformed or was issued for a different sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Order is the direction of a sort
+type Order string
+
+const (
+	Asc  Order = "asc"
+	Desc Order = "desc"
+)
+
+// Query describes which page of a list to return
+type Query struct {
+	Sort       string
+	Order      Order
+	Limit      int
+	NamePrefix string
+
+	after *cursor
+}
+
+// cursor is the position of the last item of a page
+type cursor struct {
+	Sort  string    `json:"s"`
+	Order Order     `json:"o"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// Default returns the first page sorted by name
+func Default() Query {
+	return Query{Sort: SortName, Order: Asc, Limit: DefaultLimit}
+}
+
+// Parse reads a Query from URL parameters. sorts lists the sort keys the endpoint supports,
+// when it is empty only SortName and SortCreatedAt are allowed.
+func Parse(values url.Values, sorts ...string) (Query, error) {
+	if len(sorts) == 0 {
+		sorts = []string{SortName, SortCreatedAt}
+	}
+
+	q := Default()
+
+	if sort := values.Get("sort"); sort != "" {
+		if !contains(sorts, sort) {
+			return Query{}, ErrInvalidSort
+		}
+		q.Sort = sort
+	}
+
+	if order := values.Get("order"); order != "" {
+		switch Order(strings.ToLower(order)) {
+		case Asc:
+			q.Order = Asc
+		case Desc:
+			q.Order = Desc
+		default:
+			return Query{}, ErrInvalidOrder
+		}
+	}
+
+	if limit := values.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > MaxLimit {
+			return Query{}, ErrInvalidLimit
+		}
+		q.Limit = n
+	}
+
+	q.NamePrefix = values.Get("name")
+
+	if token := values.Get("cursor"); token != "" {
+		c, err := decodeCursor(token)
+		if err != nil || c.Sort != q.Sort || c.Order != q.Order {
+			return Query{}, ErrInvalidCursor
+		}
+		q.after = &c
+	}
+
+	return q, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(token string) (cursor, error) {
+	var c cursor
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(b, &c)
+	return c, err
+}
+
+// Page is one page of a list
+type Page[T any] struct {
+	Items []T
+	// NextCursor is empty on the last page
+	NextCursor string
+}
+
+// Collector gathers the rows of a query built by Spec.Build into a Page.
+// The query fetches one row more than the limit so the collector can tell whether another page follows.
+type Collector[T any] struct {
+	query Query
+	items []T
+	keys  []cursor
+}
+
+// NewCollector creates a collector for the rows of a query
+func NewCollector[T any](q Query) *Collector[T] {
+	return &Collector[T]{query: q, items: []T{}}
+}
+
+// Add records an item with its ID and the value of the column it is sorted by
+func (c *Collector[T]) Add(item T, id uuid.UUID, sortValue any) {
+	c.items = append(c.items, item)
+	c.keys = append(c.keys, cursor{
+		Sort:  c.query.Sort,
+		Order: c.query.Order,
+		Value: formatValue(sortValue),
+		ID:    id,
+	})
+}
+
+// Page returns the collected items, trimmed to the limit, with the cursor of the last one if more follow
+func (c *Collector[T]) Page() Page[T] {
+	if c.query.Limit <= 0 || len(c.items) <= c.query.Limit {
+		return Page[T]{Items: c.items}
+	}
+
+	last := c.query.Limit - 1
+	return Page[T]{
+		Items:      c.items[:c.query.Limit],
+		NextCursor: encodeCursor(c.keys[last]),
+	}
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
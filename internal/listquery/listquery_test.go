@@ -0,0 +1,136 @@
+package listquery_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/CP-Payne/exercise/internal/listquery"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var testSpec = listquery.Spec{
+	Sorts: map[string]listquery.Column{
+		listquery.SortName:      {Expr: "t.name", Type: "text"},
+		listquery.SortCreatedAt: {Expr: "t.created_at", Type: "timestamptz"},
+	},
+	ID:   "t.id",
+	Name: "t.name",
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		values        url.Values
+		expected      listquery.Query
+		expectedError error
+	}{
+		{
+			name:     "Defaults",
+			values:   url.Values{},
+			expected: listquery.Default(),
+		},
+		{
+			name:   "All parameters",
+			values: url.Values{"sort": {"created_at"}, "order": {"DESC"}, "limit": {"10"}, "name": {"Bi"}},
+			expected: listquery.Query{
+				Sort:       listquery.SortCreatedAt,
+				Order:      listquery.Desc,
+				Limit:      10,
+				NamePrefix: "Bi",
+			},
+		},
+		{
+			name:          "Unknown sort",
+			values:        url.Values{"sort": {"id"}},
+			expectedError: listquery.ErrInvalidSort,
+		},
+		{
+			name:          "Unknown order",
+			values:        url.Values{"order": {"up"}},
+			expectedError: listquery.ErrInvalidOrder,
+		},
+		{
+			name:          "Limit too large",
+			values:        url.Values{"limit": {"1000"}},
+			expectedError: listquery.ErrInvalidLimit,
+		},
+		{
+			name:          "Limit not a number",
+			values:        url.Values{"limit": {"ten"}},
+			expectedError: listquery.ErrInvalidLimit,
+		},
+		{
+			name:          "Malformed cursor",
+			values:        url.Values{"cursor": {"!!"}},
+			expectedError: listquery.ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q, err := listquery.Parse(tc.values)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, q)
+			}
+		})
+	}
+}
+
+func TestSpec_Build(t *testing.T) {
+	q := listquery.Default()
+	q.Limit = 2
+	q.NamePrefix = "50%"
+
+	query, args, err := testSpec.Build(q, "SELECT t.id FROM t WHERE t.user_id = $1", "user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT t.id FROM t WHERE t.user_id = $1 AND lower(t.name) LIKE lower($2) || '%' ORDER BY t.name ASC, t.id ASC LIMIT $3", query)
+	assert.Equal(t, []any{"user", `50\%`, 3}, args)
+
+	_, _, err = testSpec.Build(listquery.Query{Sort: "id"}, "SELECT 1 WHERE true")
+	assert.Equal(t, listquery.ErrInvalidSort, err)
+}
+
+func TestCollector_Paginates(t *testing.T) {
+	q, _ := listquery.Parse(url.Values{"sort": {"created_at"}, "order": {"desc"}, "limit": {"2"}})
+
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	c := listquery.NewCollector[string](q)
+	c.Add("a", ids[0], created)
+	c.Add("b", ids[1], created.Add(-time.Hour))
+	c.Add("c", ids[2], created.Add(-2*time.Hour))
+
+	page := c.Page()
+	assert.Equal(t, []string{"a", "b"}, page.Items)
+	assert.NotEmpty(t, page.NextCursor)
+
+	t.Run("Next page continues after the last item", func(t *testing.T) {
+		next, err := listquery.Parse(url.Values{"sort": {"created_at"}, "order": {"desc"}, "limit": {"2"}, "cursor": {page.NextCursor}})
+		assert.NoError(t, err)
+
+		query, args, err := testSpec.Build(next, "SELECT t.id FROM t WHERE true")
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT t.id FROM t WHERE true AND (t.created_at, t.id) < ($1::timestamptz, $2::uuid) ORDER BY t.created_at DESC, t.id DESC LIMIT $3", query)
+		assert.Equal(t, []any{"2024-05-01T09:00:00Z", ids[1], 3}, args)
+	})
+
+	t.Run("Cursor is bound to its sort", func(t *testing.T) {
+		_, err := listquery.Parse(url.Values{"sort": {"name"}, "cursor": {page.NextCursor}})
+		assert.Equal(t, listquery.ErrInvalidCursor, err)
+	})
+
+	t.Run("Last page has no cursor", func(t *testing.T) {
+		c := listquery.NewCollector[string](q)
+		c.Add("a", ids[0], created)
+
+		assert.Empty(t, c.Page().NextCursor)
+	})
+}
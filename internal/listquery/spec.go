@@ -0,0 +1,66 @@
+package listquery
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Column is a sortable SQL expression and the postgres type its cursor value is cast to
+type Column struct {
+	Expr string
+	Type string
+}
+
+// Spec describes how the rows of a table are listed
+type Spec struct {
+	// Sorts maps every supported sort key to its column
+	Sorts map[string]Column
+	// ID is the unique column used to break ties between rows with the same sort value
+	ID string
+	// Name is the column matched by the name prefix filter
+	Name string
+}
+
+// Build appends the name filter, keyset condition, ordering and limit of q to query.
+// query must end in a WHERE clause, its conditions are combined with AND so OR conditions
+// need parentheses. args are the arguments already used by query.
+func (s Spec) Build(q Query, query string, args ...any) (string, []any, error) {
+	col, ok := s.Sorts[q.Sort]
+	if !ok {
+		return "", nil, ErrInvalidSort
+	}
+
+	var b strings.Builder
+	b.WriteString(query)
+
+	if q.NamePrefix != "" {
+		args = append(args, escapeLike(q.NamePrefix))
+		fmt.Fprintf(&b, " AND lower(%s) LIKE lower($%d) || '%%'", s.Name, len(args))
+	}
+
+	direction, compare := "ASC", ">"
+	if q.Order == Desc {
+		direction, compare = "DESC", "<"
+	}
+
+	if q.after != nil {
+		args = append(args, q.after.Value, q.after.ID)
+		fmt.Fprintf(&b, " AND (%s, %s) %s ($%d::%s, $%d::uuid)",
+			col.Expr, s.ID, compare, len(args)-1, col.Type, len(args))
+	}
+
+	fmt.Fprintf(&b, " ORDER BY %s %s, %s %s", col.Expr, direction, s.ID, direction)
+
+	if q.Limit > 0 {
+		// One extra row tells the collector whether another page follows
+		args = append(args, q.Limit+1)
+		fmt.Fprintf(&b, " LIMIT $%d", len(args))
+	}
+
+	return b.String(), args, nil
+}
+
+// escapeLike escapes the LIKE wildcards so a prefix only matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}